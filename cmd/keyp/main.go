@@ -1,33 +1,66 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/luiz-simples/keyp.git/internal/app"
+	"github.com/luiz-simples/keyp.git/internal/domain"
 	"github.com/luiz-simples/keyp.git/internal/service"
 	"github.com/luiz-simples/keyp.git/internal/storage"
 )
 
 func main() {
-	config := app.Config{
-		Address: "0.0.0.0:6379",
-		DataDir: "./data",
+	config, err := app.LoadConfig(os.Args[1:], os.LookupEnv)
+
+	if hasError(err) {
+		log.Fatal(err)
+	}
+
+	lmdb, err := storage.NewClientWithOptions(config.DataDir, config.StorageOptions())
+
+	if hasError(err) {
+		log.Fatal(err)
 	}
 
-	lmdb, err := storage.NewClient(config.DataDir)
+	registry := app.NewRegistry(config)
+	registry.Watch(func(config app.Config) error {
+		return lmdb.Configure(config.StorageOptions())
+	})
 
-	if noError(err) {
-		poolService := service.NewPool(lmdb)
-		server := app.NewServer(poolService)
-		defer server.Close()
-		err = server.Start(config)
+	stats := domain.NewStats()
+	poolService := service.NewPool(lmdb, registry, stats)
+	server := app.NewServer(poolService, registry, stats, lmdb)
+	go shutdownOnSignal(server, registry)
+	err = server.Start()
+
+	// Shutdown leaves the storage open when commands outlive its deadline.
+	if !errors.Is(err, context.DeadlineExceeded) {
+		lmdb.Close()
 	}
 
-	if err != nil {
+	if hasError(err) {
 		log.Fatal(err)
 	}
 }
 
-func noError(err error) bool {
-	return err == nil
+func shutdownOnSignal(server *app.Server, registry *app.Registry) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	<-signals
+
+	ctx, cancel := context.WithTimeout(context.Background(), registry.Config().ShutdownPeriod())
+	defer cancel()
+
+	if err := server.Shutdown(ctx, true); err != nil {
+		log.Println(err)
+	}
+}
+
+func hasError(err error) bool {
+	return err != nil
 }
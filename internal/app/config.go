@@ -0,0 +1,351 @@
+package app
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+	"github.com/luiz-simples/keyp.git/internal/storage"
+)
+
+const (
+	defaultAddress    = "0.0.0.0:6379"
+	defaultDataDir    = "./data"
+	defaultMaxClients = 10000
+	defaultShutdown   = 10
+	configFlag        = "config"
+	envPrefix         = "KEYP_"
+)
+
+var ErrConfigValue = errors.New("invalid config value")
+
+type (
+	Config struct {
+		Address          string
+		DataDir          string
+		MapSize          int64
+		MaxReaders       int
+		Databases        int
+		SyncMode         string
+		MaxClients       int
+		MaxPackedEntries int64
+		MaxBatchSize     int
+		MaxBatchWait     int64
+		MetricsAddress   string
+		ShutdownTimeout  int
+		ConfigFile       string
+	}
+
+	LookupEnv func(string) (string, bool)
+
+	setting struct {
+		name    string
+		usage   string
+		mutable bool
+		apply   func(*Config, string) error
+		get     func(Config) string
+	}
+)
+
+var settings = []setting{
+	{name: "address", usage: "listen address", apply: func(config *Config, value string) error {
+		config.Address = value
+		return nil
+	}, get: func(config Config) string {
+		return config.Address
+	}},
+	{name: "data-dir", usage: "LMDB data directory", apply: func(config *Config, value string) error {
+		config.DataDir = value
+		return nil
+	}, get: func(config Config) string {
+		return config.DataDir
+	}},
+	{name: "map-size", usage: "LMDB map size (e.g. 4gb)", apply: func(config *Config, value string) error {
+		size, err := parseMemory(value)
+		config.MapSize = size
+		return err
+	}, get: func(config Config) string {
+		return strconv.FormatInt(config.MapSize, 10)
+	}},
+	{name: "max-readers", usage: "maximum concurrent LMDB readers", apply: func(config *Config, value string) error {
+		readers, err := parsePositive(value, 0)
+		config.MaxReaders = readers
+		return err
+	}, get: func(config Config) string {
+		return strconv.Itoa(config.MaxReaders)
+	}},
+	{name: "databases", usage: "number of logical databases", apply: func(config *Config, value string) error {
+		databases, err := parsePositive(value, storage.MaxDatabases)
+		config.Databases = databases
+		return err
+	}, get: func(config Config) string {
+		return strconv.Itoa(config.Databases)
+	}},
+	{name: "sync-mode", usage: "LMDB sync mode (always|everysec|no)", mutable: true, apply: func(config *Config, value string) error {
+		mode := strings.ToLower(value)
+
+		if mode != domain.SyncAlways && mode != domain.SyncEverySec && mode != domain.SyncNo {
+			return ErrConfigValue
+		}
+
+		config.SyncMode = mode
+		return nil
+	}, get: func(config Config) string {
+		return config.SyncMode
+	}},
+	{name: "max-clients", usage: "maximum connected clients", mutable: true, apply: func(config *Config, value string) error {
+		clients, err := parsePositive(value, 0)
+		config.MaxClients = clients
+		return err
+	}, get: func(config Config) string {
+		return strconv.Itoa(config.MaxClients)
+	}},
+	{name: "max-packed-entries", usage: "largest collection kept in a single LMDB entry", mutable: true, apply: func(config *Config, value string) error {
+		entries, err := parseCount(value)
+		config.MaxPackedEntries = entries
+		return err
+	}, get: func(config Config) string {
+		return strconv.FormatInt(config.MaxPackedEntries, 10)
+	}},
+	{name: "max-batch-size", usage: "most writes committed together in one LMDB transaction", mutable: true, apply: func(config *Config, value string) error {
+		size, err := parsePositive(value, 0)
+		config.MaxBatchSize = size
+		return err
+	}, get: func(config Config) string {
+		return strconv.Itoa(config.MaxBatchSize)
+	}},
+	{name: "max-batch-wait", usage: "microseconds a write batch waits for more writes (0 takes only those already queued)", mutable: true, apply: func(config *Config, value string) error {
+		wait, err := parseCount(value)
+		config.MaxBatchWait = wait
+		return err
+	}, get: func(config Config) string {
+		return strconv.FormatInt(config.MaxBatchWait, 10)
+	}},
+	{name: "metrics-address", usage: "Prometheus metrics listen address (empty disables it)", apply: func(config *Config, value string) error {
+		config.MetricsAddress = value
+		return nil
+	}, get: func(config Config) string {
+		return config.MetricsAddress
+	}},
+	{name: "shutdown-timeout", usage: "seconds to let in-flight commands finish on shutdown", mutable: true, apply: func(config *Config, value string) error {
+		seconds, err := parsePositive(value, 0)
+		config.ShutdownTimeout = seconds
+		return err
+	}, get: func(config Config) string {
+		return strconv.Itoa(config.ShutdownTimeout)
+	}},
+}
+
+func DefaultConfig() Config {
+	options := storage.DefaultOptions()
+
+	return Config{
+		Address:          defaultAddress,
+		DataDir:          defaultDataDir,
+		MapSize:          options.MapSize,
+		MaxReaders:       options.MaxReaders,
+		Databases:        options.Databases,
+		SyncMode:         options.SyncMode,
+		MaxClients:       defaultMaxClients,
+		MaxPackedEntries: options.MaxPackedEntries,
+		MaxBatchSize:     int(options.MaxBatchSize),
+		MaxBatchWait:     options.MaxBatchWait.Microseconds(),
+		ShutdownTimeout:  defaultShutdown,
+	}
+}
+
+func LoadConfig(args []string, lookup LookupEnv) (Config, error) {
+	config := DefaultConfig()
+	flags := flag.NewFlagSet("keyp", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	configFile := flags.String(configFlag, "", "path to a redis.conf style config file")
+	values := make(map[string]*string, len(settings))
+
+	for _, item := range settings {
+		values[item.name] = flags.String(item.name, "", item.usage)
+	}
+
+	if err := flags.Parse(args); hasError(err) {
+		return config, err
+	}
+
+	config.ConfigFile = *configFile
+
+	if path, found := lookup(envName(configFlag)); found && config.ConfigFile == "" {
+		config.ConfigFile = path
+	}
+
+	if config.ConfigFile == "" && flags.NArg() > 0 {
+		config.ConfigFile = flags.Arg(0)
+	}
+
+	if config.ConfigFile != "" {
+		if err := config.loadFile(config.ConfigFile); hasError(err) {
+			return config, err
+		}
+	}
+
+	for _, item := range settings {
+		if value, found := lookup(envName(item.name)); found {
+			if err := applySetting(&config, item, value); hasError(err) {
+				return config, err
+			}
+		}
+	}
+
+	var err error
+
+	flags.Visit(func(visited *flag.Flag) {
+		item, found := findSetting(visited.Name)
+
+		if found && noError(err) {
+			err = applySetting(&config, item, *values[item.name])
+		}
+	})
+
+	return config, err
+}
+
+func (config Config) StorageOptions() storage.Options {
+	return storage.Options{
+		MapSize:          config.MapSize,
+		MaxReaders:       config.MaxReaders,
+		Databases:        config.Databases,
+		SyncMode:         config.SyncMode,
+		MaxPackedEntries: config.MaxPackedEntries,
+		MaxBatchSize:     int64(config.MaxBatchSize),
+		MaxBatchWait:     time.Duration(config.MaxBatchWait) * time.Microsecond,
+	}
+}
+
+func (config Config) ShutdownPeriod() time.Duration {
+	return time.Duration(config.ShutdownTimeout) * time.Second
+}
+
+func (config *Config) loadFile(path string) error {
+	file, err := os.Open(path)
+	if hasError(err) {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		name, value, found := parseDirective(scanner.Text())
+
+		if !found {
+			continue
+		}
+
+		item, known := findSetting(name)
+
+		if !known {
+			return fmt.Errorf("%s:%d: unknown directive '%s'", path, line, name)
+		}
+
+		if err = applySetting(config, item, value); hasError(err) {
+			return fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func parseDirective(line string) (string, string, bool) {
+	line = strings.TrimSpace(line)
+
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+
+	name, value, _ := strings.Cut(line, " ")
+	value = strings.TrimSpace(value)
+
+	if unquoted, err := strconv.Unquote(value); noError(err) {
+		value = unquoted
+	}
+
+	return strings.ToLower(name), value, true
+}
+
+func findSetting(name string) (setting, bool) {
+	for _, item := range settings {
+		if item.name == name {
+			return item, true
+		}
+	}
+
+	return setting{}, false
+}
+
+func applySetting(config *Config, item setting, value string) error {
+	if err := item.apply(config, value); hasError(err) {
+		return fmt.Errorf("%s '%s': %w", item.name, value, err)
+	}
+
+	return nil
+}
+
+func envName(name string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+func parsePositive(value string, limit int) (int, error) {
+	number, err := strconv.Atoi(value)
+
+	if hasError(err) || number < 1 || (limit > 0 && number > limit) {
+		return 0, ErrConfigValue
+	}
+
+	return number, nil
+}
+
+func parseCount(value string) (int64, error) {
+	count, err := strconv.ParseInt(value, 10, 64)
+
+	if hasError(err) || count < 0 {
+		return 0, ErrConfigValue
+	}
+
+	return count, nil
+}
+
+func parseMemory(value string) (int64, error) {
+	units := []struct {
+		suffix string
+		factor int64
+	}{
+		{"gb", 1 << 30}, {"mb", 1 << 20}, {"kb", 1 << 10},
+		{"g", 1000 * 1000 * 1000}, {"m", 1000 * 1000}, {"k", 1000}, {"b", 1},
+	}
+
+	value = strings.ToLower(strings.TrimSpace(value))
+	factor := int64(1)
+
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSuffix(value, unit.suffix)
+			factor = unit.factor
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+
+	if hasError(err) || size < 1 {
+		return 0, ErrConfigValue
+	}
+
+	return size * factor, nil
+}
@@ -0,0 +1,150 @@
+package app_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/luiz-simples/keyp.git/internal/app"
+)
+
+var _ = Describe("LoadConfig", func() {
+	var (
+		env     map[string]string
+		lookup  app.LookupEnv
+		tempDir string
+	)
+
+	writeConfig := func(content string) string {
+		path := filepath.Join(tempDir, "keyp.conf")
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		env = map[string]string{}
+		lookup = func(name string) (string, bool) {
+			value, found := env[name]
+			return value, found
+		}
+		tempDir = GinkgoT().TempDir()
+	})
+
+	It("should fall back to the defaults", func() {
+		config, err := app.LoadConfig(nil, lookup)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(Equal(app.DefaultConfig()))
+		Expect(config.Address).To(Equal("0.0.0.0:6379"))
+		Expect(config.DataDir).To(Equal("./data"))
+		Expect(config.SyncMode).To(Equal("everysec"))
+	})
+
+	It("should read a redis.conf style file", func() {
+		path := writeConfig(`
+# keyp settings
+address 127.0.0.1:7000
+data-dir "/var/lib/keyp"
+map-size 512mb
+max-readers 64
+databases 16
+sync-mode always
+`)
+
+		config, err := app.LoadConfig([]string{"--config", path}, lookup)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.Address).To(Equal("127.0.0.1:7000"))
+		Expect(config.DataDir).To(Equal("/var/lib/keyp"))
+		Expect(config.MapSize).To(Equal(int64(512 << 20)))
+		Expect(config.MaxReaders).To(Equal(64))
+		Expect(config.Databases).To(Equal(16))
+		Expect(config.SyncMode).To(Equal("always"))
+		Expect(config.ConfigFile).To(Equal(path))
+	})
+
+	It("should accept the config file as a positional argument or KEYP_CONFIG", func() {
+		path := writeConfig("databases 8\n")
+
+		config, err := app.LoadConfig([]string{path}, lookup)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.Databases).To(Equal(8))
+
+		env["KEYP_CONFIG"] = path
+		config, err = app.LoadConfig(nil, lookup)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.Databases).To(Equal(8))
+	})
+
+	It("should let environment variables override the file and flags override both", func() {
+		path := writeConfig("address 127.0.0.1:7000\ndata-dir /from/file\nmap-size 1gb\n")
+		env["KEYP_ADDRESS"] = "127.0.0.1:7001"
+		env["KEYP_DATA_DIR"] = "/from/env"
+		env["KEYP_MAP_SIZE"] = "2gb"
+
+		config, err := app.LoadConfig([]string{"-config", path, "-data-dir", "/from/flag"}, lookup)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.Address).To(Equal("127.0.0.1:7001"))
+		Expect(config.DataDir).To(Equal("/from/flag"))
+		Expect(config.MapSize).To(Equal(int64(2 << 30)))
+	})
+
+	It("should accept every durability mode", func() {
+		for _, mode := range []string{"always", "everysec", "no"} {
+			env["KEYP_SYNC_MODE"] = mode
+
+			config, err := app.LoadConfig(nil, lookup)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.SyncMode).To(Equal(mode))
+		}
+	})
+
+	It("should reject invalid values", func() {
+		_, err := app.LoadConfig([]string{"-databases", "0"}, lookup)
+		Expect(err).To(MatchError(app.ErrConfigValue))
+
+		_, err = app.LoadConfig([]string{"-databases", "257"}, lookup)
+		Expect(err).To(MatchError(app.ErrConfigValue))
+
+		env["KEYP_SYNC_MODE"] = "sometimes"
+		_, err = app.LoadConfig(nil, lookup)
+		Expect(err).To(MatchError(app.ErrConfigValue))
+
+		_, err = app.LoadConfig([]string{"-unknown", "1"}, lookup)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should report unknown directives with their line", func() {
+		path := writeConfig("databases 4\nappendonly yes\n")
+
+		_, err := app.LoadConfig([]string{"-config", path}, lookup)
+
+		Expect(err).To(MatchError(ContainSubstring(":2: unknown directive 'appendonly'")))
+	})
+
+	It("should fail when the config file does not exist", func() {
+		_, err := app.LoadConfig([]string{"-config", filepath.Join(tempDir, "missing.conf")}, lookup)
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should convert to storage options", func() {
+		config := app.DefaultConfig()
+		config.Databases = 4
+		config.MaxBatchSize = 32
+		config.MaxBatchWait = 250
+
+		options := config.StorageOptions()
+
+		Expect(options.Databases).To(Equal(4))
+		Expect(options.MaxBatchSize).To(Equal(int64(32)))
+		Expect(options.MaxBatchWait).To(Equal(250 * time.Microsecond))
+		Expect(options.MapSize).To(Equal(config.MapSize))
+		Expect(options.SyncMode).To(Equal(config.SyncMode))
+	})
+})
@@ -0,0 +1,154 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+const (
+	metricsPath        = "/metrics"
+	metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+	metricsTimeout     = 5 * time.Second
+)
+
+type metrics struct {
+	stats  *domain.Stats
+	engine domain.Engine
+}
+
+func (exporter *metrics) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	info, err := exporter.engine.Info(request.Context())
+
+	if hasError(err) {
+		http.Error(writer, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	writer.Header().Set("Content-Type", metricsContentType)
+	exporter.write(writer, info)
+}
+
+func (exporter *metrics) write(out io.Writer, info domain.StorageInfo) {
+	stats := exporter.stats
+	commands := stats.Commands()
+
+	family(out, "keyp_uptime_seconds", "gauge", "Seconds since the server started.")
+	sample(out, "keyp_uptime_seconds", "", time.Since(stats.Started()).Seconds())
+
+	family(out, "keyp_connected_clients", "gauge", "Clients currently connected.")
+	sample(out, "keyp_connected_clients", "", float64(stats.Clients()))
+
+	family(out, "keyp_connections_received_total", "counter", "Connections accepted by the server.")
+	sample(out, "keyp_connections_received_total", "", float64(stats.Connections()))
+
+	family(out, "keyp_rejected_connections_total", "counter", "Connections refused because of max-clients.")
+	sample(out, "keyp_rejected_connections_total", "", float64(stats.RefusedConnections()))
+
+	family(out, "keyp_commands_total", "counter", "Commands processed by command name.")
+	for _, command := range commands {
+		sample(out, "keyp_commands_total", commandLabel(command), float64(command.Calls))
+	}
+
+	family(out, "keyp_command_errors_total", "counter", "Commands that replied with an error by command name.")
+	for _, command := range commands {
+		sample(out, "keyp_command_errors_total", commandLabel(command), float64(command.Failed))
+	}
+
+	family(out, "keyp_command_rejected_total", "counter", "Commands rejected before running by command name.")
+	for _, command := range commands {
+		sample(out, "keyp_command_rejected_total", commandLabel(command), float64(command.Rejected))
+	}
+
+	family(out, "keyp_command_duration_seconds", "histogram", "Command latency measured around Handler.Apply.")
+	for _, command := range commands {
+		histogram(out, "keyp_command_duration_seconds", command)
+	}
+
+	family(out, "keyp_lmdb_map_size_bytes", "gauge", "Size of the LMDB memory map.")
+	sample(out, "keyp_lmdb_map_size_bytes", "", float64(info.MapSize))
+
+	family(out, "keyp_lmdb_used_pages", "gauge", "LMDB pages in use.")
+	sample(out, "keyp_lmdb_used_pages", "", float64(info.UsedPages))
+
+	family(out, "keyp_lmdb_page_size_bytes", "gauge", "Size of an LMDB page.")
+	sample(out, "keyp_lmdb_page_size_bytes", "", float64(info.PageSize))
+
+	family(out, "keyp_lmdb_readers", "gauge", "LMDB reader slots in use.")
+	sample(out, "keyp_lmdb_readers", "", float64(info.Readers))
+
+	family(out, "keyp_lmdb_max_readers", "gauge", "Maximum LMDB reader slots.")
+	sample(out, "keyp_lmdb_max_readers", "", float64(info.MaxReaders))
+
+	family(out, "keyp_lmdb_last_sync_timestamp_seconds", "gauge", "Unix time of the last successful LMDB sync.")
+	sample(out, "keyp_lmdb_last_sync_timestamp_seconds", "", float64(info.LastSync.Unix()))
+
+	family(out, "keyp_expired_keys_total", "counter", "Keys deleted because their TTL elapsed.")
+	sample(out, "keyp_expired_keys_total", "", float64(info.ExpiredKeys))
+
+	family(out, "keyp_evicted_keys_total", "counter", "Keys evicted to free memory, always 0 since a full LMDB map fails writes instead.")
+	sample(out, "keyp_evicted_keys_total", "", 0)
+
+	family(out, "keyp_expire_cycles_total", "counter", "Active expiry cycles run.")
+	sample(out, "keyp_expire_cycles_total", "", float64(info.ExpireRuns))
+
+	family(out, "keyp_expire_cycle_seconds_total", "counter", "Time spent in active expiry cycles.")
+	sample(out, "keyp_expire_cycle_seconds_total", "", info.ExpireTime.Seconds())
+
+	family(out, "keyp_expire_cycle_last_seconds", "gauge", "Duration of the last active expiry cycle.")
+	sample(out, "keyp_expire_cycle_last_seconds", "", info.ExpireLast.Seconds())
+
+	family(out, "keyp_keys", "gauge", "Keys stored per database.")
+	for _, keyspace := range info.Keyspaces {
+		sample(out, "keyp_keys", databaseLabel(keyspace), float64(keyspace.Keys))
+	}
+
+	family(out, "keyp_expiring_keys", "gauge", "Keys with a TTL per database.")
+	for _, keyspace := range info.Keyspaces {
+		sample(out, "keyp_expiring_keys", databaseLabel(keyspace), float64(keyspace.Expires))
+	}
+}
+
+func histogram(out io.Writer, name string, command domain.CommandStat) {
+	label := commandLabel(command)
+	var count int64
+
+	for index, bound := range domain.LatencyBuckets {
+		count += command.Latency[index]
+		sample(out, name+"_bucket", label+`,le="`+formatMetric(bound.Seconds())+`"`, float64(count))
+	}
+
+	count += command.Latency[len(domain.LatencyBuckets)]
+	sample(out, name+"_bucket", label+`,le="+Inf"`, float64(count))
+	sample(out, name+"_sum", label, float64(command.Usec)/float64(time.Second/time.Microsecond))
+	sample(out, name+"_count", label, float64(count))
+}
+
+func family(out io.Writer, name, kind, help string) {
+	fmt.Fprintf(out, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func sample(out io.Writer, name, labels string, value float64) {
+	if labels != "" {
+		name += "{" + labels + "}"
+	}
+
+	fmt.Fprintf(out, "%s %s\n", name, formatMetric(value))
+}
+
+func commandLabel(command domain.CommandStat) string {
+	return `command="` + strings.ToLower(command.Name) + `"`
+}
+
+func databaseLabel(keyspace domain.KeyspaceInfo) string {
+	return `db="` + strconv.Itoa(int(keyspace.DB)) + `"`
+}
+
+func formatMetric(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,165 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/luiz-simples/keyp.git/internal/app"
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+var _ = Describe("Metrics", func() {
+	var (
+		ctrl       *gomock.Controller
+		mockEngine *MockEngine
+		stats      *domain.Stats
+		server     *app.Server
+		metricsURL string
+	)
+
+	freeAddress := func() string {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		defer listener.Close()
+		return listener.Addr().String()
+	}
+
+	scrape := func() (int, string) {
+		response, err := http.Get(metricsURL)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		body, err := io.ReadAll(response.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return response.StatusCode, string(body)
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockEngine = NewMockEngine(ctrl)
+		stats = domain.NewStats()
+
+		config := app.DefaultConfig()
+		config.Address = freeAddress()
+		config.MetricsAddress = freeAddress()
+		metricsURL = "http://" + config.MetricsAddress + "/metrics"
+
+		server = app.NewServer(NewMockLogicaler(ctrl), app.NewRegistry(config), stats, mockEngine)
+		go server.Start()
+
+		Eventually(func() error {
+			conn, err := net.DialTimeout("tcp", config.MetricsAddress, time.Second)
+			if err == nil {
+				conn.Close()
+			}
+			return err
+		}, "5s", "10ms").Should(Succeed())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		ctrl.Finish()
+	})
+
+	It("should export command, client and storage metrics", func() {
+		stats.Connected(2)
+		stats.Record("GET", 200*time.Microsecond, nil)
+		stats.Record("GET", 2*time.Millisecond, errors.New("ERR"))
+
+		mockEngine.EXPECT().Info(gomock.Any()).Return(domain.StorageInfo{
+			MapSize:     4096,
+			UsedPages:   3,
+			Readers:     1,
+			ExpiredKeys: 7,
+			ExpireRuns:  5,
+			LastSync:    time.Unix(1700000000, 0),
+			Keyspaces:   []domain.KeyspaceInfo{{DB: 1, Keys: 4, Expires: 2}},
+		}, nil)
+
+		status, body := scrape()
+
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(ContainSubstring("# TYPE keyp_command_duration_seconds histogram\n"))
+		Expect(body).To(ContainSubstring("keyp_connected_clients 2\n"))
+		Expect(body).To(ContainSubstring(`keyp_commands_total{command="get"} 2` + "\n"))
+		Expect(body).To(ContainSubstring(`keyp_command_errors_total{command="get"} 1` + "\n"))
+		Expect(body).To(ContainSubstring(`keyp_command_duration_seconds_bucket{command="get",le="0.00025"} 1` + "\n"))
+		Expect(body).To(ContainSubstring(`keyp_command_duration_seconds_bucket{command="get",le="0.0025"} 2` + "\n"))
+		Expect(body).To(ContainSubstring(`keyp_command_duration_seconds_bucket{command="get",le="+Inf"} 2` + "\n"))
+		Expect(body).To(ContainSubstring(`keyp_command_duration_seconds_sum{command="get"} 0.0022` + "\n"))
+		Expect(body).To(ContainSubstring("keyp_lmdb_map_size_bytes 4096\n"))
+		Expect(body).To(ContainSubstring("keyp_lmdb_used_pages 3\n"))
+		Expect(body).To(ContainSubstring("keyp_lmdb_last_sync_timestamp_seconds 1.7e+09\n"))
+		Expect(body).To(ContainSubstring("keyp_expired_keys_total 7\n"))
+		Expect(body).To(ContainSubstring("keyp_evicted_keys_total 0\n"))
+		Expect(body).To(ContainSubstring("keyp_expire_cycles_total 5\n"))
+		Expect(body).To(ContainSubstring(`keyp_keys{db="1"} 4` + "\n"))
+		Expect(body).To(ContainSubstring(`keyp_expiring_keys{db="1"} 2` + "\n"))
+	})
+
+	It("should stop serving metrics before closing the storage", func() {
+		var scrapeErr error
+
+		mockEngine.EXPECT().Close().Do(func() {
+			_, scrapeErr = http.Get(metricsURL)
+		})
+
+		Expect(server.Shutdown(context.Background(), false)).To(Succeed())
+		Expect(scrapeErr).To(HaveOccurred())
+	})
+
+	It("should wait for a running scrape before closing the storage", func() {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		closed := make(chan struct{})
+
+		mockEngine.EXPECT().Info(gomock.Any()).DoAndReturn(func(context.Context) (domain.StorageInfo, error) {
+			close(started)
+			<-release
+			return domain.StorageInfo{}, nil
+		})
+		mockEngine.EXPECT().Close().Do(func() { close(closed) })
+
+		go func() {
+			defer GinkgoRecover()
+			scrape()
+		}()
+		Eventually(started).Should(BeClosed())
+
+		go server.Shutdown(context.Background(), false)
+		Consistently(closed, 20*time.Millisecond).ShouldNot(BeClosed())
+
+		close(release)
+		Eventually(closed).Should(BeClosed())
+	})
+
+	It("should not serve when shut down before starting", func() {
+		config := app.DefaultConfig()
+		config.Address = freeAddress()
+		stopped := app.NewServer(NewMockLogicaler(ctrl), app.NewRegistry(config), stats, mockEngine)
+
+		mockEngine.EXPECT().Close()
+		Expect(stopped.Shutdown(context.Background(), false)).To(Succeed())
+		Expect(stopped.Start()).To(Succeed())
+
+		_, err := net.DialTimeout("tcp", config.Address, time.Second)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should answer 503 when storage cannot be read", func() {
+		mockEngine.EXPECT().Info(gomock.Any()).Return(domain.StorageInfo{}, errors.New("env closed"))
+
+		status, body := scrape()
+
+		Expect(status).To(Equal(http.StatusServiceUnavailable))
+		Expect(body).To(ContainSubstring("env closed"))
+	})
+})
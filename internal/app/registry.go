@@ -0,0 +1,183 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+const (
+	rewriteSuffix = ".rewrite"
+	configPerm    = 0o644
+)
+
+type (
+	Watcher func(Config) error
+
+	Registry struct {
+		config   Config
+		watchers []Watcher
+		mutex    sync.RWMutex
+	}
+)
+
+func NewRegistry(config Config) *Registry {
+	return &Registry{config: config}
+}
+
+func (registry *Registry) Config() Config {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	return registry.config
+}
+
+func (registry *Registry) Watch(watcher Watcher) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.watchers = append(registry.watchers, watcher)
+}
+
+func (registry *Registry) Get(patterns ...[]byte) [][]byte {
+	config := registry.Config()
+	pairs := make([][]byte, 0, len(settings)*pairSize)
+
+	for _, item := range settings {
+		if matchesAny(patterns, item.name) {
+			pairs = append(pairs, []byte(item.name), []byte(item.get(config)))
+		}
+	}
+
+	return pairs
+}
+
+func (registry *Registry) Set(pairs ...[]byte) error {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	candidate := registry.config
+	seen := make(map[string]bool, len(pairs)/pairSize)
+
+	for index := 0; index+1 < len(pairs); index += pairSize {
+		name := strings.ToLower(string(pairs[index]))
+		item, known := findSetting(name)
+
+		switch {
+		case !known:
+			return errors.New("ERR Unknown option or number of arguments for CONFIG SET - '" + name + "'")
+		case !item.mutable:
+			return newConfigSetError(name, "can't set immutable config")
+		case seen[name]:
+			return newConfigSetError(name, "duplicate parameter")
+		}
+
+		if err := item.apply(&candidate, string(pairs[index+1])); hasError(err) {
+			return newConfigSetError(name, "argument couldn't be parsed")
+		}
+
+		seen[name] = true
+	}
+
+	for _, watcher := range registry.watchers {
+		if err := watcher(candidate); hasError(err) {
+			registry.notify(registry.config)
+			return newConfigSetError(strings.ToLower(string(pairs[0])), err.Error())
+		}
+	}
+
+	registry.config = candidate
+	return nil
+}
+
+func (registry *Registry) Rewrite() error {
+	config := registry.Config()
+
+	if config.ConfigFile == "" {
+		return domain.ErrNoConfigFile
+	}
+
+	content, err := os.ReadFile(config.ConfigFile)
+
+	if hasError(err) && !os.IsNotExist(err) {
+		return newRewriteError(err)
+	}
+
+	temporary := config.ConfigFile + rewriteSuffix
+	err = os.WriteFile(temporary, []byte(rewriteConfig(string(content), config)), configPerm)
+
+	if noError(err) {
+		err = os.Rename(temporary, config.ConfigFile)
+	}
+
+	if hasError(err) {
+		os.Remove(temporary)
+		return newRewriteError(err)
+	}
+
+	return nil
+}
+
+func (registry *Registry) notify(config Config) {
+	for _, watcher := range registry.watchers {
+		_ = watcher(config)
+	}
+}
+
+func rewriteConfig(content string, config Config) string {
+	defaults := DefaultConfig()
+	written := make(map[string]bool, len(settings))
+	lines := make([]string, 0, len(settings))
+
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		name, _, found := parseDirective(line)
+		item, known := findSetting(name)
+
+		switch {
+		case !found || !known:
+			lines = append(lines, line)
+		case !written[name]:
+			lines = append(lines, formatDirective(item, config))
+			written[name] = true
+		}
+	}
+
+	for _, item := range settings {
+		if !written[item.name] && item.get(config) != item.get(defaults) {
+			lines = append(lines, formatDirective(item, config))
+		}
+	}
+
+	return strings.TrimLeft(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+func formatDirective(item setting, config Config) string {
+	value := item.get(config)
+
+	if value == "" || strings.ContainsAny(value, " \t\"#") {
+		value = strconv.Quote(value)
+	}
+
+	return item.name + " " + value
+}
+
+func matchesAny(patterns [][]byte, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(strings.ToLower(string(pattern)), name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newConfigSetError(name, reason string) error {
+	return errors.New("ERR CONFIG SET failed (possibly related to argument '" + name + "') - " + reason)
+}
+
+func newRewriteError(err error) error {
+	return errors.New("ERR Rewriting config file: " + err.Error())
+}
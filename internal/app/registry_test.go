@@ -0,0 +1,128 @@
+package app_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/luiz-simples/keyp.git/internal/app"
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+var _ = Describe("Registry", func() {
+	var (
+		config   app.Config
+		registry *app.Registry
+	)
+
+	pairsOf := func(values ...string) [][]byte {
+		pairs := make([][]byte, 0, len(values))
+
+		for _, value := range values {
+			pairs = append(pairs, []byte(value))
+		}
+
+		return pairs
+	}
+
+	BeforeEach(func() {
+		config = app.DefaultConfig()
+		registry = app.NewRegistry(config)
+	})
+
+	Describe("Get", func() {
+		It("should return the settings matching any glob pattern", func() {
+			Expect(registry.Get([]byte("databases"))).To(Equal(pairsOf("databases", "100")))
+			Expect(registry.Get([]byte("MAX-*"))).To(Equal(pairsOf(
+				"max-readers", "128",
+				"max-clients", "10000",
+				"max-packed-entries", "128",
+				"max-batch-size", "128",
+				"max-batch-wait", "0",
+			)))
+			Expect(registry.Get([]byte("unknown"))).To(BeEmpty())
+		})
+	})
+
+	Describe("Set", func() {
+		It("should apply mutable settings and notify watchers", func() {
+			var notified app.Config
+			registry.Watch(func(config app.Config) error {
+				notified = config
+				return nil
+			})
+
+			Expect(registry.Set(pairsOf("max-clients", "2", "SYNC-MODE", "always")...)).To(Succeed())
+
+			Expect(registry.Config().MaxClients).To(Equal(2))
+			Expect(registry.Config().SyncMode).To(Equal(domain.SyncAlways))
+			Expect(notified).To(Equal(registry.Config()))
+		})
+
+		It("should leave the config untouched when any pair is rejected", func() {
+			err := registry.Set(pairsOf("max-clients", "2", "databases", "4")...)
+			Expect(err).To(MatchError("ERR CONFIG SET failed (possibly related to argument 'databases') - can't set immutable config"))
+
+			err = registry.Set(pairsOf("max-clients", "2", "max-clients", "3")...)
+			Expect(err).To(MatchError(ContainSubstring("duplicate parameter")))
+
+			err = registry.Set(pairsOf("max-clients", "none")...)
+			Expect(err).To(MatchError(ContainSubstring("argument couldn't be parsed")))
+
+			err = registry.Set(pairsOf("appendonly", "yes")...)
+			Expect(err).To(MatchError("ERR Unknown option or number of arguments for CONFIG SET - 'appendonly'"))
+
+			Expect(registry.Config()).To(Equal(config))
+		})
+
+		It("should restore the previous config when a watcher fails", func() {
+			var applied []string
+			registry.Watch(func(config app.Config) error {
+				applied = append(applied, config.SyncMode)
+
+				if config.SyncMode == domain.SyncAlways {
+					return errors.New("sync failed")
+				}
+
+				return nil
+			})
+
+			err := registry.Set(pairsOf("sync-mode", "always")...)
+
+			Expect(err).To(MatchError(ContainSubstring("sync failed")))
+			Expect(registry.Config().SyncMode).To(Equal(domain.SyncEverySec))
+			Expect(applied).To(Equal([]string{domain.SyncAlways, domain.SyncEverySec}))
+		})
+	})
+
+	Describe("Rewrite", func() {
+		It("should fail without a config file", func() {
+			Expect(registry.Rewrite()).To(MatchError(domain.ErrNoConfigFile))
+		})
+
+		It("should update directives in place and append changed settings", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "keyp.conf")
+			Expect(os.WriteFile(path, []byte("# keyp\nmax-clients 50\ndatabases 16\n"), 0o644)).To(Succeed())
+
+			config.ConfigFile = path
+			config.Databases = 16
+			config.MaxClients = 50
+			registry = app.NewRegistry(config)
+
+			Expect(registry.Set(pairsOf("max-clients", "20", "max-packed-entries", "8")...)).To(Succeed())
+			Expect(registry.Rewrite()).To(Succeed())
+
+			content, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("# keyp\nmax-clients 20\ndatabases 16\nmax-packed-entries 8\n"))
+
+			loaded, err := app.LoadConfig([]string{"-config", path}, func(string) (string, bool) { return "", false })
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded.MaxClients).To(Equal(20))
+			Expect(loaded.MaxPackedEntries).To(Equal(int64(8)))
+		})
+	})
+})
@@ -2,7 +2,10 @@ package app
 
 import (
 	"context"
+	"net"
+	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tidwall/redcon"
@@ -13,33 +16,105 @@ import (
 type (
 	Server struct {
 		rcon     *redcon.Server
+		listener net.Listener
+		http     *http.Server
 		handlers map[int64]domain.Dispatcher
 		poolHdlr domain.Logicaler
+		registry *Registry
+		stats    *domain.Stats
+		engine   domain.Engine
+		inflight atomic.Int64
+		draining atomic.Bool
+		closing  sync.Once
+		done     chan struct{}
+		failure  error
 		mutex    sync.RWMutex
 	}
-
-	Config struct {
-		Address string
-		DataDir string
-	}
 )
 
-func NewServer(pool domain.Logicaler) *Server {
+func NewServer(pool domain.Logicaler, registry *Registry, stats *domain.Stats, engine domain.Engine) *Server {
 	return &Server{
 		handlers: make(map[int64]domain.Dispatcher),
 		poolHdlr: pool,
+		registry: registry,
+		stats:    stats,
+		engine:   engine,
+		done:     make(chan struct{}),
 	}
 }
 
-func (server *Server) Start(config Config) error {
+func (server *Server) Start() error {
+	config := server.registry.Config()
+
+	if config.MetricsAddress != "" {
+		if err := server.serveMetrics(config.MetricsAddress); hasError(err) {
+			return err
+		}
+	}
+
+	listener, err := net.Listen("tcp", config.Address)
+	if hasError(err) {
+		server.mutex.Lock()
+		server.stop()
+		server.mutex.Unlock()
+		return err
+	}
+
+	server.mutex.Lock()
+
+	if server.draining.Load() {
+		server.mutex.Unlock()
+		listener.Close()
+		<-server.done
+		return server.failure
+	}
+
+	server.listener = listener
 	server.rcon = redcon.NewServer(
 		config.Address,
 		server.OnHandler,
 		server.OnAccept,
 		server.OnClosed,
 	)
+	rcon := server.rcon
+	server.mutex.Unlock()
+
+	err = rcon.Serve(listener)
+
+	if server.draining.Load() {
+		<-server.done
+		return server.failure
+	}
+
+	server.mutex.Lock()
+	server.stop()
+	server.mutex.Unlock()
+
+	return err
+}
+
+func (server *Server) serveMetrics(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if hasError(err) {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, &metrics{stats: server.stats, engine: server.engine})
+
+	server.mutex.Lock()
+
+	if server.draining.Load() {
+		server.mutex.Unlock()
+		return listener.Close()
+	}
+
+	server.http = &http.Server{Handler: mux, ReadHeaderTimeout: metricsTimeout}
+	metricsServer := server.http
+	server.mutex.Unlock()
 
-	return server.rcon.ListenAndServe()
+	go metricsServer.Serve(listener)
+	return nil
 }
 
 func (server *Server) OnHandler(conn redcon.Conn, cmd redcon.Command) {
@@ -72,30 +147,24 @@ func (server *Server) OnHandler(conn redcon.Conn, cmd redcon.Command) {
 		return
 	}
 
-	results := handler.Apply(ctx, cmd.Args)
-
-	for _, item := range results {
-		if hasError(item.Error) {
-			conn.WriteError(item.Error.Error())
-			continue
-		}
+	if isShutdown(cmd.Args) && !handler.InMulti() {
+		server.shutdown(conn, cmd.Args)
+		return
+	}
 
-		if item.Response == nil {
-			conn.WriteNull()
-			continue
-		}
+	server.inflight.Add(1)
+	defer server.inflight.Add(-1)
 
-		if hasResponse(item.Response) {
-			if isArrayResponse(item.Response) {
-				conn.WriteRaw(item.Response)
-				continue
-			}
+	if server.draining.Load() {
+		conn.WriteError(domain.ErrShuttingDown.Error())
+		return
+	}
 
-			conn.WriteBulk(item.Response)
-			continue
-		}
+	results := handler.Apply(ctx, cmd.Args)
+	protocol := handler.Protocol()
 
-		conn.WriteNull()
+	for _, item := range results {
+		writeResult(conn, item, protocol)
 	}
 }
 
@@ -110,11 +179,25 @@ func (server *Server) OnAccept(conn redcon.Conn) bool {
 	ctx := context.WithValue(context.Background(), domain.ID, connID)
 	ctx = context.WithValue(ctx, domain.DB, uint8(0))
 
-	conn.SetContext(ctx)
+	maxClients := server.registry.Config().MaxClients
 
 	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if server.draining.Load() {
+		conn.WriteError(domain.ErrShuttingDown.Error())
+		return false
+	}
+
+	if len(server.handlers) >= maxClients {
+		server.stats.Refused()
+		conn.WriteError(domain.ErrMaxClients.Error())
+		return false
+	}
+
+	conn.SetContext(ctx)
 	server.handlers[connID] = server.poolHdlr.Get(ctx)
-	server.mutex.Unlock()
+	server.stats.Connected(len(server.handlers))
 
 	return true
 }
@@ -137,6 +220,7 @@ func (server *Server) OnClosed(conn redcon.Conn, err error) {
 		dispatcher := server.handlers[connID]
 		delete(server.handlers, connID)
 		server.poolHdlr.Free(dispatcher)
+		server.stats.Disconnected(len(server.handlers))
 	}
 }
 
@@ -149,9 +233,19 @@ func (server *Server) Close() {
 	}
 
 	server.handlers = make(map[int64]domain.Dispatcher)
+	server.stats.Disconnected(len(server.handlers))
+	server.stop()
+}
 
+func (server *Server) stop() {
 	if server.rcon != nil {
 		server.rcon.Close()
+		server.listener.Close()
 		server.rcon = nil
 	}
+
+	if server.http != nil {
+		server.http.Close()
+		server.http = nil
+	}
 }
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/luiz-simples/keyp.git/internal/app"
+	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
 var _ = Describe("Server Additional Tests", func() {
@@ -21,7 +22,7 @@ var _ = Describe("Server Additional Tests", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mockPool = NewMockLogicaler(ctrl)
-		server = app.NewServer(mockPool)
+		server = app.NewServer(mockPool, app.NewRegistry(app.DefaultConfig()), domain.NewStats(), nil)
 	})
 
 	AfterEach(func() {
@@ -30,7 +31,7 @@ var _ = Describe("Server Additional Tests", func() {
 
 	Describe("NewServer", func() {
 		It("should create a new server with the provided pool", func() {
-			newServer := app.NewServer(mockPool)
+			newServer := app.NewServer(mockPool, app.NewRegistry(app.DefaultConfig()), domain.NewStats(), nil)
 			Expect(newServer).NotTo(BeNil())
 		})
 	})
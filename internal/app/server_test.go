@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 
+	"github.com/tidwall/redcon"
 	"go.uber.org/mock/gomock"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/luiz-simples/keyp.git/internal/app"
+	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
 var _ = Describe("Server", func() {
@@ -24,7 +26,7 @@ var _ = Describe("Server", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mockPool = NewMockLogicaler(ctrl)
-		server = app.NewServer(mockPool)
+		server = app.NewServer(mockPool, app.NewRegistry(app.DefaultConfig()), domain.NewStats(), nil)
 		mockConn = NewMockConn(ctrl)
 		testError = errors.New("test error")
 	})
@@ -36,14 +38,14 @@ var _ = Describe("Server", func() {
 	Describe("NewServer", func() {
 		Context("when creating new server instance", func() {
 			It("should initialize server with provided pool", func() {
-				newServer := app.NewServer(mockPool)
+				newServer := app.NewServer(mockPool, app.NewRegistry(app.DefaultConfig()), domain.NewStats(), nil)
 
 				Expect(newServer).NotTo(BeNil())
 			})
 
 			It("should create server with different pool instances", func() {
 				mockPool2 := NewMockLogicaler(ctrl)
-				newServer := app.NewServer(mockPool2)
+				newServer := app.NewServer(mockPool2, app.NewRegistry(app.DefaultConfig()), domain.NewStats(), nil)
 
 				Expect(newServer).NotTo(BeNil())
 			})
@@ -90,6 +92,20 @@ var _ = Describe("Server", func() {
 				Expect(result2).To(BeTrue())
 			})
 
+			It("should refuse connections beyond max-clients", func() {
+				config := app.DefaultConfig()
+				config.MaxClients = 1
+				server = app.NewServer(mockPool, app.NewRegistry(config), domain.NewStats(), nil)
+
+				mockPool.EXPECT().Get(gomock.Any()).Return(NewMockDispatcher(ctrl)).Times(1)
+				mockConn.EXPECT().SetContext(gomock.Any()).Times(1)
+				Expect(server.OnAccept(mockConn)).To(BeTrue())
+
+				refused := NewMockConn(ctrl)
+				refused.EXPECT().WriteError("ERR max number of clients reached").Times(1)
+				Expect(server.OnAccept(refused)).To(BeFalse())
+			})
+
 			It("should generate unique connection contexts", func() {
 				connections := 5
 
@@ -106,6 +122,110 @@ var _ = Describe("Server", func() {
 		})
 	})
 
+	Describe("OnHandler", func() {
+		var (
+			mockDispatcher *MockDispatcher
+			protocol       int
+		)
+
+		BeforeEach(func() {
+			mockDispatcher = NewMockDispatcher(ctrl)
+			protocol = domain.RESP2
+			mockDispatcher.EXPECT().Protocol().DoAndReturn(func() int { return protocol }).AnyTimes()
+
+			mockPool.EXPECT().Get(gomock.Any()).Return(mockDispatcher).Times(1)
+			mockConn.EXPECT().SetContext(gomock.Any()).Do(func(ctx context.Context) {
+				mockConn.EXPECT().Context().Return(ctx).AnyTimes()
+			}).Times(1)
+			server.OnAccept(mockConn)
+		})
+
+		Context("when writing typed replies", func() {
+			It("should write bulk values verbatim even when they look like arrays", func() {
+				mockDispatcher.EXPECT().Apply(gomock.Any(), gomock.Any()).
+					Return(domain.Results{{Response: []byte("*1\r\n")}})
+				mockConn.EXPECT().WriteBulk([]byte("*1\r\n")).Times(1)
+
+				server.OnHandler(mockConn, redcon.Command{Args: [][]byte{[]byte("GET"), []byte("key")}})
+			})
+
+			It("should write integers, simple strings and nulls by kind", func() {
+				mockDispatcher.EXPECT().Apply(gomock.Any(), gomock.Any()).
+					Return(domain.Results{
+						domain.NewResult().SetInteger(42),
+						domain.NewResult().SetOK(),
+						domain.NewResult().SetNil(),
+						domain.NewResult().SetNullArray(),
+						{Error: testError},
+					})
+
+				gomock.InOrder(
+					mockConn.EXPECT().WriteInt64(int64(42)),
+					mockConn.EXPECT().WriteString("OK"),
+					mockConn.EXPECT().WriteNull(),
+					mockConn.EXPECT().WriteRaw([]byte("*-1\r\n")),
+					mockConn.EXPECT().WriteError("test error"),
+				)
+
+				server.OnHandler(mockConn, redcon.Command{Args: [][]byte{[]byte("EXEC")}})
+			})
+
+			It("should write nested arrays recursively", func() {
+				nested := domain.NewResult().SetArray([][]byte{[]byte("a"), nil})
+				reply := domain.NewResult().SetItems(domain.ReplyArray, domain.Results{
+					domain.NewResult().SetInteger(1),
+					nested,
+				})
+
+				mockDispatcher.EXPECT().Apply(gomock.Any(), gomock.Any()).Return(domain.Results{reply})
+
+				gomock.InOrder(
+					mockConn.EXPECT().WriteArray(2),
+					mockConn.EXPECT().WriteInt64(int64(1)),
+					mockConn.EXPECT().WriteArray(2),
+					mockConn.EXPECT().WriteBulk([]byte("a")),
+					mockConn.EXPECT().WriteNull(),
+				)
+
+				server.OnHandler(mockConn, redcon.Command{Args: [][]byte{[]byte("EXEC")}})
+			})
+		})
+
+		Context("when the connection negotiated RESP3", func() {
+			It("should write RESP3 types", func() {
+				protocol = domain.RESP3
+
+				mockDispatcher.EXPECT().Apply(gomock.Any(), gomock.Any()).
+					Return(domain.Results{
+						domain.NewResult().SetMap([][]byte{[]byte("field"), []byte("value")}),
+						domain.NewResult().SetSet([][]byte{[]byte("member")}),
+						domain.NewResult().SetDouble(1.5),
+						domain.NewResult().SetNil(),
+						domain.NewResult().SetItems(domain.ReplyPairs, domain.Results{
+							{Response: []byte("a")},
+							domain.NewResult().SetDouble(2),
+						}),
+					})
+
+				gomock.InOrder(
+					mockConn.EXPECT().WriteRaw([]byte("%1\r\n")),
+					mockConn.EXPECT().WriteBulk([]byte("field")),
+					mockConn.EXPECT().WriteBulk([]byte("value")),
+					mockConn.EXPECT().WriteRaw([]byte("~1\r\n")),
+					mockConn.EXPECT().WriteBulk([]byte("member")),
+					mockConn.EXPECT().WriteRaw([]byte(",1.5\r\n")),
+					mockConn.EXPECT().WriteRaw([]byte("_\r\n")),
+					mockConn.EXPECT().WriteArray(1),
+					mockConn.EXPECT().WriteArray(2),
+					mockConn.EXPECT().WriteBulk([]byte("a")),
+					mockConn.EXPECT().WriteRaw([]byte(",2\r\n")),
+				)
+
+				server.OnHandler(mockConn, redcon.Command{Args: [][]byte{[]byte("HGETALL"), []byte("key")}})
+			})
+		})
+	})
+
 	Describe("OnClosed", func() {
 		Context("when closing connection after accept", func() {
 			It("should cleanup connection resources", func() {
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tidwall/redcon"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+const drainInterval = time.Millisecond
+
+func isShutdown(args [][]byte) bool {
+	return len(args) > domain.EmptyArgs && strings.EqualFold(string(args[domain.CommandArg]), domain.SHUTDOWN)
+}
+
+func shutdownMode(args [][]byte) (bool, error) {
+	if len(args) == domain.FirstArg {
+		return true, nil
+	}
+
+	if len(args) > domain.SecondArg {
+		return false, domain.ErrSyntax
+	}
+
+	switch strings.ToUpper(string(args[domain.FirstArg])) {
+	case "SAVE":
+		return true, nil
+	case "NOSAVE":
+		return false, nil
+	}
+
+	return false, domain.ErrSyntax
+}
+
+func (server *Server) shutdown(conn redcon.Conn, args [][]byte) {
+	save, err := shutdownMode(args)
+
+	if noError(err) {
+		ctx, cancel := context.WithTimeout(context.Background(), server.registry.Config().ShutdownPeriod())
+		defer cancel()
+		err = server.Shutdown(ctx, save)
+	}
+
+	if hasError(err) {
+		conn.WriteError(err.Error())
+	}
+}
+
+// Shutdown leaves the storage open when commands or scrapes outlive ctx.
+func (server *Server) Shutdown(ctx context.Context, save bool) error {
+	server.closing.Do(func() {
+		server.draining.Store(true)
+		drained := server.drain(ctx)
+
+		if save {
+			server.failure = server.engine.Sync()
+		}
+
+		server.mutex.Lock()
+		metrics := server.http
+		server.http = nil
+		server.stop()
+		server.mutex.Unlock()
+
+		if metrics != nil && hasError(metrics.Shutdown(ctx)) {
+			drained = false
+		}
+
+		if drained {
+			server.release()
+			server.engine.Close()
+		} else {
+			server.failure = errors.Join(server.failure, ctx.Err())
+		}
+
+		close(server.done)
+	})
+
+	<-server.done
+	return server.failure
+}
+
+func (server *Server) drain(ctx context.Context) bool {
+	ticker := time.NewTicker(drainInterval)
+	defer ticker.Stop()
+
+	for server.inflight.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+
+	return true
+}
+
+func (server *Server) release() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	for connID, handler := range server.handlers {
+		delete(server.handlers, connID)
+		server.poolHdlr.Free(handler)
+	}
+
+	server.stats.Disconnected(len(server.handlers))
+}
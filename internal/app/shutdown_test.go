@@ -0,0 +1,194 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tidwall/redcon"
+	"go.uber.org/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/luiz-simples/keyp.git/internal/app"
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+var _ = Describe("Shutdown", func() {
+	var (
+		ctrl           *gomock.Controller
+		mockPool       *MockLogicaler
+		mockEngine     *MockEngine
+		mockConn       *MockConn
+		mockDispatcher *MockDispatcher
+		server         *app.Server
+	)
+
+	command := func(args ...string) redcon.Command {
+		cmd := redcon.Command{}
+
+		for _, arg := range args {
+			cmd.Args = append(cmd.Args, []byte(arg))
+		}
+
+		return cmd
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockPool = NewMockLogicaler(ctrl)
+		mockEngine = NewMockEngine(ctrl)
+		mockConn = NewMockConn(ctrl)
+		mockDispatcher = NewMockDispatcher(ctrl)
+		mockDispatcher.EXPECT().Protocol().Return(domain.RESP2).AnyTimes()
+		mockDispatcher.EXPECT().InMulti().Return(false).AnyTimes()
+		server = app.NewServer(mockPool, app.NewRegistry(app.DefaultConfig()), domain.NewStats(), mockEngine)
+
+		mockPool.EXPECT().Get(gomock.Any()).Return(mockDispatcher).Times(1)
+		mockConn.EXPECT().SetContext(gomock.Any()).Do(func(ctx context.Context) {
+			mockConn.EXPECT().Context().Return(ctx).AnyTimes()
+		}).Times(1)
+		Expect(server.OnAccept(mockConn)).To(BeTrue())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should sync, free the handlers and close the storage in order", func() {
+		gomock.InOrder(
+			mockEngine.EXPECT().Sync().Return(nil),
+			mockPool.EXPECT().Free(mockDispatcher),
+			mockEngine.EXPECT().Close(),
+		)
+
+		Expect(server.Shutdown(context.Background(), true)).To(Succeed())
+	})
+
+	It("should run only once", func() {
+		mockEngine.EXPECT().Sync().Return(nil).Times(1)
+		mockPool.EXPECT().Free(mockDispatcher).Times(1)
+		mockEngine.EXPECT().Close().Times(1)
+
+		Expect(server.Shutdown(context.Background(), true)).To(Succeed())
+		Expect(server.Shutdown(context.Background(), true)).To(Succeed())
+	})
+
+	It("should report a failed sync", func() {
+		syncErr := errors.New("sync failed")
+		mockEngine.EXPECT().Sync().Return(syncErr)
+		mockPool.EXPECT().Free(mockDispatcher)
+		mockEngine.EXPECT().Close()
+
+		Expect(server.Shutdown(context.Background(), true)).To(MatchError(syncErr))
+	})
+
+	It("should refuse new connections and commands while draining", func() {
+		mockPool.EXPECT().Free(mockDispatcher)
+		mockEngine.EXPECT().Close()
+		Expect(server.Shutdown(context.Background(), false)).To(Succeed())
+
+		refused := NewMockConn(ctrl)
+		refused.EXPECT().WriteError(domain.ErrShuttingDown.Error())
+		Expect(server.OnAccept(refused)).To(BeFalse())
+	})
+
+	It("should wait for running commands before closing the storage", func() {
+		release := make(chan struct{})
+		started := make(chan struct{})
+
+		mockDispatcher.EXPECT().Apply(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(context.Context, [][]byte) domain.Results {
+				close(started)
+				<-release
+				return domain.Results{domain.NewResult().SetOK()}
+			},
+		)
+		mockConn.EXPECT().WriteString("OK")
+
+		go server.OnHandler(mockConn, command("SET", "key", "value"))
+		Eventually(started).Should(BeClosed())
+
+		stopped := make(chan error, 1)
+		go func() { stopped <- server.Shutdown(context.Background(), false) }()
+		Consistently(stopped, 20*time.Millisecond).ShouldNot(Receive())
+
+		mockPool.EXPECT().Free(mockDispatcher)
+		mockEngine.EXPECT().Close()
+		close(release)
+
+		Eventually(stopped).Should(Receive(BeNil()))
+	})
+
+	It("should leave the storage open when the deadline passes", func() {
+		release := make(chan struct{})
+		defer close(release)
+		started := make(chan struct{})
+
+		mockDispatcher.EXPECT().Apply(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(context.Context, [][]byte) domain.Results {
+				close(started)
+				<-release
+				return nil
+			},
+		)
+
+		go server.OnHandler(mockConn, command("GET", "key"))
+		Eventually(started).Should(BeClosed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		mockEngine.EXPECT().Sync().Return(nil)
+		Expect(server.Shutdown(ctx, true)).To(MatchError(context.DeadlineExceeded))
+	})
+
+	Describe("SHUTDOWN command", func() {
+		It("should shut down without syncing on NOSAVE", func() {
+			mockPool.EXPECT().Free(mockDispatcher)
+			mockEngine.EXPECT().Close()
+
+			server.OnHandler(mockConn, command("shutdown", "nosave"))
+
+			refused := NewMockConn(ctrl)
+			refused.EXPECT().WriteError(domain.ErrShuttingDown.Error())
+			Expect(server.OnAccept(refused)).To(BeFalse())
+		})
+
+		It("should sync on SAVE", func() {
+			gomock.InOrder(
+				mockEngine.EXPECT().Sync().Return(nil),
+				mockPool.EXPECT().Free(mockDispatcher),
+				mockEngine.EXPECT().Close(),
+			)
+
+			server.OnHandler(mockConn, command("SHUTDOWN", "SAVE"))
+		})
+
+		It("should hand the command to the handler inside MULTI", func() {
+			inMulti := NewMockDispatcher(ctrl)
+			inMulti.EXPECT().InMulti().Return(true)
+			inMulti.EXPECT().Protocol().Return(domain.RESP2)
+			inMulti.EXPECT().Apply(gomock.Any(), gomock.Any()).
+				Return(domain.Results{{Error: domain.ErrNotInMulti}})
+
+			multiConn := NewMockConn(ctrl)
+			multiConn.EXPECT().WriteError(domain.ErrNotInMulti.Error())
+			mockPool.EXPECT().Get(gomock.Any()).Return(inMulti)
+			multiConn.EXPECT().SetContext(gomock.Any()).Do(func(ctx context.Context) {
+				multiConn.EXPECT().Context().Return(ctx).AnyTimes()
+			})
+			Expect(server.OnAccept(multiConn)).To(BeTrue())
+
+			server.OnHandler(multiConn, command("SHUTDOWN"))
+		})
+
+		It("should reject unknown modifiers", func() {
+			mockConn.EXPECT().WriteError(domain.ErrSyntax.Error()).Times(2)
+
+			server.OnHandler(mockConn, command("SHUTDOWN", "NOW"))
+			server.OnHandler(mockConn, command("SHUTDOWN", "SAVE", "NOSAVE"))
+		})
+	})
+})
@@ -1,17 +1,31 @@
 package app
 
 import (
+	"strconv"
 	"time"
 
+	"github.com/tidwall/redcon"
+
 	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
+const (
+	mapPrefix = '%'
+	setPrefix = '~'
+	pairSize  = 2
+)
+
+var (
+	nullArray = []byte("*-1\r\n")
+	null      = []byte("_\r\n")
+)
+
 func hasError(err error) bool {
 	return err != nil
 }
 
-func hasResponse(response []byte) bool {
-	return response != nil
+func noError(err error) bool {
+	return err == nil
 }
 
 func generateConnectionID() int64 {
@@ -23,6 +37,90 @@ func handlerExists(handlers map[int64]domain.Dispatcher, connID int64) bool {
 	return exists
 }
 
-func isArrayResponse(response []byte) bool {
-	return len(response) > 0 && response[0] == '*'
+func writeResult(conn redcon.Conn, item *domain.Result, protocol int) {
+	if hasError(item.Error) {
+		conn.WriteError(item.Error.Error())
+		return
+	}
+
+	if protocol == domain.RESP3 {
+		writeResult3(conn, item)
+		return
+	}
+
+	switch item.Kind {
+	case domain.ReplySimple:
+		conn.WriteString(string(item.Response))
+	case domain.ReplyInteger:
+		conn.WriteInt64(item.Integer)
+	case domain.ReplyDouble:
+		conn.WriteBulkString(domain.FormatFloat(item.Double))
+	case domain.ReplyArray, domain.ReplyMap, domain.ReplySet, domain.ReplyPairs:
+		conn.WriteArray(len(item.Items))
+		writeItems(conn, item.Items, protocol)
+	case domain.ReplyNullArray:
+		conn.WriteRaw(nullArray)
+	default:
+		writeBulk(conn, item.Response)
+	}
+}
+
+func writeResult3(conn redcon.Conn, item *domain.Result) {
+	switch item.Kind {
+	case domain.ReplySimple:
+		conn.WriteString(string(item.Response))
+	case domain.ReplyInteger:
+		conn.WriteInt64(item.Integer)
+	case domain.ReplyDouble:
+		conn.WriteRaw([]byte("," + domain.FormatFloat(item.Double) + "\r\n"))
+	case domain.ReplyArray:
+		conn.WriteArray(len(item.Items))
+		writeItems(conn, item.Items, domain.RESP3)
+	case domain.ReplyMap:
+		writeAggregate(conn, mapPrefix, len(item.Items)/pairSize)
+		writeItems(conn, item.Items, domain.RESP3)
+	case domain.ReplySet:
+		writeAggregate(conn, setPrefix, len(item.Items))
+		writeItems(conn, item.Items, domain.RESP3)
+	case domain.ReplyPairs:
+		writePairs(conn, item.Items)
+	case domain.ReplyNullArray:
+		conn.WriteRaw(null)
+	default:
+		if item.Response == nil {
+			conn.WriteRaw(null)
+			return
+		}
+
+		conn.WriteBulk(item.Response)
+	}
+}
+
+func writeItems(conn redcon.Conn, items domain.Results, protocol int) {
+	for _, nested := range items {
+		writeResult(conn, nested, protocol)
+	}
+}
+
+func writePairs(conn redcon.Conn, items domain.Results) {
+	conn.WriteArray(len(items) / pairSize)
+
+	for index := 0; index+1 < len(items); index += pairSize {
+		conn.WriteArray(pairSize)
+		writeItems(conn, items[index:index+pairSize], domain.RESP3)
+	}
+}
+
+func writeAggregate(conn redcon.Conn, prefix byte, count int) {
+	header := strconv.AppendInt([]byte{prefix}, int64(count), 10)
+	conn.WriteRaw(append(header, '\r', '\n'))
+}
+
+func writeBulk(conn redcon.Conn, response []byte) {
+	if response == nil {
+		conn.WriteNull()
+		return
+	}
+
+	conn.WriteBulk(response)
 }
@@ -80,47 +80,4 @@ var _ = Describe("Utils Additional Tests", func() {
 			Expect(exists).To(BeTrue())
 		})
 	})
-
-	Describe("array response detection behavior", func() {
-		It("should detect array responses correctly", func() {
-			// Array response starts with '*'
-			arrayResponse := []byte("*2\r\n$5\r\nhello\r\n$5\r\nworld\r\n")
-			isArray := len(arrayResponse) > 0 && arrayResponse[0] == '*'
-			Expect(isArray).To(BeTrue())
-
-			// Non-array response
-			stringResponse := []byte("$5\r\nhello\r\n")
-			isArray = len(stringResponse) > 0 && stringResponse[0] == '*'
-			Expect(isArray).To(BeFalse())
-
-			// Empty response
-			emptyResponse := []byte("")
-			isArray = len(emptyResponse) > 0 && emptyResponse[0] == '*'
-			Expect(isArray).To(BeFalse())
-
-			// Nil response
-			var nilResponse []byte = nil
-			isArray = len(nilResponse) > 0 && nilResponse[0] == '*'
-			Expect(isArray).To(BeFalse())
-		})
-
-		It("should handle different Redis response types", func() {
-			responses := map[string][]byte{
-				"simple_string": []byte("+OK\r\n"),
-				"error":         []byte("-ERR\r\n"),
-				"integer":       []byte(":1000\r\n"),
-				"bulk_string":   []byte("$5\r\nhello"),
-				"array":         []byte("*2\r\n$5\r\nhello\r\n$5\r\nworld\r\n"),
-			}
-
-			for responseType, response := range responses {
-				isArray := len(response) > 0 && response[0] == '*'
-				if responseType == "array" {
-					Expect(isArray).To(BeTrue(), "Array response should be detected")
-				} else {
-					Expect(isArray).To(BeFalse(), "Non-array response should not be detected as array: %s", responseType)
-				}
-			}
-		})
-	})
 })
@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/luiz-simples/keyp.git/internal/app"
+	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
 var _ = Describe("Utils", func() {
@@ -19,7 +20,7 @@ var _ = Describe("Utils", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mockPool = NewMockLogicaler(ctrl)
-		server = app.NewServer(mockPool)
+		server = app.NewServer(mockPool, app.NewRegistry(app.DefaultConfig()), domain.NewStats(), nil)
 	})
 
 	AfterEach(func() {
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LongDoublePrecision is the mantissa of the long double Redis adds float counters with.
+	LongDoublePrecision = 64
+
+	counterDecimals = 17
+)
+
+// FormatFloat writes a double in its shortest form without exponent.
+func FormatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "inf"
+	case math.IsInf(value, -1):
+		return "-inf"
+	}
+
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// FormatCounter writes a float counter like Redis' "%.17Lf" with the trailing zeros trimmed.
+func FormatCounter(value *big.Float) []byte {
+	text := value.Text('f', counterDecimals)
+
+	if strings.Contains(text, ".") {
+		text = strings.TrimRight(text, "0")
+		text = strings.TrimSuffix(text, ".")
+	}
+
+	if text == "-0" {
+		text = "0"
+	}
+
+	return []byte(text)
+}
@@ -0,0 +1,35 @@
+package domain_test
+
+import (
+	"math"
+	"math/big"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+var _ = Describe("Float formatting", func() {
+	longDouble := func(text string) *big.Float {
+		value, _, err := big.ParseFloat(text, 10, domain.LongDoublePrecision, big.ToNearestEven)
+		Expect(err).NotTo(HaveOccurred())
+		return value
+	}
+
+	It("should write doubles in their shortest form without exponent", func() {
+		Expect(domain.FormatFloat(1.5)).To(Equal("1.5"))
+		Expect(domain.FormatFloat(1e21)).To(Equal("1000000000000000000000"))
+		Expect(domain.FormatFloat(math.Inf(1))).To(Equal("inf"))
+		Expect(domain.FormatFloat(math.Inf(-1))).To(Equal("-inf"))
+	})
+
+	It("should round counters to 17 decimals and trim the trailing zeros", func() {
+		sum := longDouble("0.1")
+		sum.Add(sum, longDouble("0.2"))
+
+		Expect(domain.FormatCounter(sum)).To(Equal([]byte("0.3")))
+		Expect(domain.FormatCounter(longDouble("10"))).To(Equal([]byte("10")))
+		Expect(domain.FormatCounter(longDouble("-0.000000000000000001"))).To(Equal([]byte("0")))
+	})
+})
@@ -0,0 +1,20 @@
+package domain
+
+import "strconv"
+
+// MaxIntegerLength is the length of the longest int64, "-9223372036854775808".
+const MaxIntegerLength = 20
+
+// ParseInteger accepts only the canonical form of an int64, as Redis counters do.
+func ParseInteger(data []byte) (int64, bool) {
+	if len(data) == 0 || len(data) > MaxIntegerLength {
+		return 0, false
+	}
+
+	value, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return value, strconv.FormatInt(value, 10) == string(data)
+}
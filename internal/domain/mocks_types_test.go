@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/domain/types.go
+// Source: domain/types.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/domain/types.go -destination=internal/domain/mocks_types_test.go -package=domain_test
+//	mockgen -source=domain/types.go -destination=domain/mocks_types_test.go -package=domain_test
 //
 
 // Package domain_test is a generated GoMock package.
@@ -41,103 +41,1117 @@ func (m *MockPersister) EXPECT() *MockPersisterMockRecorder {
 	return m.recorder
 }
 
+// Append mocks base method.
+func (m *MockPersister) Append(arg0 context.Context, arg1, arg2 []byte) int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockPersisterMockRecorder) Append(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockPersister)(nil).Append), arg0, arg1, arg2)
+}
+
+// Atomic mocks base method.
+func (m *MockPersister) Atomic(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Atomic", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Atomic indicates an expected call of Atomic.
+func (mr *MockPersisterMockRecorder) Atomic(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Atomic", reflect.TypeOf((*MockPersister)(nil).Atomic), arg0, arg1)
+}
+
+// BitCount mocks base method.
+func (m *MockPersister) BitCount(arg0 context.Context, arg1 []byte, arg2 domain.BitRange) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BitCount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitCount indicates an expected call of BitCount.
+func (mr *MockPersisterMockRecorder) BitCount(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitCount", reflect.TypeOf((*MockPersister)(nil).BitCount), arg0, arg1, arg2)
+}
+
+// BitField mocks base method.
+func (m *MockPersister) BitField(arg0 context.Context, arg1 []byte, arg2 []domain.BitFieldOp) ([]*int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BitField", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitField indicates an expected call of BitField.
+func (mr *MockPersisterMockRecorder) BitField(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitField", reflect.TypeOf((*MockPersister)(nil).BitField), arg0, arg1, arg2)
+}
+
+// BitOp mocks base method.
+func (m *MockPersister) BitOp(arg0 context.Context, arg1 domain.BitOperation, arg2 []byte, arg3 ...[]byte) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BitOp", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitOp indicates an expected call of BitOp.
+func (mr *MockPersisterMockRecorder) BitOp(arg0, arg1, arg2 any, arg3 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitOp", reflect.TypeOf((*MockPersister)(nil).BitOp), varargs...)
+}
+
+// BitPos mocks base method.
+func (m *MockPersister) BitPos(arg0 context.Context, arg1 []byte, arg2 int64, arg3 domain.BitRange) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BitPos", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitPos indicates an expected call of BitPos.
+func (mr *MockPersisterMockRecorder) BitPos(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitPos", reflect.TypeOf((*MockPersister)(nil).BitPos), arg0, arg1, arg2, arg3)
+}
+
 // Close mocks base method.
 func (m *MockPersister) Close() {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Close")
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockPersisterMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPersister)(nil).Close))
+}
+
+// DBSize mocks base method.
+func (m *MockPersister) DBSize(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DBSize", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DBSize indicates an expected call of DBSize.
+func (mr *MockPersisterMockRecorder) DBSize(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DBSize", reflect.TypeOf((*MockPersister)(nil).DBSize), arg0)
+}
+
+// Decr mocks base method.
+func (m *MockPersister) Decr(arg0 context.Context, arg1 []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decr", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decr indicates an expected call of Decr.
+func (mr *MockPersisterMockRecorder) Decr(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decr", reflect.TypeOf((*MockPersister)(nil).Decr), arg0, arg1)
+}
+
+// DecrBy mocks base method.
+func (m *MockPersister) DecrBy(arg0 context.Context, arg1 []byte, arg2 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrBy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecrBy indicates an expected call of DecrBy.
+func (mr *MockPersisterMockRecorder) DecrBy(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrBy", reflect.TypeOf((*MockPersister)(nil).DecrBy), arg0, arg1, arg2)
+}
+
+// Del mocks base method.
+func (m *MockPersister) Del(arg0 context.Context, arg1 ...[]byte) (uint32, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Del", varargs...)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockPersisterMockRecorder) Del(arg0 any, arg1 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockPersister)(nil).Del), varargs...)
+}
+
+// Encoding mocks base method.
+func (m *MockPersister) Encoding(arg0 context.Context, arg1 []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encoding", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Encoding indicates an expected call of Encoding.
+func (mr *MockPersisterMockRecorder) Encoding(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encoding", reflect.TypeOf((*MockPersister)(nil).Encoding), arg0, arg1)
+}
+
+// Exists mocks base method.
+func (m *MockPersister) Exists(arg0 context.Context, arg1 []byte) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockPersisterMockRecorder) Exists(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockPersister)(nil).Exists), arg0, arg1)
+}
+
+// ExpireAt mocks base method.
+func (m *MockPersister) ExpireAt(arg0 context.Context, arg1 []byte, arg2 int64, arg3 domain.ExpireCondition) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireAt", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireAt indicates an expected call of ExpireAt.
+func (mr *MockPersisterMockRecorder) ExpireAt(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireAt", reflect.TypeOf((*MockPersister)(nil).ExpireAt), arg0, arg1, arg2, arg3)
+}
+
+// ExpireTime mocks base method.
+func (m *MockPersister) ExpireTime(arg0 context.Context, arg1 []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireTime", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireTime indicates an expected call of ExpireTime.
+func (mr *MockPersisterMockRecorder) ExpireTime(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireTime", reflect.TypeOf((*MockPersister)(nil).ExpireTime), arg0, arg1)
+}
+
+// FlushAll mocks base method.
+func (m *MockPersister) FlushAll(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushAll", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlushAll indicates an expected call of FlushAll.
+func (mr *MockPersisterMockRecorder) FlushAll(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushAll", reflect.TypeOf((*MockPersister)(nil).FlushAll), arg0)
+}
+
+// Frequency mocks base method.
+func (m *MockPersister) Frequency(arg0 context.Context, arg1 []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Frequency", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Frequency indicates an expected call of Frequency.
+func (mr *MockPersisterMockRecorder) Frequency(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Frequency", reflect.TypeOf((*MockPersister)(nil).Frequency), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockPersister) Get(arg0 context.Context, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPersisterMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPersister)(nil).Get), arg0, arg1)
+}
+
+// GetBit mocks base method.
+func (m *MockPersister) GetBit(arg0 context.Context, arg1 []byte, arg2 uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBit", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBit indicates an expected call of GetBit.
+func (mr *MockPersisterMockRecorder) GetBit(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBit", reflect.TypeOf((*MockPersister)(nil).GetBit), arg0, arg1, arg2)
+}
+
+// GetDel mocks base method.
+func (m *MockPersister) GetDel(arg0 context.Context, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDel", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDel indicates an expected call of GetDel.
+func (mr *MockPersisterMockRecorder) GetDel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDel", reflect.TypeOf((*MockPersister)(nil).GetDel), arg0, arg1)
+}
+
+// GetEx mocks base method.
+func (m *MockPersister) GetEx(arg0 context.Context, arg1 []byte, arg2 domain.GetExOptions) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEx", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEx indicates an expected call of GetEx.
+func (mr *MockPersisterMockRecorder) GetEx(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEx", reflect.TypeOf((*MockPersister)(nil).GetEx), arg0, arg1, arg2)
+}
+
+// GetRange mocks base method.
+func (m *MockPersister) GetRange(arg0 context.Context, arg1 []byte, arg2, arg3 int64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRange", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRange indicates an expected call of GetRange.
+func (mr *MockPersisterMockRecorder) GetRange(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRange", reflect.TypeOf((*MockPersister)(nil).GetRange), arg0, arg1, arg2, arg3)
+}
+
+// HDel mocks base method.
+func (m *MockPersister) HDel(arg0 context.Context, arg1 []byte, arg2 ...[]byte) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HDel", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HDel indicates an expected call of HDel.
+func (mr *MockPersisterMockRecorder) HDel(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HDel", reflect.TypeOf((*MockPersister)(nil).HDel), varargs...)
+}
+
+// HExists mocks base method.
+func (m *MockPersister) HExists(arg0 context.Context, arg1, arg2 []byte) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HExists", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HExists indicates an expected call of HExists.
+func (mr *MockPersisterMockRecorder) HExists(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HExists", reflect.TypeOf((*MockPersister)(nil).HExists), arg0, arg1, arg2)
+}
+
+// HGet mocks base method.
+func (m *MockPersister) HGet(arg0 context.Context, arg1, arg2 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HGet", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HGet indicates an expected call of HGet.
+func (mr *MockPersisterMockRecorder) HGet(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HGet", reflect.TypeOf((*MockPersister)(nil).HGet), arg0, arg1, arg2)
+}
+
+// HGetAll mocks base method.
+func (m *MockPersister) HGetAll(arg0 context.Context, arg1 []byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HGetAll", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HGetAll indicates an expected call of HGetAll.
+func (mr *MockPersisterMockRecorder) HGetAll(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HGetAll", reflect.TypeOf((*MockPersister)(nil).HGetAll), arg0, arg1)
+}
+
+// HIncrBy mocks base method.
+func (m *MockPersister) HIncrBy(arg0 context.Context, arg1, arg2 []byte, arg3 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HIncrBy", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HIncrBy indicates an expected call of HIncrBy.
+func (mr *MockPersisterMockRecorder) HIncrBy(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HIncrBy", reflect.TypeOf((*MockPersister)(nil).HIncrBy), arg0, arg1, arg2, arg3)
+}
+
+// HIncrByFloat mocks base method.
+func (m *MockPersister) HIncrByFloat(arg0 context.Context, arg1, arg2 []byte, arg3 float64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HIncrByFloat", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HIncrByFloat indicates an expected call of HIncrByFloat.
+func (mr *MockPersisterMockRecorder) HIncrByFloat(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HIncrByFloat", reflect.TypeOf((*MockPersister)(nil).HIncrByFloat), arg0, arg1, arg2, arg3)
+}
+
+// HKeys mocks base method.
+func (m *MockPersister) HKeys(arg0 context.Context, arg1 []byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HKeys", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HKeys indicates an expected call of HKeys.
+func (mr *MockPersisterMockRecorder) HKeys(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HKeys", reflect.TypeOf((*MockPersister)(nil).HKeys), arg0, arg1)
+}
+
+// HLen mocks base method.
+func (m *MockPersister) HLen(arg0 context.Context, arg1 []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HLen", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HLen indicates an expected call of HLen.
+func (mr *MockPersisterMockRecorder) HLen(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HLen", reflect.TypeOf((*MockPersister)(nil).HLen), arg0, arg1)
+}
+
+// HMGet mocks base method.
+func (m *MockPersister) HMGet(arg0 context.Context, arg1 []byte, arg2 ...[]byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HMGet", varargs...)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HMGet indicates an expected call of HMGet.
+func (mr *MockPersisterMockRecorder) HMGet(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HMGet", reflect.TypeOf((*MockPersister)(nil).HMGet), varargs...)
+}
+
+// HScan mocks base method.
+func (m *MockPersister) HScan(arg0 context.Context, arg1, arg2, arg3 []byte, arg4 int64) ([]byte, [][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HScan", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// HScan indicates an expected call of HScan.
+func (mr *MockPersisterMockRecorder) HScan(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HScan", reflect.TypeOf((*MockPersister)(nil).HScan), arg0, arg1, arg2, arg3, arg4)
+}
+
+// HSet mocks base method.
+func (m *MockPersister) HSet(arg0 context.Context, arg1 []byte, arg2 ...[]byte) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HSet", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HSet indicates an expected call of HSet.
+func (mr *MockPersisterMockRecorder) HSet(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HSet", reflect.TypeOf((*MockPersister)(nil).HSet), varargs...)
+}
+
+// HSetNX mocks base method.
+func (m *MockPersister) HSetNX(arg0 context.Context, arg1, arg2, arg3 []byte) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HSetNX", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HSetNX indicates an expected call of HSetNX.
+func (mr *MockPersisterMockRecorder) HSetNX(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HSetNX", reflect.TypeOf((*MockPersister)(nil).HSetNX), arg0, arg1, arg2, arg3)
+}
+
+// HStrLen mocks base method.
+func (m *MockPersister) HStrLen(arg0 context.Context, arg1, arg2 []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HStrLen", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HStrLen indicates an expected call of HStrLen.
+func (mr *MockPersisterMockRecorder) HStrLen(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HStrLen", reflect.TypeOf((*MockPersister)(nil).HStrLen), arg0, arg1, arg2)
+}
+
+// HVals mocks base method.
+func (m *MockPersister) HVals(arg0 context.Context, arg1 []byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HVals", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HVals indicates an expected call of HVals.
+func (mr *MockPersisterMockRecorder) HVals(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HVals", reflect.TypeOf((*MockPersister)(nil).HVals), arg0, arg1)
+}
+
+// IdleTime mocks base method.
+func (m *MockPersister) IdleTime(arg0 context.Context, arg1 []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IdleTime", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IdleTime indicates an expected call of IdleTime.
+func (mr *MockPersisterMockRecorder) IdleTime(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IdleTime", reflect.TypeOf((*MockPersister)(nil).IdleTime), arg0, arg1)
+}
+
+// Incr mocks base method.
+func (m *MockPersister) Incr(arg0 context.Context, arg1 []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockPersisterMockRecorder) Incr(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockPersister)(nil).Incr), arg0, arg1)
+}
+
+// IncrBy mocks base method.
+func (m *MockPersister) IncrBy(arg0 context.Context, arg1 []byte, arg2 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrBy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrBy indicates an expected call of IncrBy.
+func (mr *MockPersisterMockRecorder) IncrBy(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrBy", reflect.TypeOf((*MockPersister)(nil).IncrBy), arg0, arg1, arg2)
+}
+
+// IncrByFloat mocks base method.
+func (m *MockPersister) IncrByFloat(arg0 context.Context, arg1 []byte, arg2 float64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrByFloat", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrByFloat indicates an expected call of IncrByFloat.
+func (mr *MockPersisterMockRecorder) IncrByFloat(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrByFloat", reflect.TypeOf((*MockPersister)(nil).IncrByFloat), arg0, arg1, arg2)
+}
+
+// Info mocks base method.
+func (m *MockPersister) Info(arg0 context.Context) (domain.StorageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Info", arg0)
+	ret0, _ := ret[0].(domain.StorageInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockPersisterMockRecorder) Info(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockPersister)(nil).Info), arg0)
+}
+
+// Keys mocks base method.
+func (m *MockPersister) Keys(arg0 context.Context, arg1 []byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Keys", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Keys indicates an expected call of Keys.
+func (mr *MockPersisterMockRecorder) Keys(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockPersister)(nil).Keys), arg0, arg1)
+}
+
+// LIndex mocks base method.
+func (m *MockPersister) LIndex(arg0 context.Context, arg1 []byte, arg2 int64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LIndex", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LIndex indicates an expected call of LIndex.
+func (mr *MockPersisterMockRecorder) LIndex(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LIndex", reflect.TypeOf((*MockPersister)(nil).LIndex), arg0, arg1, arg2)
+}
+
+// LLen mocks base method.
+func (m *MockPersister) LLen(arg0 context.Context, arg1 []byte) int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LLen", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// LLen indicates an expected call of LLen.
+func (mr *MockPersisterMockRecorder) LLen(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LLen", reflect.TypeOf((*MockPersister)(nil).LLen), arg0, arg1)
+}
+
+// LPop mocks base method.
+func (m *MockPersister) LPop(arg0 context.Context, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LPop", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LPop indicates an expected call of LPop.
+func (mr *MockPersisterMockRecorder) LPop(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LPop", reflect.TypeOf((*MockPersister)(nil).LPop), arg0, arg1)
+}
+
+// LPush mocks base method.
+func (m *MockPersister) LPush(arg0 context.Context, arg1 []byte, arg2 ...[]byte) int64 {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LPush", varargs...)
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// LPush indicates an expected call of LPush.
+func (mr *MockPersisterMockRecorder) LPush(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LPush", reflect.TypeOf((*MockPersister)(nil).LPush), varargs...)
+}
+
+// LRange mocks base method.
+func (m *MockPersister) LRange(arg0 context.Context, arg1 []byte, arg2, arg3 int64) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LRange", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LRange indicates an expected call of LRange.
+func (mr *MockPersisterMockRecorder) LRange(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LRange", reflect.TypeOf((*MockPersister)(nil).LRange), arg0, arg1, arg2, arg3)
+}
+
+// LSet mocks base method.
+func (m *MockPersister) LSet(arg0 context.Context, arg1 []byte, arg2 int64, arg3 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LSet", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LSet indicates an expected call of LSet.
+func (mr *MockPersisterMockRecorder) LSet(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LSet", reflect.TypeOf((*MockPersister)(nil).LSet), arg0, arg1, arg2, arg3)
+}
+
+// MGet mocks base method.
+func (m *MockPersister) MGet(arg0 context.Context, arg1 ...[]byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MGet", varargs...)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MGet indicates an expected call of MGet.
+func (mr *MockPersisterMockRecorder) MGet(arg0 any, arg1 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MGet", reflect.TypeOf((*MockPersister)(nil).MGet), varargs...)
+}
+
+// MSet mocks base method.
+func (m *MockPersister) MSet(arg0 context.Context, arg1 ...[]byte) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MSet", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// Close indicates an expected call of Close.
-func (mr *MockPersisterMockRecorder) Close() *gomock.Call {
+// MSet indicates an expected call of MSet.
+func (mr *MockPersisterMockRecorder) MSet(arg0 any, arg1 ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPersister)(nil).Close))
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MSet", reflect.TypeOf((*MockPersister)(nil).MSet), varargs...)
 }
 
-// Del mocks base method.
-func (m *MockPersister) Del(arg0 context.Context, arg1 ...[]byte) (uint32, error) {
+// MSetNX mocks base method.
+func (m *MockPersister) MSetNX(arg0 context.Context, arg1 ...[]byte) (bool, error) {
 	m.ctrl.T.Helper()
 	varargs := []any{arg0}
 	for _, a := range arg1 {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "Del", varargs...)
-	ret0, _ := ret[0].(uint32)
+	ret := m.ctrl.Call(m, "MSetNX", varargs...)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Del indicates an expected call of Del.
-func (mr *MockPersisterMockRecorder) Del(arg0 any, arg1 ...any) *gomock.Call {
+// MSetNX indicates an expected call of MSetNX.
+func (mr *MockPersisterMockRecorder) MSetNX(arg0 any, arg1 ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]any{arg0}, arg1...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockPersister)(nil).Del), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MSetNX", reflect.TypeOf((*MockPersister)(nil).MSetNX), varargs...)
 }
 
-// Expire mocks base method.
-func (m *MockPersister) Expire(arg0 context.Context, arg1 []byte, arg2 uint32) {
+// Modified mocks base method.
+func (m *MockPersister) Modified(arg0 context.Context, arg1 uint64) bool {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Expire", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "Modified", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
 }
 
-// Expire indicates an expected call of Expire.
-func (mr *MockPersisterMockRecorder) Expire(arg0, arg1, arg2 any) *gomock.Call {
+// Modified indicates an expected call of Modified.
+func (mr *MockPersisterMockRecorder) Modified(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockPersister)(nil).Expire), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Modified", reflect.TypeOf((*MockPersister)(nil).Modified), arg0, arg1)
 }
 
-// Get mocks base method.
-func (m *MockPersister) Get(arg0 context.Context, arg1 []byte) ([]byte, error) {
+// Persist mocks base method.
+func (m *MockPersister) Persist(arg0 context.Context, arg1 []byte) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret := m.ctrl.Call(m, "Persist", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Persist indicates an expected call of Persist.
+func (mr *MockPersisterMockRecorder) Persist(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Persist", reflect.TypeOf((*MockPersister)(nil).Persist), arg0, arg1)
+}
+
+// RPop mocks base method.
+func (m *MockPersister) RPop(arg0 context.Context, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RPop", arg0, arg1)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockPersisterMockRecorder) Get(arg0, arg1 any) *gomock.Call {
+// RPop indicates an expected call of RPop.
+func (mr *MockPersisterMockRecorder) RPop(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPersister)(nil).Get), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPop", reflect.TypeOf((*MockPersister)(nil).RPop), arg0, arg1)
 }
 
-// Persist mocks base method.
-func (m *MockPersister) Persist(arg0 context.Context, arg1 []byte) {
+// RPush mocks base method.
+func (m *MockPersister) RPush(arg0 context.Context, arg1 []byte, arg2 ...[]byte) int64 {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Persist", arg0, arg1)
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RPush", varargs...)
+	ret0, _ := ret[0].(int64)
+	return ret0
 }
 
-// Persist indicates an expected call of Persist.
-func (mr *MockPersisterMockRecorder) Persist(arg0, arg1 any) *gomock.Call {
+// RPush indicates an expected call of RPush.
+func (mr *MockPersisterMockRecorder) RPush(arg0, arg1 any, arg2 ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Persist", reflect.TypeOf((*MockPersister)(nil).Persist), arg0, arg1)
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPush", reflect.TypeOf((*MockPersister)(nil).RPush), varargs...)
 }
 
-// Set mocks base method.
-func (m *MockPersister) Set(arg0 context.Context, arg1, arg2 []byte) error {
+// SAdd mocks base method.
+func (m *MockPersister) SAdd(arg0 context.Context, arg1 []byte, arg2 ...[]byte) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Set", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SAdd", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SAdd indicates an expected call of SAdd.
+func (mr *MockPersisterMockRecorder) SAdd(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SAdd", reflect.TypeOf((*MockPersister)(nil).SAdd), varargs...)
+}
+
+// SIsMember mocks base method.
+func (m *MockPersister) SIsMember(arg0 context.Context, arg1, arg2 []byte) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SIsMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
-// Set indicates an expected call of Set.
-func (mr *MockPersisterMockRecorder) Set(arg0, arg1, arg2 any) *gomock.Call {
+// SIsMember indicates an expected call of SIsMember.
+func (mr *MockPersisterMockRecorder) SIsMember(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockPersister)(nil).Set), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SIsMember", reflect.TypeOf((*MockPersister)(nil).SIsMember), arg0, arg1, arg2)
 }
 
-// TTL mocks base method.
-func (m *MockPersister) TTL(arg0 context.Context, arg1 []byte) uint32 {
+// SMembers mocks base method.
+func (m *MockPersister) SMembers(arg0 context.Context, arg1 []byte) ([][]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TTL", arg0, arg1)
-	ret0, _ := ret[0].(uint32)
+	ret := m.ctrl.Call(m, "SMembers", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SMembers indicates an expected call of SMembers.
+func (mr *MockPersisterMockRecorder) SMembers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMembers", reflect.TypeOf((*MockPersister)(nil).SMembers), arg0, arg1)
+}
+
+// SRem mocks base method.
+func (m *MockPersister) SRem(arg0 context.Context, arg1 []byte, arg2 ...[]byte) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SRem", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SRem indicates an expected call of SRem.
+func (mr *MockPersisterMockRecorder) SRem(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SRem", reflect.TypeOf((*MockPersister)(nil).SRem), varargs...)
+}
+
+// Scan mocks base method.
+func (m *MockPersister) Scan(arg0 context.Context, arg1, arg2 []byte, arg3 int64, arg4 string) ([]byte, [][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scan", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockPersisterMockRecorder) Scan(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockPersister)(nil).Scan), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SetBit mocks base method.
+func (m *MockPersister) SetBit(arg0 context.Context, arg1 []byte, arg2 uint64, arg3 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBit", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBit indicates an expected call of SetBit.
+func (mr *MockPersisterMockRecorder) SetBit(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBit", reflect.TypeOf((*MockPersister)(nil).SetBit), arg0, arg1, arg2, arg3)
+}
+
+// SetRange mocks base method.
+func (m *MockPersister) SetRange(arg0 context.Context, arg1 []byte, arg2 int64, arg3 []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRange", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRange indicates an expected call of SetRange.
+func (mr *MockPersisterMockRecorder) SetRange(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRange", reflect.TypeOf((*MockPersister)(nil).SetRange), arg0, arg1, arg2, arg3)
+}
+
+// SetWith mocks base method.
+func (m *MockPersister) SetWith(arg0 context.Context, arg1, arg2 []byte, arg3 domain.SetOptions) ([]byte, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWith", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetWith indicates an expected call of SetWith.
+func (mr *MockPersisterMockRecorder) SetWith(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWith", reflect.TypeOf((*MockPersister)(nil).SetWith), arg0, arg1, arg2, arg3)
+}
+
+// StrLen mocks base method.
+func (m *MockPersister) StrLen(arg0 context.Context, arg1 []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StrLen", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StrLen indicates an expected call of StrLen.
+func (mr *MockPersisterMockRecorder) StrLen(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StrLen", reflect.TypeOf((*MockPersister)(nil).StrLen), arg0, arg1)
+}
+
+// Type mocks base method.
+func (m *MockPersister) Type(arg0 context.Context, arg1 []byte) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Type", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Type indicates an expected call of Type.
+func (mr *MockPersisterMockRecorder) Type(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Type", reflect.TypeOf((*MockPersister)(nil).Type), arg0, arg1)
+}
+
+// Unwatch mocks base method.
+func (m *MockPersister) Unwatch(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unwatch", arg0)
+}
+
+// Unwatch indicates an expected call of Unwatch.
+func (mr *MockPersisterMockRecorder) Unwatch(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unwatch", reflect.TypeOf((*MockPersister)(nil).Unwatch), arg0)
+}
+
+// Watch mocks base method.
+func (m *MockPersister) Watch(arg0 context.Context, arg1 uint64, arg2 ...[]byte) (uint64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Watch", varargs...)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockPersisterMockRecorder) Watch(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockPersister)(nil).Watch), varargs...)
+}
+
+// ZAdd mocks base method.
+func (m *MockPersister) ZAdd(arg0 context.Context, arg1 []byte, arg2 float64, arg3 []byte) int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZAdd", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// ZAdd indicates an expected call of ZAdd.
+func (mr *MockPersisterMockRecorder) ZAdd(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZAdd", reflect.TypeOf((*MockPersister)(nil).ZAdd), arg0, arg1, arg2, arg3)
+}
+
+// ZCount mocks base method.
+func (m *MockPersister) ZCount(arg0 context.Context, arg1 []byte, arg2, arg3 float64) int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZCount", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
 	return ret0
 }
 
-// TTL indicates an expected call of TTL.
-func (mr *MockPersisterMockRecorder) TTL(arg0, arg1 any) *gomock.Call {
+// ZCount indicates an expected call of ZCount.
+func (mr *MockPersisterMockRecorder) ZCount(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZCount", reflect.TypeOf((*MockPersister)(nil).ZCount), arg0, arg1, arg2, arg3)
+}
+
+// ZRange mocks base method.
+func (m *MockPersister) ZRange(arg0 context.Context, arg1 []byte, arg2, arg3 int64) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZRange", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZRange indicates an expected call of ZRange.
+func (mr *MockPersisterMockRecorder) ZRange(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZRange", reflect.TypeOf((*MockPersister)(nil).ZRange), arg0, arg1, arg2, arg3)
+}
+
+// ZRangeWithScores mocks base method.
+func (m *MockPersister) ZRangeWithScores(arg0 context.Context, arg1 []byte, arg2, arg3 int64) ([][]byte, []float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZRangeWithScores", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].([]float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ZRangeWithScores indicates an expected call of ZRangeWithScores.
+func (mr *MockPersisterMockRecorder) ZRangeWithScores(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TTL", reflect.TypeOf((*MockPersister)(nil).TTL), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZRangeWithScores", reflect.TypeOf((*MockPersister)(nil).ZRangeWithScores), arg0, arg1, arg2, arg3)
 }
 
 // MockDispatcher is a mock of Dispatcher interface.
@@ -190,6 +1204,173 @@ func (mr *MockDispatcherMockRecorder) Clear() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockDispatcher)(nil).Clear))
 }
 
+// InMulti mocks base method.
+func (m *MockDispatcher) InMulti() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InMulti")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// InMulti indicates an expected call of InMulti.
+func (mr *MockDispatcherMockRecorder) InMulti() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InMulti", reflect.TypeOf((*MockDispatcher)(nil).InMulti))
+}
+
+// Protocol mocks base method.
+func (m *MockDispatcher) Protocol() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Protocol")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Protocol indicates an expected call of Protocol.
+func (mr *MockDispatcherMockRecorder) Protocol() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Protocol", reflect.TypeOf((*MockDispatcher)(nil).Protocol))
+}
+
+// MockConfigurer is a mock of Configurer interface.
+type MockConfigurer struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfigurerMockRecorder
+	isgomock struct{}
+}
+
+// MockConfigurerMockRecorder is the mock recorder for MockConfigurer.
+type MockConfigurerMockRecorder struct {
+	mock *MockConfigurer
+}
+
+// NewMockConfigurer creates a new mock instance.
+func NewMockConfigurer(ctrl *gomock.Controller) *MockConfigurer {
+	mock := &MockConfigurer{ctrl: ctrl}
+	mock.recorder = &MockConfigurerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfigurer) EXPECT() *MockConfigurerMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockConfigurer) Get(patterns ...[]byte) [][]byte {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range patterns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].([][]byte)
+	return ret0
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockConfigurerMockRecorder) Get(patterns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockConfigurer)(nil).Get), patterns...)
+}
+
+// Rewrite mocks base method.
+func (m *MockConfigurer) Rewrite() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rewrite")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rewrite indicates an expected call of Rewrite.
+func (mr *MockConfigurerMockRecorder) Rewrite() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rewrite", reflect.TypeOf((*MockConfigurer)(nil).Rewrite))
+}
+
+// Set mocks base method.
+func (m *MockConfigurer) Set(pairs ...[]byte) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range pairs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Set", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockConfigurerMockRecorder) Set(pairs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockConfigurer)(nil).Set), pairs...)
+}
+
+// MockEngine is a mock of Engine interface.
+type MockEngine struct {
+	ctrl     *gomock.Controller
+	recorder *MockEngineMockRecorder
+	isgomock struct{}
+}
+
+// MockEngineMockRecorder is the mock recorder for MockEngine.
+type MockEngineMockRecorder struct {
+	mock *MockEngine
+}
+
+// NewMockEngine creates a new mock instance.
+func NewMockEngine(ctrl *gomock.Controller) *MockEngine {
+	mock := &MockEngine{ctrl: ctrl}
+	mock.recorder = &MockEngineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEngine) EXPECT() *MockEngineMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockEngine) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockEngineMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockEngine)(nil).Close))
+}
+
+// Info mocks base method.
+func (m *MockEngine) Info(arg0 context.Context) (domain.StorageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Info", arg0)
+	ret0, _ := ret[0].(domain.StorageInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockEngineMockRecorder) Info(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockEngine)(nil).Info), arg0)
+}
+
+// Sync mocks base method.
+func (m *MockEngine) Sync() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockEngineMockRecorder) Sync() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockEngine)(nil).Sync))
+}
+
 // MockLogicaler is a mock of Logicaler interface.
 type MockLogicaler struct {
 	ctrl     *gomock.Controller
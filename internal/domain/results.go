@@ -6,31 +6,95 @@ func NewResult() *Result {
 }
 
 func (result *Result) SetCanceled() *Result {
+	result.Clear()
 	result.Error = ErrCanceled
-	result.Response = nil
 	return result
 }
 
 func (result *Result) SetEmpty() *Result {
+	result.Clear()
 	result.Error = ErrEmpty
-	result.Response = nil
 	return result
 }
 
 func (result *Result) SetNil() *Result {
-	result.Error = nil
-	result.Response = nil
-	return result
+	return result.Clear()
 }
 
 func (result *Result) Clear() *Result {
 	result.Error = nil
 	result.Response = nil
+	result.Kind = ReplyBulk
+	result.Integer = 0
+	result.Double = 0
+	result.Items = nil
 	return result
 }
 
 func (result *Result) SetOK() *Result {
-	result.Response = OK
-	result.Error = nil
+	return result.SetSimple(OK)
+}
+
+func (result *Result) SetSimple(value []byte) *Result {
+	result.Clear()
+	result.Kind = ReplySimple
+	result.Response = value
+	return result
+}
+
+func (result *Result) SetInteger(value int64) *Result {
+	result.Clear()
+	result.Kind = ReplyInteger
+	result.Integer = value
+	return result
+}
+
+func (result *Result) SetBool(value bool) *Result {
+	if value {
+		return result.SetInteger(1)
+	}
+
+	return result.SetInteger(0)
+}
+
+func (result *Result) SetDouble(value float64) *Result {
+	result.Clear()
+	result.Kind = ReplyDouble
+	result.Double = value
 	return result
 }
+
+func (result *Result) SetArray(values [][]byte) *Result {
+	return result.SetItems(ReplyArray, bulkItems(values))
+}
+
+func (result *Result) SetMap(pairs [][]byte) *Result {
+	return result.SetItems(ReplyMap, bulkItems(pairs))
+}
+
+func (result *Result) SetSet(values [][]byte) *Result {
+	return result.SetItems(ReplySet, bulkItems(values))
+}
+
+func (result *Result) SetItems(kind ReplyKind, items Results) *Result {
+	result.Clear()
+	result.Kind = kind
+	result.Items = items
+	return result
+}
+
+func (result *Result) SetNullArray() *Result {
+	result.Clear()
+	result.Kind = ReplyNullArray
+	return result
+}
+
+func bulkItems(values [][]byte) Results {
+	items := make(Results, 0, len(values))
+
+	for _, value := range values {
+		items = append(items, &Result{Response: value})
+	}
+
+	return items
+}
@@ -84,6 +84,72 @@ var _ = Describe("Results", func() {
 				Expect(returnedResult).To(Equal(result)) // Should return self for chaining
 				Expect(result.Error).To(BeNil())
 				Expect(result.Response).To(Equal(domain.OK))
+				Expect(result.Kind).To(Equal(domain.ReplySimple))
+			})
+		})
+
+		Describe("SetInteger", func() {
+			It("should set an integer reply and clear the response", func() {
+				result.Response = []byte("some data")
+
+				returnedResult := result.SetInteger(42)
+
+				Expect(returnedResult).To(Equal(result))
+				Expect(result.Kind).To(Equal(domain.ReplyInteger))
+				Expect(result.Integer).To(Equal(int64(42)))
+				Expect(result.Response).To(BeNil())
+			})
+		})
+
+		Describe("SetBool", func() {
+			It("should set 1 for true and 0 for false", func() {
+				Expect(result.SetBool(true).Integer).To(Equal(int64(1)))
+				Expect(result.SetBool(false).Integer).To(Equal(int64(0)))
+				Expect(result.Kind).To(Equal(domain.ReplyInteger))
+			})
+		})
+
+		Describe("SetDouble", func() {
+			It("should set a double reply", func() {
+				result.SetDouble(1.5)
+
+				Expect(result.Kind).To(Equal(domain.ReplyDouble))
+				Expect(result.Double).To(Equal(1.5))
+			})
+		})
+
+		Describe("SetArray", func() {
+			It("should wrap every value in a bulk item and keep nil values as nulls", func() {
+				result.SetArray([][]byte{[]byte("a"), nil})
+
+				Expect(result.Kind).To(Equal(domain.ReplyArray))
+				Expect(result.Items).To(HaveLen(2))
+				Expect(result.Items[0].Kind).To(Equal(domain.ReplyBulk))
+				Expect(result.Items[0].Response).To(Equal([]byte("a")))
+				Expect(result.Items[1].Response).To(BeNil())
+			})
+		})
+
+		Describe("SetMap and SetSet", func() {
+			It("should keep the flattened items and tag the kind", func() {
+				result.SetMap([][]byte{[]byte("field"), []byte("value")})
+				Expect(result.Kind).To(Equal(domain.ReplyMap))
+				Expect(result.Items).To(HaveLen(2))
+
+				result.SetSet([][]byte{[]byte("member")})
+				Expect(result.Kind).To(Equal(domain.ReplySet))
+				Expect(result.Items).To(HaveLen(1))
+			})
+		})
+
+		Describe("SetNullArray", func() {
+			It("should clear items and mark the reply as a null array", func() {
+				result.SetArray([][]byte{[]byte("a")})
+
+				result.SetNullArray()
+
+				Expect(result.Kind).To(Equal(domain.ReplyNullArray))
+				Expect(result.Items).To(BeNil())
 			})
 		})
 	})
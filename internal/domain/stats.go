@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var LatencyBuckets = []time.Duration{
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+}
+
+func NewStats() *Stats {
+	return &Stats{started: time.Now()}
+}
+
+func (stats *Stats) Started() time.Time {
+	return stats.started
+}
+
+func (stats *Stats) Connected(clients int) {
+	stats.connections.Add(1)
+	stats.clients.Store(int64(clients))
+}
+
+func (stats *Stats) Disconnected(clients int) {
+	stats.clients.Store(int64(clients))
+}
+
+func (stats *Stats) Refused() {
+	stats.refused.Add(1)
+}
+
+func (stats *Stats) Clients() int64 {
+	return stats.clients.Load()
+}
+
+func (stats *Stats) Connections() int64 {
+	return stats.connections.Load()
+}
+
+func (stats *Stats) RefusedConnections() int64 {
+	return stats.refused.Load()
+}
+
+func (stats *Stats) Record(name string, elapsed time.Duration, err error) {
+	command := stats.command(name)
+	command.calls.Add(1)
+	command.usec.Add(elapsed.Microseconds())
+	command.latency[latencyBucket(elapsed)].Add(1)
+
+	if err != nil {
+		command.failed.Add(1)
+	}
+}
+
+func (stats *Stats) Reject(name string) {
+	stats.command(name).rejected.Add(1)
+}
+
+func (stats *Stats) Commands() []CommandStat {
+	commands := make([]CommandStat, 0)
+
+	stats.commands.Range(func(name, value any) bool {
+		command, _ := value.(*commandCounter)
+		text, _ := name.(string)
+		commands = append(commands, command.snapshot(text))
+		return true
+	})
+
+	slices.SortFunc(commands, func(left, right CommandStat) int {
+		return strings.Compare(left.Name, right.Name)
+	})
+
+	return commands
+}
+
+func (stats *Stats) Processed() int64 {
+	var processed int64
+
+	stats.commands.Range(func(_, value any) bool {
+		command, _ := value.(*commandCounter)
+		processed += command.calls.Load()
+		return true
+	})
+
+	return processed
+}
+
+func (stats *Stats) Reset() {
+	stats.commands.Clear()
+	stats.connections.Store(0)
+	stats.refused.Store(0)
+}
+
+func (stats *Stats) command(name string) *commandCounter {
+	entry, found := stats.commands.Load(name)
+
+	if !found {
+		entry, _ = stats.commands.LoadOrStore(name, &commandCounter{
+			latency: make([]atomic.Int64, len(LatencyBuckets)+1),
+		})
+	}
+
+	command, _ := entry.(*commandCounter)
+	return command
+}
+
+func (command *commandCounter) snapshot(name string) CommandStat {
+	latency := make([]int64, len(command.latency))
+
+	for index := range command.latency {
+		latency[index] = command.latency[index].Load()
+	}
+
+	return CommandStat{
+		Name:     name,
+		Calls:    command.calls.Load(),
+		Usec:     command.usec.Load(),
+		Rejected: command.rejected.Load(),
+		Failed:   command.failed.Load(),
+		Latency:  latency,
+	}
+}
+
+func latencyBucket(elapsed time.Duration) int {
+	bucket, _ := slices.BinarySearch(LatencyBuckets, elapsed)
+	return bucket
+}
@@ -0,0 +1,91 @@
+package domain_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+var _ = Describe("Stats", func() {
+	var stats *domain.Stats
+
+	BeforeEach(func() {
+		stats = domain.NewStats()
+	})
+
+	It("should track connected clients and connection totals", func() {
+		stats.Connected(1)
+		stats.Connected(2)
+		stats.Disconnected(1)
+		stats.Refused()
+
+		Expect(stats.Clients()).To(Equal(int64(1)))
+		Expect(stats.Connections()).To(Equal(int64(2)))
+		Expect(stats.RefusedConnections()).To(Equal(int64(1)))
+	})
+
+	It("should aggregate calls, failures and rejections per command", func() {
+		stats.Record("SET", 3*time.Microsecond, nil)
+		stats.Record("SET", 5*time.Microsecond, errors.New("ERR"))
+		stats.Record("GET", time.Microsecond, nil)
+		stats.Reject("GET")
+
+		commands := stats.Commands()
+
+		Expect(commands).To(HaveLen(2))
+		Expect(commands[0].Name).To(Equal("GET"))
+		Expect(commands[0].Calls).To(Equal(int64(1)))
+		Expect(commands[0].Rejected).To(Equal(int64(1)))
+		Expect(commands[1].Name).To(Equal("SET"))
+		Expect(commands[1].Calls).To(Equal(int64(2)))
+		Expect(commands[1].Usec).To(Equal(int64(8)))
+		Expect(commands[1].Failed).To(Equal(int64(1)))
+		Expect(stats.Processed()).To(Equal(int64(3)))
+	})
+
+	It("should count calls recorded concurrently", func() {
+		var group sync.WaitGroup
+
+		for range 8 {
+			group.Go(func() {
+				for range 1000 {
+					stats.Record("GET", time.Microsecond, nil)
+				}
+			})
+		}
+
+		group.Wait()
+
+		Expect(stats.Processed()).To(Equal(int64(8000)))
+		Expect(stats.Commands()[0].Usec).To(Equal(int64(8000)))
+	})
+
+	It("should count latencies in the bucket of their upper bound", func() {
+		stats.Record("GET", 50*time.Microsecond, nil)
+		stats.Record("GET", time.Millisecond, nil)
+		stats.Record("GET", 2*time.Second, nil)
+
+		latency := stats.Commands()[0].Latency
+
+		Expect(latency).To(HaveLen(len(domain.LatencyBuckets) + 1))
+		Expect(latency[0]).To(Equal(int64(1)))
+		Expect(latency[3]).To(Equal(int64(1)))
+		Expect(latency[len(domain.LatencyBuckets)]).To(Equal(int64(1)))
+	})
+
+	It("should reset counters but keep the connected clients", func() {
+		stats.Connected(1)
+		stats.Record("GET", time.Microsecond, nil)
+
+		stats.Reset()
+
+		Expect(stats.Commands()).To(BeEmpty())
+		Expect(stats.Connections()).To(BeZero())
+		Expect(stats.Clients()).To(Equal(int64(1)))
+	})
+})
@@ -3,6 +3,9 @@ package domain
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 var (
@@ -14,7 +17,32 @@ var (
 	ErrCanceled       error = errors.New("ERR operation canceled")
 	ErrInvalidFloat   error = errors.New("ERR value is not a valid float")
 	ErrInvalidInteger error = errors.New("ERR value is not an integer or out of range")
+	ErrSyntax         error = errors.New("ERR syntax error")
+	ErrInvalidCursor  error = errors.New("ERR invalid cursor")
 	ErrWrongType      error = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+	ErrWatchInMulti   error = errors.New("ERR WATCH inside MULTI is not allowed")
+	ErrExecNoMulti    error = errors.New("ERR EXEC without MULTI")
+	ErrExecAbort      error = errors.New("EXECABORT Transaction discarded because of previous errors.")
+	ErrNoProto        error = errors.New("NOPROTO unsupported protocol version")
+	ErrProtoVersion   error = errors.New("ERR Protocol version is not an integer or out of range")
+	ErrWrongPass      error = errors.New("WRONGPASS invalid username-password pair or user is disabled.")
+	ErrClientName     error = errors.New("ERR Client names cannot contain spaces, newlines or special characters.")
+	ErrNoConfigFile   error = errors.New("ERR The server is running without a config file")
+	ErrMaxClients     error = errors.New("ERR max number of clients reached")
+	ErrShuttingDown   error = errors.New("ERR server is shutting down")
+	ErrNotInMulti     error = errors.New("ERR Command not allowed inside a transaction")
+)
+
+const (
+	ReplyBulk ReplyKind = iota
+	ReplySimple
+	ReplyInteger
+	ReplyDouble
+	ReplyArray
+	ReplyMap
+	ReplySet
+	ReplyPairs
+	ReplyNullArray
 )
 
 const (
@@ -22,6 +50,19 @@ const (
 	MULTI   string = "MULTI"
 	EXEC    string = "EXEC"
 	DISCARD string = "DISCARD"
+	WATCH   string = "WATCH"
+
+	SHUTDOWN string = "SHUTDOWN"
+
+	ServerName    string = "keyp"
+	ServerVersion string = "7.2.0"
+
+	RESP2 = 2
+	RESP3 = 3
+
+	SyncAlways   string = "always"
+	SyncEverySec string = "everysec"
+	SyncNo       string = "no"
 
 	EmptyArgs  = 0
 	CommandArg = 0
@@ -31,9 +72,63 @@ const (
 )
 
 type (
+	ReplyKind uint8
+
 	Result struct {
 		Error    error
 		Response []byte
+		Kind     ReplyKind
+		Integer  int64
+		Double   float64
+		Items    Results
+	}
+
+	CommandStat struct {
+		Name     string
+		Calls    int64
+		Usec     int64
+		Rejected int64
+		Failed   int64
+		Latency  []int64
+	}
+
+	commandCounter struct {
+		calls    atomic.Int64
+		usec     atomic.Int64
+		rejected atomic.Int64
+		failed   atomic.Int64
+		latency  []atomic.Int64
+	}
+
+	Stats struct {
+		started     time.Time
+		clients     atomic.Int64
+		connections atomic.Int64
+		refused     atomic.Int64
+		commands    sync.Map
+	}
+
+	KeyspaceInfo struct {
+		DB      uint8
+		Keys    int64
+		Expires int64
+	}
+
+	StorageInfo struct {
+		MapSize     int64
+		UsedPages   int64
+		UsedBytes   int64
+		PageSize    int64
+		LastTxnID   int64
+		MaxReaders  int64
+		Readers     int64
+		ExpiredKeys int64
+		ExpireRuns  int64
+		ExpireTime  time.Duration
+		ExpireLast  time.Duration
+		LastSync    time.Time
+		SyncFailed  bool
+		Keyspaces   []KeyspaceInfo
 	}
 
 	Command  func(Args) *Result
@@ -42,15 +137,30 @@ type (
 	Commands map[string]Command
 
 	Persister interface {
-		Set(context.Context, []byte, []byte) error
+		SetWith(context.Context, []byte, []byte, SetOptions) ([]byte, bool, error)
 		Get(context.Context, []byte) ([]byte, error)
+		MGet(context.Context, ...[]byte) ([][]byte, error)
+		MSet(context.Context, ...[]byte) error
+		MSetNX(context.Context, ...[]byte) (bool, error)
+		GetDel(context.Context, []byte) ([]byte, error)
+		GetEx(context.Context, []byte, GetExOptions) ([]byte, error)
 		Del(context.Context, ...[]byte) (uint32, error)
 
-		TTL(context.Context, []byte) uint32
 		Persist(context.Context, []byte) bool
-		Expire(context.Context, []byte, uint32)
+		ExpireAt(context.Context, []byte, int64, ExpireCondition) (bool, error)
+		ExpireTime(context.Context, []byte) (int64, error)
 
 		Exists(context.Context, []byte) bool
+		Type(context.Context, []byte) string
+		Encoding(context.Context, []byte) (string, error)
+		IdleTime(context.Context, []byte) (int64, error)
+		Frequency(context.Context, []byte) (int64, error)
+
+		Scan(context.Context, []byte, []byte, int64, string) ([]byte, [][]byte, error)
+		Keys(context.Context, []byte) ([][]byte, error)
+		DBSize(context.Context) (int64, error)
+		Info(context.Context) (StorageInfo, error)
+
 		LLen(context.Context, []byte) int64
 		LIndex(context.Context, []byte, int64) ([]byte, error)
 		LSet(context.Context, []byte, int64, []byte) error
@@ -61,35 +171,109 @@ type (
 		LRange(context.Context, []byte, int64, int64) ([][]byte, error)
 
 		FlushAll(context.Context) error
-		SAdd(context.Context, []byte, ...[]byte) int64
-		SRem(context.Context, []byte, ...[]byte) int64
+		SAdd(context.Context, []byte, ...[]byte) (int64, error)
+		SRem(context.Context, []byte, ...[]byte) (int64, error)
 		SMembers(context.Context, []byte) ([][]byte, error)
 		SIsMember(context.Context, []byte, []byte) bool
 
 		ZAdd(context.Context, []byte, float64, []byte) int64
 		ZRange(context.Context, []byte, int64, int64) ([][]byte, error)
+		ZRangeWithScores(context.Context, []byte, int64, int64) ([][]byte, []float64, error)
 		ZCount(context.Context, []byte, float64, float64) int64
 
+		HSet(context.Context, []byte, ...[]byte) (int64, error)
+		HSetNX(context.Context, []byte, []byte, []byte) (bool, error)
+		HGet(context.Context, []byte, []byte) ([]byte, error)
+		HMGet(context.Context, []byte, ...[]byte) ([][]byte, error)
+		HDel(context.Context, []byte, ...[]byte) (int64, error)
+		HExists(context.Context, []byte, []byte) (bool, error)
+		HLen(context.Context, []byte) (int64, error)
+		HKeys(context.Context, []byte) ([][]byte, error)
+		HVals(context.Context, []byte) ([][]byte, error)
+		HGetAll(context.Context, []byte) ([][]byte, error)
+		HIncrBy(context.Context, []byte, []byte, int64) (int64, error)
+		HIncrByFloat(context.Context, []byte, []byte, float64) ([]byte, error)
+		HStrLen(context.Context, []byte, []byte) (int64, error)
+		HScan(context.Context, []byte, []byte, []byte, int64) ([]byte, [][]byte, error)
+
 		Incr(context.Context, []byte) (int64, error)
 		IncrBy(context.Context, []byte, int64) (int64, error)
+		IncrByFloat(context.Context, []byte, float64) ([]byte, error)
 		Decr(context.Context, []byte) (int64, error)
 		DecrBy(context.Context, []byte, int64) (int64, error)
 
 		Append(context.Context, []byte, []byte) int64
+		StrLen(context.Context, []byte) (int64, error)
+		GetRange(context.Context, []byte, int64, int64) ([]byte, error)
+		SetRange(context.Context, []byte, int64, []byte) (int64, error)
+		GetBit(context.Context, []byte, uint64) (int64, error)
+		SetBit(context.Context, []byte, uint64, int64) (int64, error)
+		BitCount(context.Context, []byte, BitRange) (int64, error)
+		BitPos(context.Context, []byte, int64, BitRange) (int64, error)
+		BitOp(context.Context, BitOperation, []byte, ...[]byte) (int64, error)
+		BitField(context.Context, []byte, []BitFieldOp) ([]*int64, error)
 
+		Atomic(context.Context, func(context.Context) error) error
+		Watch(context.Context, uint64, ...[]byte) (uint64, error)
+		Modified(context.Context, uint64) bool
+		Unwatch(uint64)
 		Close()
 	}
 
 	Dispatcher interface {
 		Apply(ctx context.Context, args Args) Results
+		Protocol() int
+		InMulti() bool
 		Clear()
 	}
 
+	Configurer interface {
+		Get(patterns ...[]byte) [][]byte
+		Set(pairs ...[]byte) error
+		Rewrite() error
+	}
+
+	Engine interface {
+		Info(context.Context) (StorageInfo, error)
+		Sync() error
+		Close()
+	}
+
 	Logicaler interface {
 		Get(ctx context.Context) Dispatcher
 		Free(handler Dispatcher)
 	}
 
+	SetOptions struct {
+		NX       bool
+		XX       bool
+		Get      bool
+		KeepTTL  bool
+		Deadline int64
+	}
+
+	GetExOptions struct {
+		Persist  bool
+		Deadline int64
+	}
+
+	// BitRange.HasEnd tells BITPOS whether End was given.
+	BitRange struct {
+		Start  int64
+		End    int64
+		Bit    bool
+		HasEnd bool
+	}
+
+	BitFieldOp struct {
+		Kind     BitFieldKind
+		Signed   bool
+		Width    uint8
+		Offset   uint64
+		Value    int64
+		Overflow BitOverflow
+	}
+
 	Validation struct {
 		MinArgs int
 		MaxArgs int
@@ -98,8 +282,43 @@ type (
 	Validations map[string]*Validation
 
 	CTX string
+
+	ExpireCondition uint8
+
+	BitOperation uint8
+	BitFieldKind uint8
+	BitOverflow  uint8
+)
+
+const (
+	ExpireAlways ExpireCondition = iota
+	ExpireNX
+	ExpireXX
+	ExpireGT
+	ExpireLT
+)
+
+const (
+	BitAnd BitOperation = iota
+	BitOr
+	BitXor
+	BitNot
+)
+
+const (
+	BitFieldGet BitFieldKind = iota
+	BitFieldSet
+	BitFieldIncrBy
+)
+
+const (
+	OverflowWrap BitOverflow = iota
+	OverflowSat
+	OverflowFail
 )
 
+const NoDeadline int64 = 0
+
 const (
 	DB = CTX("DB")
 	ID = CTX("ID")
@@ -10,6 +10,6 @@ func (handler *Handler) append(args Args) *Result {
 	value := args[domain.SecondArg]
 
 	length := handler.storage.Append(handler.context, key, value)
-	res.Response = formatInt64(length)
+	res.SetInteger(length)
 	return res
 }
@@ -3,48 +3,87 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
+// Apply records one call per invocation, so queued commands and EXEC count once each.
 func (handler *Handler) Apply(ctx context.Context, args Args) Results {
 	if emptyArgs(args) {
 		return Results{{Error: domain.ErrEmpty}}
 	}
 
+	started := time.Now()
 	cmdName := normalizeCommandName(string(args[0]))
+	results, counted := handler.dispatch(cmdName, args)
 
+	if counted {
+		handler.stats.Record(cmdName, time.Since(started), results[0].Error)
+	}
+
+	return results
+}
+
+func (handler *Handler) dispatch(cmdName string, args Args) (Results, bool) {
 	if cmdName == domain.MULTI {
 		handler.multEnabled = true
-		return Results{{Response: OK}}
+		return Results{domain.NewResult().SetOK()}, true
 	}
 
 	if cmdName == domain.DISCARD {
-		handler.multEnabled = false
-		handler.multArgs = handler.multArgs[:0]
-		return Results{{Response: OK}}
+		handler.discard()
+		return Results{domain.NewResult().SetOK()}, true
 	}
 
 	if cmdName == domain.EXEC {
-		return handler.exec()
+		return handler.exec(), true
+	}
+
+	if handler.multEnabled && cmdName == domain.SHUTDOWN {
+		handler.stats.Reject(cmdName)
+		return handler.reject(domain.ErrNotInMulti), false
 	}
 
 	validation, exists := handler.validations[cmdName]
 
 	if !exists {
-		return Results{{Error: errors.New("ERR unknown command '" + cmdName + "'")}}
+		return handler.reject(errors.New("ERR unknown command '" + cmdName + "'")), false
 	}
 
 	err := isValid(validation, cmdName, len(args))
 
 	if hasError(err) {
-		return Results{{Error: err}}
+		handler.stats.Reject(cmdName)
+		return handler.reject(err), false
+	}
+
+	if handler.multEnabled && cmdName == domain.WATCH {
+		handler.stats.Reject(cmdName)
+		return Results{{Error: domain.ErrWatchInMulti}}, false
 	}
 
 	if handler.multEnabled {
 		handler.multArgs = append(handler.multArgs, args)
-		return Results{{Response: domain.QUEUED}}
+		return Results{domain.NewResult().SetSimple(domain.QUEUED)}, true
+	}
+
+	return Results{handler.run(cmdName, args)}, true
+}
+
+// InMulti reports whether MULTI is queueing commands on this connection.
+func (handler *Handler) InMulti() bool {
+	return handler.multEnabled
+}
+
+func (handler *Handler) run(cmdName string, args Args) *Result {
+	return handler.commands[cmdName](args)
+}
+
+func (handler *Handler) reject(err error) Results {
+	if handler.multEnabled {
+		handler.multFailed = true
 	}
 
-	return Results{handler.commands[cmdName](args)}
+	return Results{{Error: err}}
 }
@@ -0,0 +1,22 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) bitcount(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+
+	span, err := parseBitRange(args[domain.SecondArg:], false)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	count, err := handler.storage.BitCount(handler.context, key, span)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetInteger(count)
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+const (
+	maxSignedWidth   = 64
+	maxUnsignedWidth = 63
+)
+
+var (
+	errBitFieldType     = errors.New("ERR Invalid bitfield type. Use something like i16 u8. Note that u64 is not supported but i64 is.")
+	errBitFieldOverflow = errors.New("ERR Invalid OVERFLOW type specified")
+)
+
+var bitOverflows = map[string]domain.BitOverflow{
+	"WRAP": domain.OverflowWrap,
+	"SAT":  domain.OverflowSat,
+	"FAIL": domain.OverflowFail,
+}
+
+func (handler *Handler) bitfield(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+
+	ops, err := parseBitField(args[domain.SecondArg:])
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	values, err := handler.storage.BitField(handler.context, key, ops)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	items := make(Results, 0, len(values))
+
+	for _, value := range values {
+		if value == nil {
+			items = append(items, domain.NewResult())
+			continue
+		}
+
+		items = append(items, domain.NewResult().SetInteger(*value))
+	}
+
+	return res.SetItems(domain.ReplyArray, items)
+}
+
+func parseBitField(args Args) ([]domain.BitFieldOp, error) {
+	ops := make([]domain.BitFieldOp, 0, len(args)/3)
+	overflow := domain.OverflowWrap
+
+	for index := 0; index < len(args); {
+		subcommand := strings.ToUpper(string(args[index]))
+
+		if subcommand == "OVERFLOW" {
+			if index+1 >= len(args) {
+				return nil, domain.ErrSyntax
+			}
+
+			mode, found := bitOverflows[strings.ToUpper(string(args[index+1]))]
+			if !found {
+				return nil, errBitFieldOverflow
+			}
+
+			overflow = mode
+			index += 2
+			continue
+		}
+
+		op := domain.BitFieldOp{Overflow: overflow}
+		size := 4
+
+		switch subcommand {
+		case "GET":
+			op.Kind = domain.BitFieldGet
+			size = 3
+		case "SET":
+			op.Kind = domain.BitFieldSet
+		case "INCRBY":
+			op.Kind = domain.BitFieldIncrBy
+		default:
+			return nil, domain.ErrSyntax
+		}
+
+		if index+size > len(args) {
+			return nil, domain.ErrSyntax
+		}
+
+		if err := parseBitFieldOp(&op, args[index+1:index+size]); hasError(err) {
+			return nil, err
+		}
+
+		ops = append(ops, op)
+		index += size
+	}
+
+	return ops, nil
+}
+
+func parseBitFieldOp(op *domain.BitFieldOp, args Args) error {
+	var err error
+
+	if op.Signed, op.Width, err = parseBitFieldType(args[0]); hasError(err) {
+		return err
+	}
+
+	if op.Offset, err = parseBitFieldOffset(args[1], op.Width); hasError(err) {
+		return err
+	}
+
+	if op.Kind == domain.BitFieldGet {
+		return nil
+	}
+
+	if op.Value, err = strconv.ParseInt(string(args[2]), 10, 64); hasError(err) {
+		return domain.ErrInvalidInteger
+	}
+
+	return nil
+}
+
+func parseBitFieldType(arg []byte) (bool, uint8, error) {
+	if len(arg) < 2 {
+		return false, 0, errBitFieldType
+	}
+
+	signed := arg[0] == 'i' || arg[0] == 'I'
+	limit := maxUnsignedWidth
+
+	if signed {
+		limit = maxSignedWidth
+	} else if arg[0] != 'u' && arg[0] != 'U' {
+		return false, 0, errBitFieldType
+	}
+
+	width, err := strconv.Atoi(string(arg[1:]))
+	if hasError(err) || width < 1 || width > limit {
+		return false, 0, errBitFieldType
+	}
+
+	return signed, uint8(width), nil
+}
+
+func parseBitFieldOffset(arg []byte, width uint8) (uint64, error) {
+	multiplier := uint64(1)
+
+	if len(arg) > 0 && arg[0] == '#' {
+		multiplier = uint64(width)
+		arg = arg[1:]
+	}
+
+	offset, err := strconv.ParseUint(string(arg), 10, 64)
+
+	if hasError(err) || offset > maxBitOffset/multiplier {
+		return 0, errBitOffset
+	}
+
+	offset *= multiplier
+
+	if offset+uint64(width)-1 > maxBitOffset {
+		return 0, errBitOffset
+	}
+
+	return offset, nil
+}
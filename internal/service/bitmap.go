@@ -0,0 +1,81 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+// maxBitOffset is the last bit of a 512MB string.
+const maxBitOffset = 512<<20*8 - 1
+
+var (
+	errBitOffset = errors.New("ERR bit offset is not an integer or out of range")
+	errBitValue  = errors.New("ERR bit is not an integer or out of range")
+)
+
+func parseBitOffset(arg []byte) (uint64, error) {
+	offset, err := strconv.ParseUint(string(arg), 10, 64)
+
+	if hasError(err) || offset > maxBitOffset {
+		return 0, errBitOffset
+	}
+
+	return offset, nil
+}
+
+func parseBit(arg []byte, invalid error) (int64, error) {
+	switch string(arg) {
+	case "0":
+		return 0, nil
+	case "1":
+		return 1, nil
+	}
+
+	return 0, invalid
+}
+
+// parseBitRange accepts a start without end only when partial is set.
+func parseBitRange(args Args, partial bool) (domain.BitRange, error) {
+	span := domain.BitRange{End: -1}
+
+	if len(args) == 0 {
+		return span, nil
+	}
+
+	if len(args) > 3 || (len(args) == 1 && !partial) {
+		return span, domain.ErrSyntax
+	}
+
+	var err error
+
+	if span.Start, err = strconv.ParseInt(string(args[0]), 10, 64); hasError(err) {
+		return span, domain.ErrInvalidInteger
+	}
+
+	if len(args) == 1 {
+		return span, nil
+	}
+
+	if span.End, err = strconv.ParseInt(string(args[1]), 10, 64); hasError(err) {
+		return span, domain.ErrInvalidInteger
+	}
+
+	span.HasEnd = true
+
+	if len(args) == 2 {
+		return span, nil
+	}
+
+	switch strings.ToUpper(string(args[2])) {
+	case "BYTE":
+	case "BIT":
+		span.Bit = true
+	default:
+		return span, domain.ErrSyntax
+	}
+
+	return span, nil
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+var errBitOpNot = errors.New("ERR BITOP NOT must be called with a single source key.")
+
+var bitOperations = map[string]domain.BitOperation{
+	"AND": domain.BitAnd,
+	"OR":  domain.BitOr,
+	"XOR": domain.BitXor,
+	"NOT": domain.BitNot,
+}
+
+func (handler *Handler) bitop(args Args) *Result {
+	res := domain.NewResult()
+	dest := args[domain.SecondArg]
+	keys := args[domain.ThirdArg:]
+
+	op, found := bitOperations[strings.ToUpper(string(args[domain.FirstArg]))]
+	if !found {
+		res.Error = domain.ErrSyntax
+		return res
+	}
+
+	if op == domain.BitNot && len(keys) != 1 {
+		res.Error = errBitOpNot
+		return res
+	}
+
+	length, err := handler.storage.BitOp(handler.context, op, dest, keys...)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetInteger(length)
+}
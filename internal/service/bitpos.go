@@ -0,0 +1,34 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+var errBitPosArg = errors.New("ERR The bit argument must be 1 or 0.")
+
+func (handler *Handler) bitpos(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+
+	bit, err := parseBit(args[domain.SecondArg], errBitPosArg)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	span, err := parseBitRange(args[domain.ThirdArg:], true)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	position, err := handler.storage.BitPos(handler.context, key, bit, span)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetInteger(position)
+}
@@ -1,7 +1,26 @@
 package service
 
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
 func (handler *Handler) Clear() {
+	handler.discard()
+	handler.protocol = domain.RESP2
+	handler.name = ""
+	handler.context = nil
+}
+
+func (handler *Handler) discard() {
+	handler.release()
 	handler.multEnabled = false
+	handler.multFailed = false
 	handler.multArgs = handler.multArgs[:0]
-	handler.context = nil
+}
+
+func (handler *Handler) release() {
+	if handler.watching == 0 {
+		return
+	}
+
+	handler.storage.Unwatch(handler.watching)
+	handler.watching = 0
 }
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+var configHelp = [][]byte{
+	[]byte("CONFIG <subcommand> [<arg> [value] [opt] ...]. Subcommands are:"),
+	[]byte("GET <pattern> [<pattern> ...]"),
+	[]byte("    Return parameters matching the glob-like <pattern> and their values."),
+	[]byte("SET <directive> <value> [<directive> <value> ...]"),
+	[]byte("    Set the configuration <directive> to <value>."),
+	[]byte("RESETSTAT"),
+	[]byte("    Reset statistics reported by the INFO command."),
+	[]byte("REWRITE"),
+	[]byte("    Rewrite the configuration file."),
+	[]byte("HELP"),
+	[]byte("    Print this help."),
+}
+
+func (handler *Handler) config(args Args) *Result {
+	res := domain.NewResult()
+	subcommand := normalizeCommandName(string(args[domain.FirstArg]))
+	params := args[domain.SecondArg:]
+
+	switch {
+	case subcommand == "HELP":
+		return res.SetArray(configHelp)
+	case subcommand == "GET" && len(params) > 0:
+		return res.SetMap(handler.settings.Get(params...))
+	case subcommand == "SET" && len(params) > 0 && len(params)%2 == 0:
+		res.Error = handler.settings.Set(params...)
+	case subcommand == "REWRITE" && len(params) == 0:
+		res.Error = handler.settings.Rewrite()
+	case subcommand == "RESETSTAT" && len(params) == 0:
+		handler.stats.Reset()
+	case subcommand == "GET" || subcommand == "SET" || subcommand == "REWRITE" || subcommand == "RESETSTAT":
+		res.Error = newInvalidArgsError("config|" + subcommand)
+	default:
+		res.Error = newUnknownSubcommandError("CONFIG", args[domain.FirstArg])
+	}
+
+	if hasError(res.Error) {
+		return res
+	}
+
+	return res.SetOK()
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+const (
+	scanStart      = 0
+	maxScanCursors = 1 << 16
+	scanCursorTTL  = 10 * time.Minute
+)
+
+type (
+	scanPosition struct {
+		db     uint8
+		scope  string
+		key    []byte
+		issued time.Time
+	}
+
+	// scanCursors is shared by the handlers of a pool, so cursors resume on any connection.
+	scanCursors struct {
+		mutex     sync.Mutex
+		first     uint64
+		last      uint64
+		positions map[uint64]scanPosition
+	}
+)
+
+func newScanCursors() *scanCursors {
+	return &scanCursors{first: scanStart + 1, positions: make(map[uint64]scanPosition)}
+}
+
+func (cursors *scanCursors) save(position scanPosition) uint64 {
+	cursors.mutex.Lock()
+	defer cursors.mutex.Unlock()
+
+	cursors.last++
+	cursors.positions[cursors.last] = position
+
+	for cursors.first < cursors.last && cursors.stale(cursors.first, position.issued) {
+		delete(cursors.positions, cursors.first)
+		cursors.first++
+	}
+
+	return cursors.last
+}
+
+func (cursors *scanCursors) stale(cursor uint64, now time.Time) bool {
+	position, found := cursors.positions[cursor]
+	return !found || cursors.last-cursor >= maxScanCursors || now.Sub(position.issued) > scanCursorTTL
+}
+
+func (cursors *scanCursors) load(cursor uint64) (scanPosition, bool) {
+	cursors.mutex.Lock()
+	defer cursors.mutex.Unlock()
+
+	position, found := cursors.positions[cursor]
+	return position, found && time.Since(position.issued) <= scanCursorTTL
+}
+
+func (handler *Handler) saveCursor(scope, key []byte) uint64 {
+	if key == nil {
+		return scanStart
+	}
+
+	return handler.cursors.save(scanPosition{db: handler.db(), scope: string(scope), key: key, issued: time.Now()})
+}
+
+func (handler *Handler) resumeCursor(scope []byte, cursor uint64) ([]byte, error) {
+	if cursor == scanStart {
+		return nil, nil
+	}
+
+	position, found := handler.cursors.load(cursor)
+	if !found || position.db != handler.db() || position.scope != string(scope) {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	return position.key, nil
+}
+
+func (handler *Handler) db() uint8 {
+	db, _ := handler.context.Value(domain.DB).(uint8)
+	return db
+}
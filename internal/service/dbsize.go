@@ -0,0 +1,21 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) dbsize(_ Args) *Result {
+	res := domain.NewResult()
+
+	size, err := handler.storage.DBSize(handler.context)
+
+	if isContextCanceled(err) {
+		return res.SetCanceled()
+	}
+
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.SetInteger(size)
+	return res
+}
@@ -19,6 +19,6 @@ func (handler *Handler) del(args Args) *Result {
 		return res.SetCanceled()
 	}
 
-	res.Response = formatUint32(deleted)
+	res.SetInteger(int64(deleted))
 	return res
 }
@@ -1,13 +1,69 @@
 package service
 
+import (
+	"context"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
 func (handler *Handler) exec() Results {
-	results := make(Results, 0, len(handler.multArgs))
+	if !handler.multEnabled {
+		return Results{{Error: domain.ErrExecNoMulti}}
+	}
+
+	defer handler.discard()
+
+	if handler.multFailed {
+		return Results{{Error: domain.ErrExecAbort}}
+	}
 
-	if handler.multEnabled {
-		for _, args := range handler.multArgs {
-			results = append(results, handler.do(args))
+	queued := handler.multArgs
+	origin := handler.context
+	results := make(Results, 0, len(queued))
+	aborted := false
+
+	err := handler.storage.Atomic(origin, func(ctx context.Context) error {
+		if handler.watching != 0 && handler.storage.Modified(ctx, handler.watching) {
+			aborted = true
+			return nil
+		}
+
+		handler.context = ctx
+
+		for _, args := range queued {
+			if err := ctx.Err(); hasError(err) {
+				return err
+			}
+
+			results = append(results, handler.run(normalizeCommandName(string(args[domain.CommandArg])), args))
 		}
+
+		return ctx.Err()
+	})
+
+	handler.context = restoreDB(origin, handler.context)
+
+	if isContextCanceled(err) {
+		return Results{domain.NewResult().SetCanceled()}
+	}
+
+	if hasError(err) {
+		return Results{{Error: err}}
+	}
+
+	if aborted {
+		return Results{domain.NewResult().SetNullArray()}
+	}
+
+	return Results{domain.NewResult().SetItems(domain.ReplyArray, results)}
+}
+
+func restoreDB(origin, current context.Context) context.Context {
+	db := current.Value(domain.DB)
+
+	if db == origin.Value(domain.DB) {
+		return origin
 	}
 
-	return results
+	return context.WithValue(origin, domain.DB, db)
 }
@@ -15,6 +15,6 @@ func (handler *Handler) exists(args Args) *Result {
 		}
 	}
 
-	res.Response = formatInt64(count)
+	res.SetInteger(count)
 	return res
 }
@@ -1,14 +1,129 @@
 package service
 
 import (
+	"errors"
+	"math"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
+const (
+	millisPerSecond = int64(time.Second / time.Millisecond)
+	millisPerMilli  = int64(1)
+)
+
+var (
+	errExpireNXConflict = errors.New("ERR NX and XX, GT or LT options at the same time are not compatible")
+	errExpireGTConflict = errors.New("ERR GT and LT options at the same time are not compatible")
+)
+
 func (handler *Handler) expire(args Args) *Result {
+	return handler.expireBy(args, "expire", millisPerSecond, false)
+}
+
+func (handler *Handler) pexpire(args Args) *Result {
+	return handler.expireBy(args, "pexpire", millisPerMilli, false)
+}
+
+func (handler *Handler) expireat(args Args) *Result {
+	return handler.expireBy(args, "expireat", millisPerSecond, true)
+}
+
+func (handler *Handler) pexpireat(args Args) *Result {
+	return handler.expireBy(args, "pexpireat", millisPerMilli, true)
+}
+
+func (handler *Handler) expireBy(args Args, name string, unit int64, absolute bool) *Result {
+	res := domain.NewResult()
 	key := args[domain.FirstArg]
-	secsInt, _ := strconv.ParseInt(string(args[domain.SecondArg]), 10, 64)
-	handler.storage.Expire(handler.context, key, uint32(secsInt))
-	return domain.NewResult().SetOK()
+
+	amount, err := strconv.ParseInt(string(args[domain.SecondArg]), 10, 64)
+	if hasError(err) {
+		res.Error = domain.ErrInvalidInteger
+		return res
+	}
+
+	condition, err := parseExpireCondition(args[domain.ThirdArg:])
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	deadline, valid := expireDeadline(amount, unit, absolute)
+	if !valid {
+		res.Error = newInvalidExpireError(name)
+		return res
+	}
+
+	applied, err := handler.storage.ExpireAt(handler.context, key, deadline, condition)
+
+	if isContextCanceled(err) {
+		return res.SetCanceled()
+	}
+
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetBool(applied)
+}
+
+func expireDeadline(amount, unit int64, absolute bool) (int64, bool) {
+	if amount > math.MaxInt64/unit || amount < math.MinInt64/unit {
+		return 0, false
+	}
+
+	deadline := amount * unit
+
+	if absolute {
+		return deadline, true
+	}
+
+	now := time.Now().UnixMilli()
+
+	if deadline > math.MaxInt64-now {
+		return 0, false
+	}
+
+	return now + deadline, true
+}
+
+func parseExpireCondition(flags Args) (domain.ExpireCondition, error) {
+	var nx, xx, gt, lt bool
+
+	for _, flag := range flags {
+		switch strings.ToUpper(string(flag)) {
+		case "NX":
+			nx = true
+		case "XX":
+			xx = true
+		case "GT":
+			gt = true
+		case "LT":
+			lt = true
+		default:
+			return domain.ExpireAlways, errors.New("ERR Unsupported option " + string(flag))
+		}
+	}
+
+	switch {
+	case nx && (xx || gt || lt):
+		return domain.ExpireAlways, errExpireNXConflict
+	case gt && lt:
+		return domain.ExpireAlways, errExpireGTConflict
+	case nx:
+		return domain.ExpireNX, nil
+	case gt:
+		return domain.ExpireGT, nil
+	case lt:
+		return domain.ExpireLT, nil
+	case xx:
+		return domain.ExpireXX, nil
+	}
+
+	return domain.ExpireAlways, nil
 }
@@ -11,6 +11,5 @@ func (handler *Handler) flushall(args Args) *Result {
 		return res
 	}
 
-	res.Response = []byte("OK")
-	return res
+	return res.SetOK()
 }
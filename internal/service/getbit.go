@@ -0,0 +1,22 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) getbit(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+
+	offset, err := parseBitOffset(args[domain.SecondArg])
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	bit, err := handler.storage.GetBit(handler.context, key, offset)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetInteger(bit)
+}
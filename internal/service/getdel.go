@@ -0,0 +1,19 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) getdel(args Args) *Result {
+	key := args[domain.FirstArg]
+	res := domain.NewResult()
+	res.Response, res.Error = handler.storage.GetDel(handler.context, key)
+
+	if isContextCanceled(res.Error) {
+		return res.SetCanceled()
+	}
+
+	if isKeyNotFoundError(res.Error) {
+		return res.SetNil()
+	}
+
+	return res
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+func (handler *Handler) getex(args Args) *Result {
+	key := args[domain.FirstArg]
+	res := domain.NewResult()
+
+	options, err := parseGetExOptions(args[domain.SecondArg:])
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.Response, res.Error = handler.storage.GetEx(handler.context, key, options)
+
+	if isContextCanceled(res.Error) {
+		return res.SetCanceled()
+	}
+
+	if isKeyNotFoundError(res.Error) {
+		return res.SetNil()
+	}
+
+	return res
+}
+
+func parseGetExOptions(args Args) (domain.GetExOptions, error) {
+	var options domain.GetExOptions
+
+	if len(args) == 0 {
+		return options, nil
+	}
+
+	switch option := strings.ToUpper(string(args[0])); option {
+	case "PERSIST":
+		if len(args) != 1 {
+			return options, domain.ErrSyntax
+		}
+
+		options.Persist = true
+	case "EX", "PX", "EXAT", "PXAT":
+		if len(args) != 2 {
+			return options, domain.ErrSyntax
+		}
+
+		deadline, err := setDeadline("getex", option, args[1])
+		if hasError(err) {
+			return options, err
+		}
+
+		options.Deadline = deadline
+	default:
+		return options, domain.ErrSyntax
+	}
+
+	return options, nil
+}
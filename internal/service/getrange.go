@@ -0,0 +1,27 @@
+package service
+
+import (
+	"strconv"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+func (handler *Handler) getrange(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+
+	start, err := strconv.ParseInt(string(args[domain.SecondArg]), 10, 64)
+	if hasError(err) {
+		res.Error = domain.ErrInvalidInteger
+		return res
+	}
+
+	end, err := strconv.ParseInt(string(args[domain.ThirdArg]), 10, 64)
+	if hasError(err) {
+		res.Error = domain.ErrInvalidInteger
+		return res
+	}
+
+	res.Response, res.Error = handler.storage.GetRange(handler.context, key, start, end)
+	return res
+}
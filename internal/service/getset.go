@@ -0,0 +1,27 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) getset(args Args) *Result {
+	key := args[domain.FirstArg]
+	value := args[domain.SecondArg]
+
+	res := domain.NewResult()
+	previous, _, err := handler.storage.SetWith(handler.context, key, value, domain.SetOptions{Get: true})
+
+	if isContextCanceled(err) {
+		return res.SetCanceled()
+	}
+
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	if previous == nil {
+		return res.SetNil()
+	}
+
+	res.Response = previous
+	return res
+}
@@ -14,25 +14,36 @@ type (
 	Results = domain.Results
 
 	Handler struct {
-		context context.Context
-		storage domain.Persister
+		context  context.Context
+		storage  domain.Persister
+		settings domain.Configurer
+		stats    *domain.Stats
 
 		commands    domain.Commands
 		validations domain.Validations
 
 		multArgs    []Args
 		multEnabled bool
+		multFailed  bool
+		watching    uint64
+		cursors     *scanCursors
+		protocol    int
+		name        string
 	}
 )
 
-func NewHandler(storage domain.Persister) *Handler {
+func NewHandler(storage domain.Persister, settings domain.Configurer, stats *domain.Stats) *Handler {
 	ctx := context.WithValue(context.Background(), domain.DB, uint8(0))
 
 	handler := &Handler{
 		context:     ctx,
 		storage:     storage,
+		settings:    settings,
+		stats:       stats,
 		multArgs:    make([]Args, 0),
 		multEnabled: false,
+		cursors:     newScanCursors(),
+		protocol:    domain.RESP2,
 	}
 
 	handler.commands = domain.Commands{
@@ -42,11 +53,32 @@ func NewHandler(storage domain.Persister) *Handler {
 		"SEL": handler.sel,
 		"DO":  handler.do,
 
-		"TTL":     handler.ttl,
-		"EXPIRE":  handler.expire,
-		"PERSIST": handler.persist,
+		"MGET":   handler.mget,
+		"MSET":   handler.mset,
+		"MSETNX": handler.msetnx,
+		"GETSET": handler.getset,
+		"GETDEL": handler.getdel,
+		"GETEX":  handler.getex,
+		"SETNX":  handler.setnx,
+		"SETEX":  handler.setex,
+		"PSETEX": handler.psetex,
+
+		"TTL":         handler.ttl,
+		"PTTL":        handler.pttl,
+		"EXPIRE":      handler.expire,
+		"PEXPIRE":     handler.pexpire,
+		"EXPIREAT":    handler.expireat,
+		"PEXPIREAT":   handler.pexpireat,
+		"EXPIRETIME":  handler.expiretime,
+		"PEXPIRETIME": handler.pexpiretime,
+		"PERSIST":     handler.persist,
 
 		"EXISTS": handler.exists,
+		"TYPE":   handler.keyType,
+		"OBJECT": handler.object,
+		"SCAN":   handler.scan,
+		"KEYS":   handler.keys,
+		"DBSIZE": handler.dbsize,
 		"LLEN":   handler.llen,
 		"LINDEX": handler.lindex,
 		"LSET":   handler.lset,
@@ -66,29 +98,82 @@ func NewHandler(storage domain.Persister) *Handler {
 		"ZRANGE": handler.zrange,
 		"ZCOUNT": handler.zcount,
 
-		"INCR":   handler.incr,
-		"INCRBY": handler.incrby,
-		"DECR":   handler.decr,
-		"DECRBY": handler.decrby,
-
-		"APPEND": handler.append,
+		"HSET":         handler.hset,
+		"HSETNX":       handler.hsetnx,
+		"HGET":         handler.hget,
+		"HMGET":        handler.hmget,
+		"HDEL":         handler.hdel,
+		"HEXISTS":      handler.hexists,
+		"HLEN":         handler.hlen,
+		"HKEYS":        handler.hkeys,
+		"HVALS":        handler.hvals,
+		"HGETALL":      handler.hgetall,
+		"HINCRBY":      handler.hincrby,
+		"HINCRBYFLOAT": handler.hincrbyfloat,
+		"HSTRLEN":      handler.hstrlen,
+		"HSCAN":        handler.hscan,
+
+		"INCR":        handler.incr,
+		"INCRBY":      handler.incrby,
+		"INCRBYFLOAT": handler.incrbyfloat,
+		"DECR":        handler.decr,
+		"DECRBY":      handler.decrby,
+
+		"APPEND":   handler.append,
+		"STRLEN":   handler.strlen,
+		"GETRANGE": handler.getrange,
+		"SETRANGE": handler.setrange,
+		"GETBIT":   handler.getbit,
+		"SETBIT":   handler.setbit,
+		"BITCOUNT": handler.bitcount,
+		"BITPOS":   handler.bitpos,
+		"BITOP":    handler.bitop,
+		"BITFIELD": handler.bitfield,
+
+		"WATCH":   handler.watch,
+		"UNWATCH": handler.unwatch,
+
+		"CONFIG": handler.config,
+		"INFO":   handler.info,
 
 		"PING":   ping,
+		"HELLO":  handler.hello,
 		"DELETE": handler.del,
 	}
 
 	handler.validations = domain.Validations{
 		"DEL": {MinArgs: 2, MaxArgs: -1},
 		"GET": {MinArgs: 2, MaxArgs: 2},
-		"SET": {MinArgs: 3, MaxArgs: 5},
+		"SET": {MinArgs: 3, MaxArgs: -1},
 		"SEL": {MinArgs: 2, MaxArgs: 2},
 		"DO":  {MinArgs: 2, MaxArgs: -1},
 
-		"TTL":     {MinArgs: 2, MaxArgs: 2},
-		"EXPIRE":  {MinArgs: 3, MaxArgs: 3},
-		"PERSIST": {MinArgs: 2, MaxArgs: 2},
+		"MGET":   {MinArgs: 2, MaxArgs: -1},
+		"MSET":   {MinArgs: 3, MaxArgs: -1},
+		"MSETNX": {MinArgs: 3, MaxArgs: -1},
+		"GETSET": {MinArgs: 3, MaxArgs: 3},
+		"GETDEL": {MinArgs: 2, MaxArgs: 2},
+		"GETEX":  {MinArgs: 2, MaxArgs: 4},
+		"SETNX":  {MinArgs: 3, MaxArgs: 3},
+		"SETEX":  {MinArgs: 4, MaxArgs: 4},
+		"PSETEX": {MinArgs: 4, MaxArgs: 4},
+
+		"TTL":         {MinArgs: 2, MaxArgs: 2},
+		"PTTL":        {MinArgs: 2, MaxArgs: 2},
+		"EXPIRE":      {MinArgs: 3, MaxArgs: -1},
+		"PEXPIRE":     {MinArgs: 3, MaxArgs: -1},
+		"EXPIREAT":    {MinArgs: 3, MaxArgs: -1},
+		"PEXPIREAT":   {MinArgs: 3, MaxArgs: -1},
+		"EXPIRETIME":  {MinArgs: 2, MaxArgs: 2},
+		"PEXPIRETIME": {MinArgs: 2, MaxArgs: 2},
+		"PERSIST":     {MinArgs: 2, MaxArgs: 2},
 
 		"EXISTS": {MinArgs: 2, MaxArgs: 0},
+		"TYPE":   {MinArgs: 2, MaxArgs: 2},
+		"OBJECT": {MinArgs: 2, MaxArgs: 3},
+		"SCAN":   {MinArgs: 2, MaxArgs: 8},
+		"KEYS":   {MinArgs: 2, MaxArgs: 2},
+		"DBSIZE": {MinArgs: 1, MaxArgs: 1},
 		"LLEN":   {MinArgs: 2, MaxArgs: 2},
 		"LINDEX": {MinArgs: 3, MaxArgs: 3},
 		"LSET":   {MinArgs: 4, MaxArgs: 4},
@@ -105,17 +190,49 @@ func NewHandler(storage domain.Persister) *Handler {
 		"SISMEMBER": {MinArgs: 3, MaxArgs: 3},
 
 		"ZADD":   {MinArgs: 4, MaxArgs: 4},
-		"ZRANGE": {MinArgs: 4, MaxArgs: 4},
+		"ZRANGE": {MinArgs: 4, MaxArgs: 5},
 		"ZCOUNT": {MinArgs: 4, MaxArgs: 4},
 
-		"INCR":   {MinArgs: 2, MaxArgs: 2},
-		"INCRBY": {MinArgs: 3, MaxArgs: 3},
-		"DECR":   {MinArgs: 2, MaxArgs: 2},
-		"DECRBY": {MinArgs: 3, MaxArgs: 3},
-
-		"APPEND": {MinArgs: 3, MaxArgs: 3},
+		"HSET":         {MinArgs: 4, MaxArgs: -1},
+		"HSETNX":       {MinArgs: 4, MaxArgs: 4},
+		"HGET":         {MinArgs: 3, MaxArgs: 3},
+		"HMGET":        {MinArgs: 3, MaxArgs: -1},
+		"HDEL":         {MinArgs: 3, MaxArgs: -1},
+		"HEXISTS":      {MinArgs: 3, MaxArgs: 3},
+		"HLEN":         {MinArgs: 2, MaxArgs: 2},
+		"HKEYS":        {MinArgs: 2, MaxArgs: 2},
+		"HVALS":        {MinArgs: 2, MaxArgs: 2},
+		"HGETALL":      {MinArgs: 2, MaxArgs: 2},
+		"HINCRBY":      {MinArgs: 4, MaxArgs: 4},
+		"HINCRBYFLOAT": {MinArgs: 4, MaxArgs: 4},
+		"HSTRLEN":      {MinArgs: 3, MaxArgs: 3},
+		"HSCAN":        {MinArgs: 3, MaxArgs: 7},
+
+		"INCR":        {MinArgs: 2, MaxArgs: 2},
+		"INCRBY":      {MinArgs: 3, MaxArgs: 3},
+		"INCRBYFLOAT": {MinArgs: 3, MaxArgs: 3},
+		"DECR":        {MinArgs: 2, MaxArgs: 2},
+		"DECRBY":      {MinArgs: 3, MaxArgs: 3},
+
+		"APPEND":   {MinArgs: 3, MaxArgs: 3},
+		"STRLEN":   {MinArgs: 2, MaxArgs: 2},
+		"GETRANGE": {MinArgs: 4, MaxArgs: 4},
+		"SETRANGE": {MinArgs: 4, MaxArgs: 4},
+		"GETBIT":   {MinArgs: 3, MaxArgs: 3},
+		"SETBIT":   {MinArgs: 4, MaxArgs: 4},
+		"BITCOUNT": {MinArgs: 2, MaxArgs: 5},
+		"BITPOS":   {MinArgs: 3, MaxArgs: 6},
+		"BITOP":    {MinArgs: 4, MaxArgs: -1},
+		"BITFIELD": {MinArgs: 2, MaxArgs: -1},
+
+		"WATCH":   {MinArgs: 2, MaxArgs: -1},
+		"UNWATCH": {MinArgs: 1, MaxArgs: 1},
+
+		"CONFIG": {MinArgs: 2, MaxArgs: -1},
+		"INFO":   {MinArgs: 1, MaxArgs: -1},
 
 		"PING":   {MinArgs: 1, MaxArgs: 2},
+		"HELLO":  {MinArgs: 1, MaxArgs: -1},
 		"DELETE": {MinArgs: 2, MaxArgs: -1},
 	}
 
@@ -0,0 +1,18 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) hdel(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+	fields := args[domain.SecondArg:]
+
+	removed, err := handler.storage.HDel(handler.context, key, fields...)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.SetInteger(removed)
+	return res
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+const defaultUser = "default"
+
+// Protocol is the RESP version negotiated by HELLO on this connection.
+func (handler *Handler) Protocol() int {
+	return handler.protocol
+}
+
+func (handler *Handler) hello(args Args) *Result {
+	res := domain.NewResult()
+	protocol := handler.protocol
+	name := handler.name
+
+	if len(args) > domain.FirstArg {
+		version, err := strconv.ParseInt(string(args[domain.FirstArg]), 10, 64)
+		if hasError(err) {
+			res.Error = domain.ErrProtoVersion
+			return res
+		}
+
+		if version != domain.RESP2 && version != domain.RESP3 {
+			res.Error = domain.ErrNoProto
+			return res
+		}
+
+		protocol = int(version)
+	}
+
+	for index := domain.SecondArg; index < len(args); index++ {
+		option := strings.ToUpper(string(args[index]))
+		remaining := len(args) - index - 1
+
+		switch {
+		case option == "AUTH" && remaining >= 2:
+			if string(args[index+1]) != defaultUser {
+				res.Error = domain.ErrWrongPass
+				return res
+			}
+
+			index += 2
+		case option == "SETNAME" && remaining >= 1:
+			if !isValidClientName(args[index+1]) {
+				res.Error = domain.ErrClientName
+				return res
+			}
+
+			name = string(args[index+1])
+			index++
+		default:
+			res.Error = errors.New("ERR Syntax error in HELLO option '" + string(args[index]) + "'")
+			return res
+		}
+	}
+
+	handler.protocol = protocol
+	handler.name = name
+	connID, _ := handler.context.Value(domain.ID).(int64)
+
+	return res.SetItems(domain.ReplyMap, domain.Results{
+		bulk("server"), bulk(domain.ServerName),
+		bulk("version"), bulk(domain.ServerVersion),
+		bulk("proto"), domain.NewResult().SetInteger(int64(protocol)),
+		bulk("id"), domain.NewResult().SetInteger(connID),
+		bulk("mode"), bulk("standalone"),
+		bulk("role"), bulk("master"),
+		bulk("modules"), domain.NewResult().SetArray(nil),
+	})
+}
+
+func bulk(value string) *Result {
+	return &Result{Response: []byte(value)}
+}
+
+func isValidClientName(name []byte) bool {
+	for _, char := range name {
+		if char < '!' || char > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,18 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) hexists(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+	field := args[domain.SecondArg]
+
+	found, err := handler.storage.HExists(handler.context, key, field)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.SetBool(found)
+	return res
+}
@@ -0,0 +1,21 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) hget(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+	field := args[domain.SecondArg]
+
+	res.Response, res.Error = handler.storage.HGet(handler.context, key, field)
+
+	if isContextCanceled(res.Error) {
+		return res.SetCanceled()
+	}
+
+	if isKeyNotFoundError(res.Error) {
+		return res.SetNil()
+	}
+
+	return res
+}
@@ -0,0 +1,16 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) hgetall(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+
+	pairs, err := handler.storage.HGetAll(handler.context, key)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetMap(pairs)
+}
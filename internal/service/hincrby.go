@@ -0,0 +1,28 @@
+package service
+
+import (
+	"strconv"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+func (handler *Handler) hincrby(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+	field := args[domain.SecondArg]
+
+	delta, err := strconv.ParseInt(string(args[domain.ThirdArg]), 10, 64)
+	if hasError(err) {
+		res.Error = domain.ErrInvalidInteger
+		return res
+	}
+
+	result, err := handler.storage.HIncrBy(handler.context, key, field, delta)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.SetInteger(result)
+	return res
+}
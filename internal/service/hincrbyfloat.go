@@ -0,0 +1,24 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) hincrbyfloat(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+	field := args[domain.SecondArg]
+
+	delta, err := parseIncrement(args[domain.ThirdArg])
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	result, err := handler.storage.HIncrByFloat(handler.context, key, field, delta)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.Response = result
+	return res
+}
@@ -0,0 +1,5 @@
+package service
+
+func (handler *Handler) hkeys(args Args) *Result {
+	return processHashCollection(args, handler.storage.HKeys, handler)
+}
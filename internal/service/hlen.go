@@ -0,0 +1,17 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) hlen(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+
+	length, err := handler.storage.HLen(handler.context, key)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.SetInteger(length)
+	return res
+}
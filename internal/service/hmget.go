@@ -0,0 +1,18 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) hmget(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+	fields := args[domain.SecondArg:]
+
+	values, err := handler.storage.HMGet(handler.context, key, fields...)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.SetArray(values)
+	return res
+}
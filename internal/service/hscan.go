@@ -0,0 +1,40 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) hscan(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+
+	cursor, err := parseCursor(args[domain.SecondArg])
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	options, err := parseScanOptions(args[domain.ThirdArg:], false)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	after, err := handler.resumeCursor(key, cursor)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	last, items, err := handler.storage.HScan(handler.context, key, after, options.pattern, options.count)
+
+	if isContextCanceled(err) {
+		return res.SetCanceled()
+	}
+
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	setScan(res, handler.saveCursor(key, last), items)
+	return res
+}
@@ -0,0 +1,23 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) hset(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+	pairs := args[domain.SecondArg:]
+
+	if len(pairs)%2 != 0 {
+		res.Error = newInvalidArgsError("HSET")
+		return res
+	}
+
+	added, err := handler.storage.HSet(handler.context, key, pairs...)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.SetInteger(added)
+	return res
+}
@@ -0,0 +1,19 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) hsetnx(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+	field := args[domain.SecondArg]
+	value := args[domain.ThirdArg]
+
+	created, err := handler.storage.HSetNX(handler.context, key, field, value)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.SetBool(created)
+	return res
+}
@@ -0,0 +1,18 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) hstrlen(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+	field := args[domain.SecondArg]
+
+	length, err := handler.storage.HStrLen(handler.context, key, field)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.SetInteger(length)
+	return res
+}
@@ -0,0 +1,5 @@
+package service
+
+func (handler *Handler) hvals(args Args) *Result {
+	return processHashCollection(args, handler.storage.HVals, handler)
+}
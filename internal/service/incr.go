@@ -14,6 +14,6 @@ func (handler *Handler) incr(args Args) *Result {
 		return res
 	}
 
-	res.Response = formatInt64(value)
+	res.SetInteger(value)
 	return res
 }
@@ -0,0 +1,23 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) incrbyfloat(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+
+	delta, err := parseIncrement(args[domain.SecondArg])
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	result, err := handler.storage.IncrByFloat(handler.context, key, delta)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.Response = result
+	return res
+}
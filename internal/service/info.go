@@ -0,0 +1,235 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+const (
+	infoLineBreak   = "\r\n"
+	secondsPerDay   = 24 * 60 * 60
+	humanUnitFactor = 1024
+)
+
+type (
+	infoSource struct {
+		settings map[string]string
+		storage  domain.StorageInfo
+		memory   runtime.MemStats
+	}
+
+	infoSection struct {
+		name     string
+		title    string
+		extended bool
+		lines    func(*Handler, *infoSource) []string
+	}
+)
+
+var infoSections = []infoSection{
+	{name: "server", title: "Server", lines: serverInfo},
+	{name: "clients", title: "Clients", lines: clientsInfo},
+	{name: "memory", title: "Memory", lines: memoryInfo},
+	{name: "persistence", title: "Persistence", lines: persistenceInfo},
+	{name: "stats", title: "Stats", lines: statsInfo},
+	{name: "commandstats", title: "Commandstats", extended: true, lines: commandInfo},
+	{name: "keyspace", title: "Keyspace", lines: keyspaceInfo},
+}
+
+func (handler *Handler) info(args Args) *Result {
+	res := domain.NewResult()
+	selected := selectedSections(args[domain.FirstArg:])
+	source := &infoSource{settings: make(map[string]string)}
+
+	pairs := handler.settings.Get([]byte("address"), []byte("max-clients"), []byte("sync-mode"))
+
+	for index := 0; index+1 < len(pairs); index += 2 {
+		source.settings[string(pairs[index])] = string(pairs[index+1])
+	}
+
+	if selected["memory"] || selected["persistence"] || selected["stats"] || selected["keyspace"] {
+		source.storage, res.Error = handler.storage.Info(handler.context)
+	}
+
+	if isContextCanceled(res.Error) {
+		return res.SetCanceled()
+	}
+
+	if hasError(res.Error) {
+		return res
+	}
+
+	if selected["memory"] {
+		runtime.ReadMemStats(&source.memory)
+	}
+
+	blocks := make([]string, 0, len(infoSections))
+
+	for _, section := range infoSections {
+		if selected[section.name] {
+			lines := append([]string{"# " + section.title}, section.lines(handler, source)...)
+			blocks = append(blocks, strings.Join(lines, infoLineBreak)+infoLineBreak)
+		}
+	}
+
+	res.Response = []byte(strings.Join(blocks, infoLineBreak))
+	return res
+}
+
+func selectedSections(names Args) map[string]bool {
+	selected := make(map[string]bool, len(infoSections))
+
+	if len(names) == 0 {
+		names = Args{[]byte("default")}
+	}
+
+	for _, name := range names {
+		switch normalized := strings.ToLower(string(name)); normalized {
+		case "default":
+			markSections(selected, false)
+		case "all", "everything":
+			markSections(selected, true)
+		default:
+			selected[normalized] = true
+		}
+	}
+
+	return selected
+}
+
+func markSections(selected map[string]bool, all bool) {
+	for _, section := range infoSections {
+		if all || !section.extended {
+			selected[section.name] = true
+		}
+	}
+}
+
+func serverInfo(handler *Handler, source *infoSource) []string {
+	uptime := int64(time.Since(handler.stats.Started()).Seconds())
+	_, port, _ := net.SplitHostPort(source.settings["address"])
+
+	return []string{
+		"redis_version:" + domain.ServerVersion,
+		"redis_mode:standalone",
+		"server_name:" + domain.ServerName,
+		"os:" + runtime.GOOS + " " + runtime.GOARCH,
+		"arch_bits:" + strconv.Itoa(strconv.IntSize),
+		"go_version:" + runtime.Version(),
+		"process_id:" + strconv.Itoa(os.Getpid()),
+		"tcp_port:" + port,
+		"uptime_in_seconds:" + strconv.FormatInt(uptime, 10),
+		"uptime_in_days:" + strconv.FormatInt(uptime/secondsPerDay, 10),
+	}
+}
+
+func clientsInfo(handler *Handler, source *infoSource) []string {
+	return []string{
+		"connected_clients:" + strconv.FormatInt(handler.stats.Clients(), 10),
+		"maxclients:" + source.settings["max-clients"],
+		"blocked_clients:0",
+	}
+}
+
+func memoryInfo(_ *Handler, source *infoSource) []string {
+	heap := int64(source.memory.HeapAlloc)
+	storage := source.storage
+
+	return []string{
+		"used_memory:" + strconv.FormatInt(heap, 10),
+		"used_memory_human:" + humanBytes(heap),
+		"used_memory_rss:" + strconv.FormatUint(source.memory.Sys, 10),
+		"lmdb_map_size:" + strconv.FormatInt(storage.MapSize, 10),
+		"lmdb_map_size_human:" + humanBytes(storage.MapSize),
+		"lmdb_used_bytes:" + strconv.FormatInt(storage.UsedBytes, 10),
+		"lmdb_used_bytes_human:" + humanBytes(storage.UsedBytes),
+		"lmdb_page_size:" + strconv.FormatInt(storage.PageSize, 10),
+		"lmdb_readers:" + strconv.FormatInt(storage.Readers, 10),
+		"lmdb_max_readers:" + strconv.FormatInt(storage.MaxReaders, 10),
+	}
+}
+
+func persistenceInfo(_ *Handler, source *infoSource) []string {
+	return []string{
+		"loading:0",
+		"sync_mode:" + source.settings["sync-mode"],
+		"lmdb_last_txn_id:" + strconv.FormatInt(source.storage.LastTxnID, 10),
+		"last_sync_time:" + strconv.FormatInt(source.storage.LastSync.Unix(), 10),
+		"last_sync_status:" + syncStatus(source.storage.SyncFailed),
+	}
+}
+
+func syncStatus(failed bool) string {
+	if failed {
+		return "err"
+	}
+
+	return "ok"
+}
+
+func statsInfo(handler *Handler, source *infoSource) []string {
+	return []string{
+		"total_connections_received:" + strconv.FormatInt(handler.stats.Connections(), 10),
+		"total_commands_processed:" + strconv.FormatInt(handler.stats.Processed(), 10),
+		"rejected_connections:" + strconv.FormatInt(handler.stats.RefusedConnections(), 10),
+		"expired_keys:" + strconv.FormatInt(source.storage.ExpiredKeys, 10),
+		"expire_cycles:" + strconv.FormatInt(source.storage.ExpireRuns, 10),
+		"expire_cycle_cpu_milliseconds:" + strconv.FormatInt(source.storage.ExpireTime.Milliseconds(), 10),
+		"expire_cycle_last_usec:" + strconv.FormatInt(source.storage.ExpireLast.Microseconds(), 10),
+		"evicted_keys:0",
+	}
+}
+
+func commandInfo(handler *Handler, _ *infoSource) []string {
+	commands := handler.stats.Commands()
+	lines := make([]string, 0, len(commands))
+
+	for _, command := range commands {
+		perCall := 0.0
+
+		if command.Calls > 0 {
+			perCall = float64(command.Usec) / float64(command.Calls)
+		}
+
+		lines = append(lines, fmt.Sprintf(
+			"cmdstat_%s:calls=%d,usec=%d,usec_per_call=%.2f,rejected_calls=%d,failed_calls=%d",
+			strings.ToLower(command.Name), command.Calls, command.Usec, perCall, command.Rejected, command.Failed,
+		))
+	}
+
+	return lines
+}
+
+func keyspaceInfo(_ *Handler, source *infoSource) []string {
+	lines := make([]string, 0, len(source.storage.Keyspaces))
+
+	for _, keyspace := range source.storage.Keyspaces {
+		lines = append(lines, fmt.Sprintf("db%d:keys=%d,expires=%d,avg_ttl=0", keyspace.DB, keyspace.Keys, keyspace.Expires))
+	}
+
+	return lines
+}
+
+func humanBytes(size int64) string {
+	value := float64(size)
+	units := []string{"B", "K", "M", "G", "T"}
+	unit := 0
+
+	for value >= humanUnitFactor && unit < len(units)-1 {
+		value /= humanUnitFactor
+		unit++
+	}
+
+	if unit == 0 {
+		return strconv.FormatInt(size, 10) + units[unit]
+	}
+
+	return strconv.FormatFloat(value, 'f', 2, 64) + units[unit]
+}
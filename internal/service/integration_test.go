@@ -2,10 +2,8 @@ package service_test
 
 import (
 	"context"
-	"encoding/binary"
 	"fmt"
 	"net"
-	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -13,6 +11,7 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/tidwall/redcon"
 
+	"github.com/luiz-simples/keyp.git/internal/domain"
 	"github.com/luiz-simples/keyp.git/internal/service"
 	"github.com/luiz-simples/keyp.git/internal/storage"
 )
@@ -50,7 +49,7 @@ var _ = Describe("Handler Integration Tests", func() {
 		var err error
 		storageImpl, err = storage.NewClient(testDir)
 		Expect(err).NotTo(HaveOccurred())
-		handler = service.NewHandler(storageImpl)
+		handler = service.NewHandler(storageImpl, nil, domain.NewStats())
 
 		listener, err := net.Listen("tcp", ":0")
 		Expect(err).NotTo(HaveOccurred())
@@ -74,15 +73,17 @@ var _ = Describe("Handler Integration Tests", func() {
 				return
 			}
 
-			if result.Response == nil {
-				conn.WriteNull()
+			switch result.Kind {
+			case domain.ReplyInteger:
+				conn.WriteInt64(result.Integer)
+				return
+			case domain.ReplySimple:
+				conn.WriteString(string(result.Response))
 				return
 			}
 
-			cmdName := strings.ToUpper(string(cmd.Args[0]))
-			if len(cmd.Args) > 0 && (cmdName == "DEL" || cmdName == "DELETE") && len(result.Response) == 4 {
-				count := binary.LittleEndian.Uint32(result.Response)
-				conn.WriteInt64(int64(count))
+			if result.Response == nil {
+				conn.WriteNull()
 				return
 			}
 
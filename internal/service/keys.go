@@ -0,0 +1,22 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) keys(args Args) *Result {
+	res := domain.NewResult()
+	pattern := args[domain.FirstArg]
+
+	keys, err := handler.storage.Keys(handler.context, pattern)
+
+	if isContextCanceled(err) {
+		return res.SetCanceled()
+	}
+
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.SetArray(keys)
+	return res
+}
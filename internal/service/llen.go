@@ -9,6 +9,6 @@ func (handler *Handler) llen(args Args) *Result {
 	key := args[domain.FirstArg]
 	length := handler.storage.LLen(handler.context, key)
 
-	res.Response = formatInt64(length)
+	res.SetInteger(length)
 	return res
 }
@@ -18,6 +18,6 @@ func (handler *Handler) lrange(args Args) *Result {
 		return res
 	}
 
-	res.Response = formatArray(values)
+	res.SetArray(values)
 	return res
 }
@@ -18,6 +18,5 @@ func (handler *Handler) lset(args Args) *Result {
 		return res
 	}
 
-	res.Response = OK
-	return res
+	return res.SetOK()
 }
@@ -0,0 +1,21 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) mget(args Args) *Result {
+	res := domain.NewResult()
+	keys := args[domain.FirstArg:]
+
+	values, err := handler.storage.MGet(handler.context, keys...)
+
+	if isContextCanceled(err) {
+		return res.SetCanceled()
+	}
+
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetArray(values)
+}
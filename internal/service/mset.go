@@ -0,0 +1,26 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) mset(args Args) *Result {
+	res := domain.NewResult()
+	pairs := args[domain.FirstArg:]
+
+	if len(pairs)%2 != 0 {
+		res.Error = newInvalidArgsError("MSET")
+		return res
+	}
+
+	err := handler.storage.MSet(handler.context, pairs...)
+
+	if isContextCanceled(err) {
+		return res.SetCanceled()
+	}
+
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetOK()
+}
@@ -0,0 +1,26 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) msetnx(args Args) *Result {
+	res := domain.NewResult()
+	pairs := args[domain.FirstArg:]
+
+	if len(pairs)%2 != 0 {
+		res.Error = newInvalidArgsError("MSETNX")
+		return res
+	}
+
+	applied, err := handler.storage.MSetNX(handler.context, pairs...)
+
+	if isContextCanceled(err) {
+		return res.SetCanceled()
+	}
+
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetBool(applied)
+}
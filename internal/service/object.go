@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+var objectHelp = [][]byte{
+	[]byte("OBJECT <subcommand> [<arg> [value] [opt] ...]. Subcommands are:"),
+	[]byte("ENCODING <key>"),
+	[]byte("    Return the kind of internal representation used in order to store the value associated with a <key>."),
+	[]byte("FREQ <key>"),
+	[]byte("    Return the access frequency index of the <key>."),
+	[]byte("IDLETIME <key>"),
+	[]byte("    Return the idle time of the <key>, that is the approximated number of seconds elapsed since the last access to the key."),
+	[]byte("REFCOUNT <key>"),
+	[]byte("    Return the number of references of the value associated with the specified <key>."),
+	[]byte("HELP"),
+	[]byte("    Print this help."),
+}
+
+func newUnknownSubcommandError(commandName string, subcommand []byte) error {
+	return errors.New("ERR unknown subcommand '" + string(subcommand) + "'. Try " + commandName + " HELP.")
+}
+
+func (handler *Handler) object(args Args) *Result {
+	res := domain.NewResult()
+	subcommand := normalizeCommandName(string(args[domain.FirstArg]))
+
+	if subcommand == "HELP" {
+		res.SetArray(objectHelp)
+		return res
+	}
+
+	if len(args) != 3 {
+		res.Error = newInvalidArgsError("object|" + subcommand)
+		return res
+	}
+
+	key := args[domain.SecondArg]
+
+	switch subcommand {
+	case "ENCODING":
+		encoding, err := handler.storage.Encoding(handler.context, key)
+		res.Response, res.Error = []byte(encoding), err
+	case "IDLETIME":
+		idle, err := handler.storage.IdleTime(handler.context, key)
+		res.SetInteger(idle)
+		res.Error = err
+	case "FREQ":
+		frequency, err := handler.storage.Frequency(handler.context, key)
+		res.SetInteger(frequency)
+		res.Error = err
+	case "REFCOUNT":
+		if !handler.storage.Exists(handler.context, key) {
+			return res.SetNil()
+		}
+		res.SetInteger(1)
+	default:
+		res.Error = newUnknownSubcommandError("OBJECT", args[domain.FirstArg])
+		return res
+	}
+
+	if isContextCanceled(res.Error) {
+		return res.SetCanceled()
+	}
+
+	if isKeyNotFoundError(res.Error) {
+		return res.SetNil()
+	}
+
+	return res
+}
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/luiz-simples/keyp.git/internal/domain"
 	"github.com/luiz-simples/keyp.git/internal/service"
 	"github.com/luiz-simples/keyp.git/internal/storage"
 )
@@ -29,7 +30,7 @@ var _ = Describe("Handler Performance Tests", func() {
 		var err error
 		storageImpl, err = storage.NewClient(testDir)
 		Expect(err).NotTo(HaveOccurred())
-		handler = service.NewHandler(storageImpl)
+		handler = service.NewHandler(storageImpl, nil, domain.NewStats())
 	})
 
 	AfterEach(func() {
@@ -164,7 +165,7 @@ func BenchmarkHandlerSET(b *testing.B) {
 	}
 	defer storageImpl.Close()
 
-	handler := service.NewHandler(storageImpl)
+	handler := service.NewHandler(storageImpl, nil, domain.NewStats())
 	key := []byte("benchmark:key")
 	value := []byte("benchmark value")
 	args := [][]byte{[]byte("SET"), key, value}
@@ -189,7 +190,7 @@ func BenchmarkHandlerGET(b *testing.B) {
 	}
 	defer storageImpl.Close()
 
-	handler := service.NewHandler(storageImpl)
+	handler := service.NewHandler(storageImpl, nil, domain.NewStats())
 	key := []byte("benchmark:key")
 	value := []byte("benchmark value")
 
@@ -218,7 +219,7 @@ func BenchmarkHandlerDEL(b *testing.B) {
 	}
 	defer storageImpl.Close()
 
-	handler := service.NewHandler(storageImpl)
+	handler := service.NewHandler(storageImpl, nil, domain.NewStats())
 	value := []byte("benchmark value")
 
 	b.ResetTimer()
@@ -247,7 +248,7 @@ func BenchmarkHandlerPING(b *testing.B) {
 	}
 	defer storageImpl.Close()
 
-	handler := service.NewHandler(storageImpl)
+	handler := service.NewHandler(storageImpl, nil, domain.NewStats())
 	args := [][]byte{[]byte("PING")}
 
 	b.ResetTimer()
@@ -270,7 +271,7 @@ func BenchmarkHandlerMixed(b *testing.B) {
 	}
 	defer storageImpl.Close()
 
-	handler := service.NewHandler(storageImpl)
+	handler := service.NewHandler(storageImpl, nil, domain.NewStats())
 	value := []byte("benchmark value")
 
 	b.ResetTimer()
@@ -7,6 +7,6 @@ func (handler *Handler) persist(args Args) *Result {
 	key := args[domain.FirstArg]
 	removed := handler.storage.Persist(handler.context, key)
 
-	res.Response = formatBool(removed)
+	res.SetBool(removed)
 	return res
 }
@@ -10,6 +10,5 @@ func ping(args Args) *Result {
 		return result
 	}
 
-	result.Response = domain.PONG
-	return result
+	return result.SetSimple(domain.PONG)
 }
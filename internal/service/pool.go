@@ -13,11 +13,15 @@ type (
 	}
 )
 
-func NewPool(storage domain.Persister) *Pool {
+func NewPool(storage domain.Persister, settings domain.Configurer, stats *domain.Stats) *Pool {
+	cursors := newScanCursors()
+
 	return &Pool{
 		refs: &sync.Pool{
 			New: func() any {
-				return NewHandler(storage)
+				handler := NewHandler(storage, settings, stats)
+				handler.cursors = cursors
+				return handler
 			},
 		},
 	}
@@ -3,7 +3,6 @@ package service_test
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
@@ -11,6 +10,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/luiz-simples/keyp.git/internal/domain"
 	"github.com/luiz-simples/keyp.git/internal/service"
 	"github.com/luiz-simples/keyp.git/internal/storage"
 )
@@ -32,7 +32,7 @@ var _ = Describe("Handler Property-Based Tests", func() {
 		var err error
 		storageImpl, err = storage.NewClient(testDir)
 		Expect(err).NotTo(HaveOccurred())
-		handler = service.NewHandler(storageImpl)
+		handler = service.NewHandler(storageImpl, nil, domain.NewStats())
 
 		parameters := gopter.DefaultTestParameters()
 		parameters.MinSuccessfulTests = 100
@@ -108,8 +108,7 @@ var _ = Describe("Handler Property-Based Tests", func() {
 						return false
 					}
 
-					deletedCount := string(delResults[0].Response)
-					if deletedCount != "1" {
+					if delResults[0].Integer != 1 {
 						return false
 					}
 
@@ -174,8 +173,7 @@ var _ = Describe("Handler Property-Based Tests", func() {
 						return false
 					}
 
-					deletedCount := string(delResults[0].Response)
-					return deletedCount == strconv.Itoa(len(validKeys))
+					return delResults[0].Integer == int64(len(validKeys))
 				},
 				gen.SliceOf(gen.AlphaString().SuchThat(func(s string) bool { return len(s) < 50 })).
 					SuchThat(func(slice []string) bool { return len(slice) <= 10 }),
@@ -285,7 +283,8 @@ var _ = Describe("Handler Property-Based Tests", func() {
 						return false
 					}
 
-					if argCount >= 3 && argCount <= 5 {
+					// Anything after the value is parsed as a SET option, and argN is not one.
+					if argCount == 3 {
 						return results[0].Error == nil
 					}
 
@@ -3,8 +3,10 @@ package service_test
 import (
 	"context"
 	"fmt"
+	"math"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -12,6 +14,7 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"github.com/luiz-simples/keyp.git/internal/app"
+	"github.com/luiz-simples/keyp.git/internal/domain"
 	"github.com/luiz-simples/keyp.git/internal/service"
 	"github.com/luiz-simples/keyp.git/internal/storage"
 )
@@ -25,6 +28,8 @@ var _ = Describe("Redis Compatibility Integration Tests", func() {
 		testPort    string
 		testDir     string
 		poolService *service.Pool
+		registry    *app.Registry
+		stats       *domain.Stats
 	)
 
 	// createRedisClient cria um cliente Redis com configurações otimizadas para testes
@@ -49,24 +54,29 @@ var _ = Describe("Redis Compatibility Integration Tests", func() {
 		storageImpl, err = storage.NewClient(testDir)
 		Expect(err).NotTo(HaveOccurred())
 
-		poolService = service.NewPool(storageImpl)
-		server = app.NewServer(poolService)
-
 		listener, err := net.Listen("tcp", ":0")
 		Expect(err).NotTo(HaveOccurred())
 		testPort = fmt.Sprintf("%d", listener.Addr().(*net.TCPAddr).Port)
 		listener.Close()
 
-		config := app.Config{
-			Address: "localhost:" + testPort,
-			DataDir: testDir,
-		}
+		config := app.DefaultConfig()
+		config.Address = "localhost:" + testPort
+		config.DataDir = testDir
+
+		registry = app.NewRegistry(config)
+		stats = domain.NewStats()
+		registry.Watch(func(config app.Config) error {
+			return storageImpl.Configure(config.StorageOptions())
+		})
+
+		poolService = service.NewPool(storageImpl, registry, stats)
+		server = app.NewServer(poolService, registry, stats, storageImpl)
 
 		ch := make(chan bool)
 		go func(chBool chan bool) {
 			defer GinkgoRecover()
 			chBool <- true
-			err := server.Start(config)
+			err := server.Start()
 			if err != nil {
 				fmt.Printf("Server error: %v\n", err)
 			}
@@ -111,6 +121,17 @@ var _ = Describe("Redis Compatibility Integration Tests", func() {
 			Expect(getResult.Val()).To(Equal(value))
 		})
 
+		It("should return values that look like RESP replies as plain strings", func() {
+			key := "test:string:resp"
+			value := "*1\r\n$2\r\nOK\r\n"
+
+			Expect(redisClient.Set(ctx, key, value, 0).Err()).NotTo(HaveOccurred())
+
+			getResult := redisClient.Get(ctx, key)
+			Expect(getResult.Err()).NotTo(HaveOccurred())
+			Expect(getResult.Val()).To(Equal(value))
+		})
+
 		It("should handle APPEND command", func() {
 			key := "test:append:key"
 			value1 := "Hello"
@@ -152,6 +173,36 @@ var _ = Describe("Redis Compatibility Integration Tests", func() {
 			Expect(incrByResult.Val()).To(Equal(increment * 2))
 		})
 
+		It("should refuse counter overflow instead of wrapping", func() {
+			key := "test:incr:overflow"
+
+			Expect(redisClient.Set(ctx, key, "9223372036854775807", 0).Err()).NotTo(HaveOccurred())
+			Expect(redisClient.IncrBy(ctx, key, 1).Err()).To(MatchError("ERR increment or decrement would overflow"))
+			Expect(redisClient.Get(ctx, key).Val()).To(Equal("9223372036854775807"))
+
+			Expect(redisClient.DecrBy(ctx, key, math.MinInt64).Err()).To(MatchError("ERR decrement would overflow"))
+
+			Expect(redisClient.Set(ctx, key, " 1", 0).Err()).NotTo(HaveOccurred())
+			Expect(redisClient.Incr(ctx, key).Err()).To(MatchError("ERR value is not an integer or out of range"))
+		})
+
+		It("should handle INCRBYFLOAT command", func() {
+			key := "test:incrbyfloat:key"
+
+			Expect(redisClient.Set(ctx, key, "10.50", 0).Err()).NotTo(HaveOccurred())
+			Expect(redisClient.IncrByFloat(ctx, key, 0.1).Val()).To(Equal(10.6))
+			Expect(redisClient.IncrByFloat(ctx, key, -5.6).Val()).To(Equal(5.0))
+			Expect(redisClient.Get(ctx, key).Val()).To(Equal("5"))
+
+			Expect(redisClient.Set(ctx, key, "0.2", 0).Err()).NotTo(HaveOccurred())
+			Expect(redisClient.Do(ctx, "INCRBYFLOAT", key, "0.1").Text()).To(Equal("0.3"))
+			Expect(redisClient.Do(ctx, "HINCRBYFLOAT", key+":hash", "f", "0.1").Text()).To(Equal("0.1"))
+			Expect(redisClient.Do(ctx, "HINCRBYFLOAT", key+":hash", "f", "0.2").Text()).To(Equal("0.3"))
+
+			Expect(redisClient.Set(ctx, key, "1.7e308", 0).Err()).NotTo(HaveOccurred())
+			Expect(redisClient.IncrByFloat(ctx, key, 1.7e308).Err()).To(MatchError("ERR increment would produce NaN or Infinity"))
+		})
+
 		It("should handle DECR command", func() {
 			key := "test:decr:key"
 
@@ -232,6 +283,90 @@ var _ = Describe("Redis Compatibility Integration Tests", func() {
 			Expect(ttlResult.Val().Seconds()).To(BeNumerically(">", 0))
 		})
 
+		It("should handle SET options atomically for locks", func() {
+			key := "test:set:lock"
+
+			Expect(redisClient.SetNX(ctx, key, "owner-1", 3*time.Second).Val()).To(BeTrue())
+			Expect(redisClient.SetNX(ctx, key, "owner-2", 3*time.Second).Val()).To(BeFalse())
+			Expect(redisClient.PTTL(ctx, key).Val()).To(BeNumerically(">", 2*time.Second))
+
+			previous := redisClient.SetArgs(ctx, key, "owner-3", redis.SetArgs{Mode: "XX", Get: true, KeepTTL: true})
+			Expect(previous.Val()).To(Equal("owner-1"))
+			Expect(redisClient.PTTL(ctx, key).Val()).To(BeNumerically(">", 2*time.Second))
+
+			Expect(redisClient.Set(ctx, key, "plain", 0).Err()).NotTo(HaveOccurred())
+			Expect(redisClient.TTL(ctx, key).Val()).To(Equal(time.Duration(-1)))
+
+			Expect(redisClient.Do(ctx, "SET", key, "v", "EX", "10", "PX", "100").Err()).To(MatchError("ERR syntax error"))
+		})
+
+		It("should handle the multi-key and get-and-modify string commands", func() {
+			Expect(redisClient.MSet(ctx, "test:m:a", "1", "test:m:b", "2").Err()).NotTo(HaveOccurred())
+			Expect(redisClient.MGet(ctx, "test:m:a", "test:m:missing", "test:m:b").Val()).To(Equal([]interface{}{"1", nil, "2"}))
+			Expect(redisClient.MSetNX(ctx, "test:m:c", "3", "test:m:a", "4").Val()).To(BeFalse())
+			Expect(redisClient.Exists(ctx, "test:m:c").Val()).To(BeZero())
+
+			Expect(redisClient.GetSet(ctx, "test:m:a", "5").Val()).To(Equal("1"))
+			Expect(redisClient.GetDel(ctx, "test:m:a").Val()).To(Equal("5"))
+			Expect(redisClient.Exists(ctx, "test:m:a").Val()).To(BeZero())
+
+			Expect(redisClient.SetEx(ctx, "test:m:ex", "v", 10*time.Second).Err()).NotTo(HaveOccurred())
+			Expect(redisClient.TTL(ctx, "test:m:ex").Val()).To(BeNumerically(">", 5*time.Second))
+			Expect(redisClient.GetEx(ctx, "test:m:ex", 0).Val()).To(Equal("v"))
+			Expect(redisClient.TTL(ctx, "test:m:ex").Val()).To(Equal(time.Duration(-1)))
+			Expect(redisClient.Do(ctx, "GETEX", "test:m:ex", "PX", "1500").Val()).To(Equal("v"))
+			Expect(redisClient.PTTL(ctx, "test:m:ex").Val()).To(BeNumerically(">", time.Second))
+
+			Expect(redisClient.SetNX(ctx, "test:m:b", "x", 0).Val()).To(BeFalse())
+			Expect(redisClient.Do(ctx, "PSETEX", "test:m:px", "1500", "v").Val()).To(Equal("OK"))
+			Expect(redisClient.PTTL(ctx, "test:m:px").Val()).To(BeNumerically(">", time.Second))
+		})
+
+		It("should handle substring and bitmap commands", func() {
+			key := "test:bitmap:users"
+
+			Expect(redisClient.SetBit(ctx, key, 100, 1).Val()).To(BeZero())
+			Expect(redisClient.SetBit(ctx, key, 3, 1).Val()).To(BeZero())
+			Expect(redisClient.GetBit(ctx, key, 100).Val()).To(Equal(int64(1)))
+			Expect(redisClient.StrLen(ctx, key).Val()).To(Equal(int64(13)))
+			Expect(redisClient.BitCount(ctx, key, nil).Val()).To(Equal(int64(2)))
+			Expect(redisClient.BitPos(ctx, key, 1).Val()).To(Equal(int64(3)))
+			Expect(redisClient.BitPos(ctx, key, 1, 1).Val()).To(Equal(int64(100)))
+
+			Expect(redisClient.BitOpNot(ctx, "test:bitmap:inverted", key).Val()).To(Equal(int64(13)))
+			Expect(redisClient.BitCount(ctx, "test:bitmap:inverted", nil).Val()).To(Equal(int64(13*8 - 2)))
+
+			counters := redisClient.Do(ctx, "BITFIELD", "test:bitmap:counters", "INCRBY", "u2", "#0", 3, "OVERFLOW", "FAIL", "INCRBY", "u2", "#0", 1, "GET", "u2", "#0")
+			Expect(counters.Val()).To(Equal([]interface{}{int64(3), nil, int64(3)}))
+
+			Expect(redisClient.Set(ctx, "test:range", "Hello World", 0).Err()).NotTo(HaveOccurred())
+			Expect(redisClient.SetRange(ctx, "test:range", 6, "Redis").Val()).To(Equal(int64(11)))
+			Expect(redisClient.GetRange(ctx, "test:range", -5, -1).Val()).To(Equal("Redis"))
+		})
+
+		It("should handle millisecond expiry commands and flags", func() {
+			key := "test:pexpire:key"
+			redisClient.Set(ctx, key, "value", 0)
+
+			Expect(redisClient.PExpire(ctx, key, 1500*time.Millisecond).Val()).To(BeTrue())
+			pttl := redisClient.PTTL(ctx, key).Val()
+			Expect(pttl).To(BeNumerically(">", time.Second))
+			Expect(pttl).To(BeNumerically("<=", 1500*time.Millisecond))
+
+			Expect(redisClient.ExpireNX(ctx, key, time.Hour).Val()).To(BeFalse())
+			Expect(redisClient.ExpireGT(ctx, key, time.Hour).Val()).To(BeTrue())
+			Expect(redisClient.ExpireLT(ctx, key, 2*time.Hour).Val()).To(BeFalse())
+
+			at := time.Now().Add(time.Hour).Truncate(time.Second)
+			Expect(redisClient.ExpireAt(ctx, key, at).Val()).To(BeTrue())
+			Expect(redisClient.ExpireTime(ctx, key).Val()).To(Equal(time.Duration(at.Unix()) * time.Second))
+			Expect(redisClient.Do(ctx, "PEXPIRETIME", key).Val()).To(Equal(at.UnixMilli()))
+
+			Expect(redisClient.PExpireAt(ctx, key, time.Now().Add(-time.Second)).Val()).To(BeTrue())
+			Expect(redisClient.Exists(ctx, key).Val()).To(BeZero())
+			Expect(redisClient.PTTL(ctx, key).Val()).To(Equal(time.Duration(-2)))
+		})
+
 		It("should handle PERSIST command", func() {
 			key := "test:persist:key"
 			value := "test value"
@@ -247,6 +382,64 @@ var _ = Describe("Redis Compatibility Integration Tests", func() {
 			Expect(ttlResult.Err()).NotTo(HaveOccurred())
 			Expect(ttlResult.Val()).To(Equal(time.Duration(-1)))
 		})
+
+		It("should handle TYPE command", func() {
+			redisClient.Set(ctx, "test:type:string", "value", 0)
+			redisClient.RPush(ctx, "test:type:list", "a")
+
+			Expect(redisClient.Type(ctx, "test:type:string").Val()).To(Equal("string"))
+			Expect(redisClient.Type(ctx, "test:type:list").Val()).To(Equal("list"))
+			Expect(redisClient.Type(ctx, "test:type:missing").Val()).To(Equal("none"))
+		})
+
+		It("should handle SCAN, KEYS and DBSIZE commands", func() {
+			for i := range 25 {
+				redisClient.Set(ctx, fmt.Sprintf("test:scan:%02d", i), "value", 0)
+			}
+			redisClient.Set(ctx, "other:key", "value", 0)
+
+			keys := make([]string, 0)
+			iter := redisClient.Scan(ctx, 0, "test:scan:*", 10).Iterator()
+			for iter.Next(ctx) {
+				keys = append(keys, iter.Val())
+			}
+			Expect(iter.Err()).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(25))
+
+			keysResult := redisClient.Keys(ctx, "other:*")
+			Expect(keysResult.Err()).NotTo(HaveOccurred())
+			Expect(keysResult.Val()).To(Equal([]string{"other:key"}))
+
+			sizeResult := redisClient.DBSize(ctx)
+			Expect(sizeResult.Err()).NotTo(HaveOccurred())
+			Expect(sizeResult.Val()).To(Equal(int64(26)))
+		})
+
+		It("should resume SCAN cursors on another connection", func() {
+			for i := range 25 {
+				redisClient.Set(ctx, fmt.Sprintf("test:scan:%02d", i), "value", 0)
+			}
+
+			other := createRedisClient("localhost:" + testPort)
+			defer other.Close()
+
+			clients := []*redis.Client{redisClient, other}
+			keys := make([]string, 0)
+			cursor := uint64(0)
+
+			for turn := 0; ; turn++ {
+				page, next, err := clients[turn%len(clients)].Scan(ctx, cursor, "test:scan:*", 10).Result()
+				Expect(err).NotTo(HaveOccurred())
+				keys = append(keys, page...)
+				cursor = next
+
+				if cursor == 0 {
+					break
+				}
+			}
+
+			Expect(keys).To(HaveLen(25))
+		})
 	})
 
 	Describe("List Operations", func() {
@@ -445,6 +638,275 @@ var _ = Describe("Redis Compatibility Integration Tests", func() {
 		})
 	})
 
+	Describe("Hash Operations", func() {
+		It("should handle HSET, HGET and HGETALL commands", func() {
+			key := "test:hash:key"
+
+			hsetResult := redisClient.HSet(ctx, key, "field1", "value1", "field2", "value2")
+			Expect(hsetResult.Err()).NotTo(HaveOccurred())
+			Expect(hsetResult.Val()).To(Equal(int64(2)))
+
+			hgetResult := redisClient.HGet(ctx, key, "field1")
+			Expect(hgetResult.Err()).NotTo(HaveOccurred())
+			Expect(hgetResult.Val()).To(Equal("value1"))
+
+			hgetResult = redisClient.HGet(ctx, key, "missing")
+			Expect(hgetResult.Err()).To(Equal(redis.Nil))
+
+			hgetallResult := redisClient.HGetAll(ctx, key)
+			Expect(hgetallResult.Err()).NotTo(HaveOccurred())
+			Expect(hgetallResult.Val()).To(Equal(map[string]string{"field1": "value1", "field2": "value2"}))
+		})
+
+		It("should handle HMGET, HDEL, HEXISTS and HLEN commands", func() {
+			key := "test:hash:fields"
+			redisClient.HSet(ctx, key, "a", "1", "b", "2")
+
+			hmgetResult := redisClient.HMGet(ctx, key, "a", "missing")
+			Expect(hmgetResult.Err()).NotTo(HaveOccurred())
+			Expect(hmgetResult.Val()).To(Equal([]interface{}{"1", nil}))
+
+			Expect(redisClient.HExists(ctx, key, "a").Val()).To(BeTrue())
+			Expect(redisClient.HDel(ctx, key, "a", "missing").Val()).To(Equal(int64(1)))
+			Expect(redisClient.HExists(ctx, key, "a").Val()).To(BeFalse())
+			Expect(redisClient.HLen(ctx, key).Val()).To(Equal(int64(1)))
+		})
+
+		It("should handle HINCRBY and HINCRBYFLOAT commands", func() {
+			key := "test:hash:counters"
+
+			Expect(redisClient.HIncrBy(ctx, key, "count", 5).Val()).To(Equal(int64(5)))
+			Expect(redisClient.HIncrByFloat(ctx, key, "price", 1.25).Val()).To(Equal(1.25))
+
+			redisClient.HSet(ctx, key, "name", "keyp")
+			Expect(redisClient.HIncrBy(ctx, key, "name", 1).Err()).To(HaveOccurred())
+		})
+
+		It("should handle HSCAN command", func() {
+			key := "test:hash:scan"
+			redisClient.HSet(ctx, key, "f1", "v1", "f2", "v2", "other", "v3")
+
+			items, cursor, err := redisClient.HScan(ctx, key, 0, "f*", 10).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cursor).To(Equal(uint64(0)))
+			Expect(items).To(Equal([]string{"f1", "v1", "f2", "v2"}))
+		})
+
+		It("should return WRONGTYPE for string keys", func() {
+			key := "test:hash:string"
+			redisClient.Set(ctx, key, "value", 0)
+
+			err := redisClient.HSet(ctx, key, "field", "value").Err()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("WRONGTYPE"))
+		})
+	})
+
+	Describe("Protocol Negotiation", func() {
+		It("should negotiate RESP3 with HELLO", func() {
+			hello := redisClient.Do(ctx, "HELLO", "3", "SETNAME", "tests")
+			Expect(hello.Err()).NotTo(HaveOccurred())
+
+			reply, ok := hello.Val().(map[interface{}]interface{})
+			Expect(ok).To(BeTrue())
+			Expect(reply["server"]).To(Equal("keyp"))
+			Expect(reply["proto"]).To(Equal(int64(3)))
+		})
+
+		It("should reject unsupported protocol versions and options", func() {
+			Expect(redisClient.Do(ctx, "HELLO", "4").Err()).To(MatchError(ContainSubstring("NOPROTO")))
+			Expect(redisClient.Do(ctx, "HELLO", "three").Err()).To(MatchError(ContainSubstring("Protocol version")))
+			Expect(redisClient.Do(ctx, "HELLO", "3", "AUTH", "admin", "secret").Err()).To(MatchError(ContainSubstring("WRONGPASS")))
+			Expect(redisClient.Do(ctx, "HELLO", "3", "BOGUS").Err()).To(MatchError(ContainSubstring("Syntax error")))
+		})
+
+		It("should accept the default user with any password", func() {
+			authed := redis.NewClient(&redis.Options{
+				Addr:     "localhost:" + testPort,
+				Username: "default",
+				Password: "secret",
+				PoolSize: 1,
+			})
+			defer authed.Close()
+
+			Expect(authed.Ping(ctx).Err()).NotTo(HaveOccurred())
+		})
+
+		It("should queue HELLO inside MULTI", func() {
+			conn, err := net.Dial("tcp", "localhost:"+testPort)
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			_, err = conn.Write([]byte(
+				"*1\r\n$5\r\nMULTI\r\n" +
+					"*2\r\n$5\r\nHELLO\r\n$1\r\n3\r\n" +
+					"*1\r\n$4\r\nEXEC\r\n",
+			))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(conn.SetReadDeadline(time.Now().Add(3 * time.Second))).To(Succeed())
+
+			var received []byte
+			buffer := make([]byte, 4096)
+
+			for !strings.Contains(string(received), "modules") {
+				read, readErr := conn.Read(buffer)
+				Expect(readErr).NotTo(HaveOccurred())
+				received = append(received, buffer[:read]...)
+			}
+
+			Expect(string(received)).To(HavePrefix("+OK\r\n+QUEUED\r\n*1\r\n%7\r\n"))
+		})
+
+		It("should write maps, doubles and nulls to RESP3 clients", func() {
+			redisClient.HSet(ctx, "proto:hash", "field", "value")
+			redisClient.ZAdd(ctx, "proto:zset", redis.Z{Score: 1.5, Member: "a"})
+
+			conn, err := net.Dial("tcp", "localhost:"+testPort)
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			_, err = conn.Write([]byte(
+				"*2\r\n$5\r\nHELLO\r\n$1\r\n3\r\n" +
+					"*2\r\n$7\r\nHGETALL\r\n$10\r\nproto:hash\r\n" +
+					"*5\r\n$6\r\nZRANGE\r\n$10\r\nproto:zset\r\n$1\r\n0\r\n$2\r\n-1\r\n$10\r\nWITHSCORES\r\n" +
+					"*2\r\n$3\r\nGET\r\n$13\r\nproto:missing\r\n" +
+					"*1\r\n$4\r\nPING\r\n",
+			))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(conn.SetReadDeadline(time.Now().Add(3 * time.Second))).To(Succeed())
+
+			var received []byte
+			buffer := make([]byte, 4096)
+
+			for !strings.HasSuffix(string(received), "+PONG\r\n") {
+				read, readErr := conn.Read(buffer)
+				Expect(readErr).NotTo(HaveOccurred())
+				received = append(received, buffer[:read]...)
+			}
+
+			Expect(string(received)).To(HavePrefix("%7\r\n"))
+			Expect(string(received)).To(HaveSuffix(
+				"%1\r\n$5\r\nfield\r\n$5\r\nvalue\r\n" +
+					"*1\r\n*2\r\n$1\r\na\r\n,1.5\r\n" +
+					"_\r\n" +
+					"+PONG\r\n",
+			))
+		})
+
+		It("should keep RESP2 replies for RESP2 clients", func() {
+			resp2Client := redis.NewClient(&redis.Options{Addr: "localhost:" + testPort, Protocol: 2, PoolSize: 1})
+			defer resp2Client.Close()
+
+			resp2Client.HSet(ctx, "proto:hash", "field", "value")
+			resp2Client.ZAdd(ctx, "proto:zset", redis.Z{Score: 1.5, Member: "a"})
+			resp2Client.ZAdd(ctx, "proto:zset", redis.Z{Score: 2, Member: "b"})
+
+			Expect(resp2Client.HGetAll(ctx, "proto:hash").Val()).To(Equal(map[string]string{"field": "value"}))
+			Expect(resp2Client.ZRangeWithScores(ctx, "proto:zset", 0, -1).Val()).To(Equal([]redis.Z{
+				{Score: 1.5, Member: "a"},
+				{Score: 2, Member: "b"},
+			}))
+			Expect(resp2Client.Do(ctx, "ZRANGE", "proto:zset", "0", "0", "WITHSCORES").Val()).To(Equal([]interface{}{"a", "1.5"}))
+		})
+
+		It("should read scores as doubles on RESP3 clients", func() {
+			redisClient.ZAdd(ctx, "proto:zset", redis.Z{Score: 1.5, Member: "a"})
+			redisClient.ZAdd(ctx, "proto:zset", redis.Z{Score: 2, Member: "b"})
+
+			Expect(redisClient.ZRangeWithScores(ctx, "proto:zset", 0, -1).Val()).To(Equal([]redis.Z{
+				{Score: 1.5, Member: "a"},
+				{Score: 2, Member: "b"},
+			}))
+		})
+	})
+
+	Describe("Transactions", func() {
+		It("should reply to EXEC with one array of replies", func() {
+			var incr *redis.IntCmd
+			var get *redis.StringCmd
+
+			cmds, err := redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(ctx, "tx:key", "value", 0)
+				incr = pipe.Incr(ctx, "tx:counter")
+				get = pipe.Get(ctx, "tx:key")
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmds).To(HaveLen(3))
+			Expect(incr.Val()).To(Equal(int64(1)))
+			Expect(get.Val()).To(Equal("value"))
+
+			Expect(redisClient.Ping(ctx).Err()).NotTo(HaveOccurred())
+		})
+
+		It("should keep per-command errors inside the EXEC reply", func() {
+			redisClient.Set(ctx, "tx:text", "abc", 0)
+
+			var incr *redis.IntCmd
+			var get *redis.StringCmd
+
+			_, err := redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				incr = pipe.Incr(ctx, "tx:text")
+				get = pipe.Get(ctx, "tx:text")
+				return nil
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(incr.Err()).To(HaveOccurred())
+			Expect(get.Val()).To(Equal("abc"))
+		})
+
+		It("should reject EXEC without MULTI", func() {
+			err := redisClient.Do(ctx, "EXEC").Err()
+			Expect(err).To(MatchError("ERR EXEC without MULTI"))
+		})
+
+		It("should abort EXEC after a queuing error", func() {
+			conn := redisClient.Conn()
+			defer conn.Close()
+
+			Expect(conn.Do(ctx, "MULTI").Err()).NotTo(HaveOccurred())
+			Expect(conn.Do(ctx, "SET", "tx:aborted", "value").Err()).NotTo(HaveOccurred())
+			Expect(conn.Do(ctx, "NOSUCHCOMMAND").Err()).To(HaveOccurred())
+
+			err := conn.Do(ctx, "EXEC").Err()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("EXECABORT"))
+
+			Expect(conn.Exists(ctx, "tx:aborted").Val()).To(Equal(int64(0)))
+		})
+
+		It("should fail a watched transaction when the key changes", func() {
+			other := createRedisClient("localhost:" + testPort)
+			defer other.Close()
+
+			redisClient.Set(ctx, "tx:watched", "1", 0)
+
+			err := redisClient.Watch(ctx, func(tx *redis.Tx) error {
+				Expect(other.Set(ctx, "tx:watched", "2", 0).Err()).NotTo(HaveOccurred())
+
+				_, txErr := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+					pipe.Set(ctx, "tx:watched", "3", 0)
+					return nil
+				})
+				return txErr
+			}, "tx:watched")
+			Expect(err).To(Equal(redis.TxFailedErr))
+			Expect(redisClient.Get(ctx, "tx:watched").Val()).To(Equal("2"))
+
+			err = redisClient.Watch(ctx, func(tx *redis.Tx) error {
+				_, txErr := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+					pipe.Set(ctx, "tx:watched", "4", 0)
+					return nil
+				})
+				return txErr
+			}, "tx:watched")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(redisClient.Get(ctx, "tx:watched").Val()).To(Equal("4"))
+		})
+	})
+
 	Describe("Database Operations", func() {
 		It("should handle PING command", func() {
 			pingResult := redisClient.Ping(ctx)
@@ -477,6 +939,34 @@ var _ = Describe("Redis Compatibility Integration Tests", func() {
 			}
 		})
 
+		It("should handle CONFIG GET and CONFIG SET", func() {
+			databases := redisClient.ConfigGet(ctx, "databases")
+			Expect(databases.Err()).NotTo(HaveOccurred())
+			Expect(databases.Val()).To(Equal(map[string]string{"databases": "100"}))
+
+			Expect(redisClient.ConfigSet(ctx, "max-packed-entries", "4").Err()).NotTo(HaveOccurred())
+			Expect(redisClient.ConfigGet(ctx, "max-packed-*").Val()).To(Equal(map[string]string{"max-packed-entries": "4"}))
+
+			redisClient.RPush(ctx, "test:config:list", "a", "b", "c", "d", "e")
+			Expect(redisClient.ObjectEncoding(ctx, "test:config:list").Val()).To(Equal("quicklist"))
+
+			err := redisClient.ConfigSet(ctx, "databases", "4").Err()
+			Expect(err).To(MatchError(ContainSubstring("can't set immutable config")))
+		})
+
+		It("should handle INFO with the default and keyspace sections", func() {
+			redisClient.Set(ctx, "test:info:key", "value", 0)
+
+			info := redisClient.Info(ctx)
+			Expect(info.Err()).NotTo(HaveOccurred())
+			Expect(info.Val()).To(ContainSubstring("connected_clients:1\r\n"))
+			Expect(info.Val()).To(ContainSubstring("tcp_port:" + testPort + "\r\n"))
+			Expect(info.Val()).NotTo(ContainSubstring("# Commandstats"))
+
+			keyspace := redisClient.Info(ctx, "keyspace")
+			Expect(keyspace.Val()).To(Equal("# Keyspace\r\ndb0:keys=1,expires=0,avg_ttl=0\r\n"))
+		})
+
 		It("should handle SEL command for database selection", func() {
 			selectResult := redisClient.Do(ctx, "SEL", "0")
 			Expect(selectResult.Err()).NotTo(HaveOccurred())
@@ -19,6 +19,6 @@ func (handler *Handler) rpush(args Args) *Result {
 		}
 	}
 
-	res.Response = formatInt64(length)
+	res.SetInteger(length)
 	return res
 }
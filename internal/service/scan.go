@@ -0,0 +1,93 @@
+package service
+
+import (
+	"strconv"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+func (handler *Handler) scan(args Args) *Result {
+	res := domain.NewResult()
+
+	cursor, err := parseCursor(args[domain.FirstArg])
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	options, err := parseScanOptions(args[domain.SecondArg:], true)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	after, err := handler.resumeCursor(nil, cursor)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	last, keys, err := handler.storage.Scan(handler.context, after, options.pattern, options.count, options.kind)
+
+	if isContextCanceled(err) {
+		return res.SetCanceled()
+	}
+
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	setScan(res, handler.saveCursor(nil, last), keys)
+	return res
+}
+
+type scanOptions struct {
+	pattern []byte
+	count   int64
+	kind    string
+}
+
+func parseCursor(arg []byte) (uint64, error) {
+	cursor, err := strconv.ParseUint(string(arg), 10, 64)
+	if hasError(err) {
+		return 0, domain.ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+func parseScanOptions(args Args, allowType bool) (scanOptions, error) {
+	var options scanOptions
+
+	for index := 0; index < len(args); index += 2 {
+		if index+1 >= len(args) {
+			return options, domain.ErrSyntax
+		}
+
+		value := args[index+1]
+
+		switch normalizeCommandName(string(args[index])) {
+		case "MATCH":
+			options.pattern = value
+		case "COUNT":
+			count, err := strconv.ParseInt(string(value), 10, 64)
+			if hasError(err) {
+				return options, domain.ErrInvalidInteger
+			}
+			if count < 1 {
+				return options, domain.ErrSyntax
+			}
+			options.count = count
+		case "TYPE":
+			if !allowType {
+				return options, domain.ErrSyntax
+			}
+			options.kind = string(value)
+		default:
+			return options, domain.ErrSyntax
+		}
+	}
+
+	return options, nil
+}
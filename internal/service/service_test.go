@@ -9,6 +9,8 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
 func TestService(t *testing.T) {
@@ -27,3 +29,13 @@ func cleanupTestDir(dir string) {
 		os.RemoveAll(dir)
 	}
 }
+
+func itemsOf(result *domain.Result) []string {
+	values := make([]string, 0, len(result.Items))
+
+	for _, item := range result.Items {
+		values = append(values, string(item.Response))
+	}
+
+	return values
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/luiz-simples/keyp.git/internal/domain"
 )
@@ -11,38 +12,103 @@ func (handler *Handler) set(args Args) *Result {
 	value := args[domain.SecondArg]
 
 	res := domain.NewResult()
-	res.Error = handler.storage.Set(handler.context, key, value)
+	options, err := parseSetOptions(args[domain.ThirdArg:])
 
-	if isContextCanceled(res.Error) {
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	previous, applied, err := handler.storage.SetWith(handler.context, key, value, options)
+
+	if isContextCanceled(err) {
 		return res.SetCanceled()
 	}
 
-	if hasError(res.Error) {
+	if hasError(err) {
+		res.Error = err
 		return res
 	}
 
-	if len(args) >= 5 {
-		option := string(args[3])
-		ttlStr := string(args[4])
+	if options.Get {
+		if previous == nil {
+			return res.SetNil()
+		}
 
-		if option == "EX" {
-			ttl, err := strconv.ParseInt(ttlStr, 10, 64)
-			if hasError(err) {
-				res.Error = domain.ErrInvalidInteger
-				return res
+		res.Response = previous
+		return res
+	}
+
+	if !applied {
+		return res.SetNil()
+	}
+
+	return res.SetOK()
+}
+
+func parseSetOptions(args Args) (domain.SetOptions, error) {
+	var options domain.SetOptions
+	expiry := false
+
+	for index := 0; index < len(args); index++ {
+		switch option := strings.ToUpper(string(args[index])); option {
+		case "NX", "XX":
+			if options.NX || options.XX {
+				return options, domain.ErrSyntax
 			}
-			handler.storage.Expire(handler.context, key, uint32(ttl))
-		}
 
-		if option == "PX" {
-			ttl, err := strconv.ParseInt(ttlStr, 10, 64)
+			options.NX = option == "NX"
+			options.XX = option == "XX"
+		case "GET":
+			options.Get = true
+		case "KEEPTTL":
+			if expiry {
+				return options, domain.ErrSyntax
+			}
+
+			expiry = true
+			options.KeepTTL = true
+		case "EX", "PX", "EXAT", "PXAT":
+			if expiry || index+1 >= len(args) {
+				return options, domain.ErrSyntax
+			}
+
+			expiry = true
+			index++
+
+			deadline, err := setDeadline("set", option, args[index])
 			if hasError(err) {
-				res.Error = domain.ErrInvalidInteger
-				return res
+				return options, err
 			}
-			handler.storage.Expire(handler.context, key, uint32(ttl/1000))
+
+			options.Deadline = deadline
+		default:
+			return options, domain.ErrSyntax
 		}
 	}
 
-	return res.SetOK()
+	return options, nil
+}
+
+func setDeadline(name, option string, arg []byte) (int64, error) {
+	amount, err := strconv.ParseInt(string(arg), 10, 64)
+	if hasError(err) {
+		return 0, domain.ErrInvalidInteger
+	}
+
+	if amount <= 0 {
+		return 0, newInvalidExpireError(name)
+	}
+
+	unit := millisPerSecond
+	if strings.HasPrefix(option, "P") {
+		unit = millisPerMilli
+	}
+
+	deadline, valid := expireDeadline(amount, unit, strings.HasSuffix(option, "AT"))
+	if !valid {
+		return 0, newInvalidExpireError(name)
+	}
+
+	return deadline, nil
 }
@@ -0,0 +1,28 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) setbit(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+
+	offset, err := parseBitOffset(args[domain.SecondArg])
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	bit, err := parseBit(args[domain.ThirdArg], errBitValue)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	previous, err := handler.storage.SetBit(handler.context, key, offset, bit)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetInteger(previous)
+}
@@ -0,0 +1,37 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) setex(args Args) *Result {
+	return handler.setExpiring(args, "setex", "EX")
+}
+
+func (handler *Handler) psetex(args Args) *Result {
+	return handler.setExpiring(args, "psetex", "PX")
+}
+
+func (handler *Handler) setExpiring(args Args, name, option string) *Result {
+	key := args[domain.FirstArg]
+	value := args[domain.ThirdArg]
+
+	res := domain.NewResult()
+	deadline, err := setDeadline(name, option, args[domain.SecondArg])
+
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	_, _, err = handler.storage.SetWith(handler.context, key, value, domain.SetOptions{Deadline: deadline})
+
+	if isContextCanceled(err) {
+		return res.SetCanceled()
+	}
+
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetOK()
+}
@@ -0,0 +1,22 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) setnx(args Args) *Result {
+	key := args[domain.FirstArg]
+	value := args[domain.SecondArg]
+
+	res := domain.NewResult()
+	_, applied, err := handler.storage.SetWith(handler.context, key, value, domain.SetOptions{NX: true})
+
+	if isContextCanceled(err) {
+		return res.SetCanceled()
+	}
+
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetBool(applied)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+var errOffsetRange = errors.New("ERR offset is out of range")
+
+func (handler *Handler) setrange(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+	value := args[domain.ThirdArg]
+
+	offset, err := strconv.ParseInt(string(args[domain.SecondArg]), 10, 64)
+	if hasError(err) {
+		res.Error = domain.ErrInvalidInteger
+		return res
+	}
+
+	if offset < 0 {
+		res.Error = errOffsetRange
+		return res
+	}
+
+	length, err := handler.storage.SetRange(handler.context, key, offset, value)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetInteger(length)
+}
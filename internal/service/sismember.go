@@ -10,6 +10,6 @@ func (handler *Handler) sismember(args Args) *Result {
 	member := args[domain.SecondArg]
 
 	exists := handler.storage.SIsMember(handler.context, key, member)
-	res.Response = formatBool(exists)
+	res.SetBool(exists)
 	return res
 }
@@ -14,6 +14,6 @@ func (handler *Handler) smembers(args Args) *Result {
 		return res
 	}
 
-	res.Response = formatArray(members)
+	res.SetSet(members)
 	return res
 }
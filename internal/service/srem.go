@@ -9,7 +9,12 @@ func (handler *Handler) srem(args Args) *Result {
 	key := args[domain.FirstArg]
 	members := args[domain.SecondArg:]
 
-	count := handler.storage.SRem(handler.context, key, members...)
-	res.Response = formatInt64(count)
+	count, err := handler.storage.SRem(handler.context, key, members...)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	res.SetInteger(count)
 	return res
 }
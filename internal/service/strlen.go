@@ -0,0 +1,16 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) strlen(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+
+	length, err := handler.storage.StrLen(handler.context, key)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	return res.SetInteger(length)
+}
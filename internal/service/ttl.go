@@ -1,28 +1,54 @@
 package service
 
 import (
+	"time"
+
 	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
 func (handler *Handler) ttl(args Args) *Result {
-	res := domain.NewResult()
-	key := args[domain.FirstArg]
-	secs := handler.storage.TTL(handler.context, key)
-
-	if secs == 0 {
-		if handler.storage.Exists(handler.context, key) {
-			res.Response = []byte("-1")
-		} else {
-			res.Response = []byte("-2")
-		}
+	return handler.remaining(args, millisPerSecond)
+}
+
+func (handler *Handler) pttl(args Args) *Result {
+	return handler.remaining(args, millisPerMilli)
+}
+
+func (handler *Handler) expiretime(args Args) *Result {
+	return handler.deadline(args, millisPerSecond)
+}
+
+func (handler *Handler) pexpiretime(args Args) *Result {
+	return handler.deadline(args, millisPerMilli)
+}
+
+func (handler *Handler) remaining(args Args, unit int64) *Result {
+	res := handler.deadline(args, millisPerMilli)
+
+	if hasError(res.Error) || res.Integer < 0 {
 		return res
 	}
 
-	if secs == 0xFFFFFFFF {
-		res.Response = []byte("-1")
+	left := max(res.Integer-time.Now().UnixMilli(), 0)
+	return res.SetInteger((left + unit/2) / unit)
+}
+
+func (handler *Handler) deadline(args Args, unit int64) *Result {
+	res := domain.NewResult()
+	deadline, err := handler.storage.ExpireTime(handler.context, args[domain.FirstArg])
+
+	if isContextCanceled(err) {
+		return res.SetCanceled()
+	}
+
+	if hasError(err) {
+		res.Error = err
 		return res
 	}
 
-	res.Response = formatUint32(secs)
-	return res
+	if deadline < 0 {
+		return res.SetInteger(deadline)
+	}
+
+	return res.SetInteger(deadline / unit)
 }
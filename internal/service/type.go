@@ -0,0 +1,10 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) keyType(args Args) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
+
+	return res.SetSimple([]byte(handler.storage.Type(handler.context, key)))
+}
@@ -3,6 +3,7 @@ package service_test
 import (
 	"context"
 	"errors"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -14,16 +15,20 @@ import (
 
 var _ = Describe("Handler Unit Tests", func() {
 	var (
-		ctrl          *gomock.Controller
-		mockPersister *MockPersister
-		handler       *service.Handler
-		ctx           context.Context
+		ctrl           *gomock.Controller
+		mockPersister  *MockPersister
+		mockConfigurer *MockConfigurer
+		handler        *service.Handler
+		stats          *domain.Stats
+		ctx            context.Context
 	)
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mockPersister = NewMockPersister(ctrl)
-		handler = service.NewHandler(mockPersister)
+		mockConfigurer = NewMockConfigurer(ctrl)
+		stats = domain.NewStats()
+		handler = service.NewHandler(mockPersister, mockConfigurer, stats)
 		ctx = context.Background()
 	})
 
@@ -56,6 +61,75 @@ var _ = Describe("Handler Unit Tests", func() {
 		})
 	})
 
+	Describe("HELLO Command", func() {
+		It("should reply with server details and keep RESP2 by default", func() {
+			results := handler.Apply(ctx, [][]byte{[]byte("HELLO")})
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Error).To(BeNil())
+			Expect(results[0].Kind).To(Equal(domain.ReplyMap))
+			Expect(results[0].Items).To(HaveLen(14))
+			Expect(results[0].Items[5].Integer).To(Equal(int64(domain.RESP2)))
+			Expect(handler.Protocol()).To(Equal(domain.RESP2))
+		})
+
+		It("should switch the connection protocol", func() {
+			results := handler.Apply(ctx, [][]byte{[]byte("HELLO"), []byte("3"), []byte("SETNAME"), []byte("worker")})
+
+			Expect(results[0].Error).To(BeNil())
+			Expect(handler.Protocol()).To(Equal(domain.RESP3))
+
+			handler.Clear()
+			Expect(handler.Protocol()).To(Equal(domain.RESP2))
+		})
+
+		It("should accept the default user with any password", func() {
+			args := [][]byte{[]byte("HELLO"), []byte("3"), []byte("AUTH"), []byte("default"), []byte("secret"), []byte("SETNAME"), []byte("worker")}
+
+			results := handler.Apply(ctx, args)
+
+			Expect(results[0].Error).To(BeNil())
+			Expect(handler.Protocol()).To(Equal(domain.RESP3))
+		})
+
+		It("should reject invalid versions, credentials and options", func() {
+			hello := func(args ...string) error {
+				command := [][]byte{[]byte("HELLO")}
+				for _, arg := range args {
+					command = append(command, []byte(arg))
+				}
+
+				return handler.Apply(ctx, command)[0].Error
+			}
+
+			Expect(hello("4")).To(Equal(domain.ErrNoProto))
+			Expect(hello("three")).To(Equal(domain.ErrProtoVersion))
+			Expect(hello("3", "AUTH", "admin", "secret")).To(Equal(domain.ErrWrongPass))
+			Expect(hello("3", "SETNAME", "bad name")).To(Equal(domain.ErrClientName))
+			Expect(hello("3", "AUTH", "default")).To(MatchError("ERR Syntax error in HELLO option 'AUTH'"))
+			Expect(handler.Protocol()).To(Equal(domain.RESP2))
+		})
+
+		It("should be queued inside MULTI", func() {
+			handler.Apply(ctx, [][]byte{[]byte("MULTI")})
+
+			results := handler.Apply(ctx, [][]byte{[]byte("HELLO"), []byte("3")})
+			Expect(results[0].Response).To(Equal([]byte(domain.QUEUED)))
+			Expect(handler.Protocol()).To(Equal(domain.RESP2))
+
+			mockPersister.EXPECT().
+				Atomic(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+
+			results = handler.Apply(ctx, [][]byte{[]byte("EXEC")})
+			Expect(results[0].Items).To(HaveLen(1))
+			Expect(results[0].Items[0].Kind).To(Equal(domain.ReplyMap))
+			Expect(handler.Protocol()).To(Equal(domain.RESP3))
+		})
+	})
+
 	Describe("SET Command", func() {
 		Context("when storage succeeds", func() {
 			It("should return OK", func() {
@@ -64,8 +138,8 @@ var _ = Describe("Handler Unit Tests", func() {
 				args := [][]byte{[]byte("SET"), key, value}
 
 				mockPersister.EXPECT().
-					Set(gomock.Any(), key, value).
-					Return(nil)
+					SetWith(gomock.Any(), key, value, domain.SetOptions{}).
+					Return(nil, true, nil)
 
 				results := handler.Apply(ctx, args)
 
@@ -83,8 +157,8 @@ var _ = Describe("Handler Unit Tests", func() {
 				expectedError := errors.New("storage error")
 
 				mockPersister.EXPECT().
-					Set(gomock.Any(), key, value).
-					Return(expectedError)
+					SetWith(gomock.Any(), key, value, domain.SetOptions{}).
+					Return(nil, false, expectedError)
 
 				results := handler.Apply(ctx, args)
 
@@ -100,8 +174,8 @@ var _ = Describe("Handler Unit Tests", func() {
 				args := [][]byte{[]byte("SET"), key, value}
 
 				mockPersister.EXPECT().
-					Set(gomock.Any(), key, value).
-					Return(context.Canceled)
+					SetWith(gomock.Any(), key, value, domain.SetOptions{}).
+					Return(nil, false, context.Canceled)
 
 				results := handler.Apply(ctx, args)
 
@@ -110,6 +184,71 @@ var _ = Describe("Handler Unit Tests", func() {
 			})
 		})
 
+		Context("with options", func() {
+			It("should pass the condition, GET and deadline to storage", func() {
+				key := []byte("lock")
+				value := []byte("token")
+				before := time.Now().UnixMilli()
+
+				mockPersister.EXPECT().
+					SetWith(gomock.Any(), key, value, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ []byte, options domain.SetOptions) ([]byte, bool, error) {
+						Expect(options.NX).To(BeTrue())
+						Expect(options.Deadline).To(BeNumerically("~", before+3000, 1000))
+						return nil, false, nil
+					})
+
+				results := handler.Apply(ctx, [][]byte{[]byte("SET"), key, value, []byte("nx"), []byte("PX"), []byte("3000")})
+
+				Expect(results[0].Error).To(BeNil())
+				Expect(results[0].Kind).To(Equal(domain.ReplyBulk))
+				Expect(results[0].Response).To(BeNil())
+			})
+
+			It("should reply with the previous value on GET", func() {
+				key := []byte("key")
+				value := []byte("new")
+				options := domain.SetOptions{XX: true, Get: true, KeepTTL: true}
+
+				mockPersister.EXPECT().SetWith(gomock.Any(), key, value, options).Return([]byte("old"), true, nil)
+
+				results := handler.Apply(ctx, [][]byte{[]byte("SET"), key, value, []byte("KEEPTTL"), []byte("GET"), []byte("XX")})
+
+				Expect(results[0].Error).To(BeNil())
+				Expect(results[0].Response).To(Equal([]byte("old")))
+			})
+
+			It("should convert absolute deadlines to milliseconds", func() {
+				key := []byte("key")
+				value := []byte("value")
+
+				mockPersister.EXPECT().SetWith(gomock.Any(), key, value, domain.SetOptions{Deadline: 1700000000000}).Return(nil, true, nil)
+				mockPersister.EXPECT().SetWith(gomock.Any(), key, value, domain.SetOptions{Deadline: 1700000000123}).Return(nil, true, nil)
+
+				handler.Apply(ctx, [][]byte{[]byte("SET"), key, value, []byte("EXAT"), []byte("1700000000")})
+				handler.Apply(ctx, [][]byte{[]byte("SET"), key, value, []byte("PXAT"), []byte("1700000000123")})
+			})
+
+			It("should reject invalid combinations before touching storage", func() {
+				set := func(options ...string) error {
+					args := [][]byte{[]byte("SET"), []byte("key"), []byte("value")}
+					for _, option := range options {
+						args = append(args, []byte(option))
+					}
+					return handler.Apply(ctx, args)[0].Error
+				}
+
+				Expect(set("NX", "XX")).To(MatchError(domain.ErrSyntax))
+				Expect(set("EX", "10", "PX", "100")).To(MatchError(domain.ErrSyntax))
+				Expect(set("KEEPTTL", "EX", "10")).To(MatchError(domain.ErrSyntax))
+				Expect(set("EX")).To(MatchError(domain.ErrSyntax))
+				Expect(set("FOREVER")).To(MatchError(domain.ErrSyntax))
+				Expect(set("EX", "ten")).To(MatchError(domain.ErrInvalidInteger))
+				Expect(set("PX", "0")).To(MatchError("ERR invalid expire time in 'set' command"))
+				Expect(set("EX", "9223372036854775807")).To(MatchError("ERR invalid expire time in 'set' command"))
+			})
+		})
+
 		Context("with invalid arguments", func() {
 			It("should return error for missing key", func() {
 				args := [][]byte{[]byte("SET")}
@@ -131,6 +270,159 @@ var _ = Describe("Handler Unit Tests", func() {
 		})
 	})
 
+	Describe("String family commands", func() {
+		It("should read and write many keys with MGET, MSET and MSETNX", func() {
+			a, b := []byte("a"), []byte("b")
+
+			mockPersister.EXPECT().MSet(gomock.Any(), a, []byte("1"), b, []byte("2")).Return(nil)
+			mockPersister.EXPECT().MSetNX(gomock.Any(), a, []byte("3")).Return(false, nil)
+			mockPersister.EXPECT().MGet(gomock.Any(), a, b).Return([][]byte{[]byte("1"), nil}, nil)
+
+			results := handler.Apply(ctx, [][]byte{[]byte("MSET"), a, []byte("1"), b, []byte("2")})
+			Expect(results[0].Response).To(Equal([]byte("OK")))
+
+			results = handler.Apply(ctx, [][]byte{[]byte("MSETNX"), a, []byte("3")})
+			Expect(results[0].Kind).To(Equal(domain.ReplyInteger))
+			Expect(results[0].Integer).To(BeZero())
+
+			results = handler.Apply(ctx, [][]byte{[]byte("MGET"), a, b})
+			Expect(results[0].Error).To(BeNil())
+			Expect(results[0].Items).To(HaveLen(2))
+
+			results = handler.Apply(ctx, [][]byte{[]byte("MSET"), a, []byte("1"), b})
+			Expect(results[0].Error).To(MatchError(ContainSubstring("wrong number of arguments")))
+		})
+
+		It("should map GETSET, SETNX, SETEX and PSETEX onto SetWith", func() {
+			key := []byte("key")
+			value := []byte("value")
+			before := time.Now().UnixMilli()
+
+			mockPersister.EXPECT().SetWith(gomock.Any(), key, value, domain.SetOptions{Get: true}).Return([]byte("old"), true, nil)
+			mockPersister.EXPECT().SetWith(gomock.Any(), key, value, domain.SetOptions{NX: true}).Return(nil, false, nil)
+			mockPersister.EXPECT().
+				SetWith(gomock.Any(), key, value, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ []byte, options domain.SetOptions) ([]byte, bool, error) {
+					Expect(options.Deadline).To(BeNumerically("~", before+10000, 1000))
+					return nil, true, nil
+				})
+			mockPersister.EXPECT().
+				SetWith(gomock.Any(), key, value, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ []byte, options domain.SetOptions) ([]byte, bool, error) {
+					Expect(options.Deadline).To(BeNumerically("~", before+250, 1000))
+					return nil, true, nil
+				})
+
+			Expect(handler.Apply(ctx, [][]byte{[]byte("GETSET"), key, value})[0].Response).To(Equal([]byte("old")))
+			Expect(handler.Apply(ctx, [][]byte{[]byte("SETNX"), key, value})[0].Integer).To(BeZero())
+			Expect(handler.Apply(ctx, [][]byte{[]byte("SETEX"), key, []byte("10"), value})[0].Response).To(Equal([]byte("OK")))
+			Expect(handler.Apply(ctx, [][]byte{[]byte("PSETEX"), key, []byte("250"), value})[0].Response).To(Equal([]byte("OK")))
+
+			results := handler.Apply(ctx, [][]byte{[]byte("SETEX"), key, []byte("0"), value})
+			Expect(results[0].Error).To(MatchError("ERR invalid expire time in 'setex' command"))
+		})
+
+		It("should reply nil from GETDEL and GETEX on missing keys", func() {
+			key := []byte("missing")
+
+			mockPersister.EXPECT().GetDel(gomock.Any(), key).Return(nil, errors.New("key not found"))
+			mockPersister.EXPECT().GetEx(gomock.Any(), key, domain.GetExOptions{Persist: true}).Return(nil, errors.New("key not found"))
+
+			results := handler.Apply(ctx, [][]byte{[]byte("GETDEL"), key})
+			Expect(results[0].Error).To(BeNil())
+			Expect(results[0].Response).To(BeNil())
+
+			results = handler.Apply(ctx, [][]byte{[]byte("GETEX"), key, []byte("persist")})
+			Expect(results[0].Error).To(BeNil())
+			Expect(results[0].Response).To(BeNil())
+		})
+
+		It("should parse the GETEX options", func() {
+			key := []byte("key")
+
+			mockPersister.EXPECT().GetEx(gomock.Any(), key, domain.GetExOptions{Deadline: 1700000000000}).Return([]byte("v"), nil)
+
+			results := handler.Apply(ctx, [][]byte{[]byte("GETEX"), key, []byte("EXAT"), []byte("1700000000")})
+			Expect(results[0].Response).To(Equal([]byte("v")))
+
+			getex := func(options ...string) error {
+				args := [][]byte{[]byte("GETEX"), key}
+				for _, option := range options {
+					args = append(args, []byte(option))
+				}
+				return handler.Apply(ctx, args)[0].Error
+			}
+
+			Expect(getex("PERSIST", "EX")).To(MatchError(domain.ErrSyntax))
+			Expect(getex("EX")).To(MatchError(domain.ErrSyntax))
+			Expect(getex("KEEPTTL")).To(MatchError(domain.ErrSyntax))
+			Expect(getex("PX", "-1")).To(MatchError("ERR invalid expire time in 'getex' command"))
+		})
+	})
+
+	Describe("Bitmap commands", func() {
+		apply := func(args ...string) *domain.Result {
+			command := make([][]byte, 0, len(args))
+			for _, arg := range args {
+				command = append(command, []byte(arg))
+			}
+			return handler.Apply(ctx, command)[0]
+		}
+
+		It("should pass parsed offsets and ranges to storage", func() {
+			key := []byte("key")
+
+			mockPersister.EXPECT().SetBit(gomock.Any(), key, uint64(7), int64(1)).Return(int64(0), nil)
+			mockPersister.EXPECT().BitCount(gomock.Any(), key, domain.BitRange{Start: 1, End: -1, HasEnd: true, Bit: true}).Return(int64(3), nil)
+			mockPersister.EXPECT().BitPos(gomock.Any(), key, int64(0), domain.BitRange{Start: 2, End: -1}).Return(int64(16), nil)
+			mockPersister.EXPECT().BitOp(gomock.Any(), domain.BitXor, []byte("dest"), key, []byte("other")).Return(int64(2), nil)
+
+			Expect(apply("SETBIT", "key", "7", "1").Integer).To(BeZero())
+			Expect(apply("BITCOUNT", "key", "1", "-1", "bit").Integer).To(Equal(int64(3)))
+			Expect(apply("BITPOS", "key", "0", "2").Integer).To(Equal(int64(16)))
+			Expect(apply("BITOP", "xor", "dest", "key", "other").Integer).To(Equal(int64(2)))
+		})
+
+		It("should reject invalid arguments before touching storage", func() {
+			Expect(apply("SETBIT", "key", "-1", "1").Error).To(MatchError("ERR bit offset is not an integer or out of range"))
+			Expect(apply("SETBIT", "key", "4294967296", "1").Error).To(MatchError("ERR bit offset is not an integer or out of range"))
+			Expect(apply("SETBIT", "key", "0", "2").Error).To(MatchError("ERR bit is not an integer or out of range"))
+			Expect(apply("BITPOS", "key", "2").Error).To(MatchError("ERR The bit argument must be 1 or 0."))
+			Expect(apply("BITCOUNT", "key", "1").Error).To(MatchError(domain.ErrSyntax))
+			Expect(apply("BITCOUNT", "key", "0", "1", "WORD").Error).To(MatchError(domain.ErrSyntax))
+			Expect(apply("BITOP", "NOT", "dest", "a", "b").Error).To(MatchError("ERR BITOP NOT must be called with a single source key."))
+			Expect(apply("BITOP", "NAND", "dest", "a").Error).To(MatchError(domain.ErrSyntax))
+			Expect(apply("SETRANGE", "key", "-1", "x").Error).To(MatchError("ERR offset is out of range"))
+			Expect(apply("GETRANGE", "key", "a", "1").Error).To(MatchError(domain.ErrInvalidInteger))
+		})
+
+		It("should parse BITFIELD subcommands and reply nil for failed ops", func() {
+			key := []byte("key")
+			ops := []domain.BitFieldOp{
+				{Kind: domain.BitFieldGet, Signed: true, Width: 8, Offset: 16},
+				{Kind: domain.BitFieldSet, Width: 4, Offset: 8, Value: 9},
+				{Kind: domain.BitFieldIncrBy, Width: 4, Offset: 8, Value: 10, Overflow: domain.OverflowFail},
+			}
+			current := int64(-3)
+
+			mockPersister.EXPECT().BitField(gomock.Any(), key, ops).Return([]*int64{&current, &current, nil}, nil)
+
+			result := apply("BITFIELD", "key", "GET", "i8", "#2", "SET", "u4", "8", "9", "overflow", "fail", "INCRBY", "u4", "8", "10")
+
+			Expect(result.Error).To(BeNil())
+			Expect(result.Kind).To(Equal(domain.ReplyArray))
+			Expect(result.Items).To(HaveLen(3))
+			Expect(result.Items[0].Integer).To(Equal(current))
+			Expect(result.Items[2].Kind).To(Equal(domain.ReplyBulk))
+			Expect(result.Items[2].Response).To(BeNil())
+
+			Expect(apply("BITFIELD", "key", "GET", "u64", "0").Error).To(MatchError(ContainSubstring("Invalid bitfield type")))
+			Expect(apply("BITFIELD", "key", "GET", "i8").Error).To(MatchError(domain.ErrSyntax))
+			Expect(apply("BITFIELD", "key", "OVERFLOW", "CLAMP").Error).To(MatchError("ERR Invalid OVERFLOW type specified"))
+			Expect(apply("BITFIELD", "key", "SET", "i8", "0", "x").Error).To(MatchError(domain.ErrInvalidInteger))
+		})
+	})
+
 	Describe("GET Command", func() {
 		Context("when key exists", func() {
 			It("should return value", func() {
@@ -216,7 +508,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("1"))
+				Expect(results[0].Integer).To(Equal(int64(1)))
 			})
 		})
 
@@ -235,7 +527,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("2"))
+				Expect(results[0].Integer).To(Equal(int64(2)))
 			})
 		})
 
@@ -280,38 +572,57 @@ var _ = Describe("Handler Unit Tests", func() {
 
 	Describe("EXPIRE Command", func() {
 		Context("when setting expiration", func() {
-			It("should return OK", func() {
+			It("should reply 1 when the deadline was set", func() {
 				key := []byte("testkey")
-				seconds := []byte("60")
-				args := [][]byte{[]byte("EXPIRE"), key, seconds}
+				args := [][]byte{[]byte("EXPIRE"), key, []byte("60")}
+				before := time.Now().UnixMilli()
 
 				mockPersister.EXPECT().
-					Expire(gomock.Any(), key, uint32(60)).
-					Return()
+					ExpireAt(gomock.Any(), key, gomock.Any(), domain.ExpireAlways).
+					DoAndReturn(func(_ context.Context, _ []byte, deadline int64, _ domain.ExpireCondition) (bool, error) {
+						Expect(deadline).To(BeNumerically("~", before+60000, 1000))
+						return true, nil
+					})
 
 				results := handler.Apply(ctx, args)
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(results[0].Response).To(Equal([]byte("OK")))
+				Expect(results[0].Integer).To(Equal(int64(1)))
+			})
+
+			It("should pass millisecond and absolute deadlines through", func() {
+				key := []byte("testkey")
+
+				mockPersister.EXPECT().ExpireAt(gomock.Any(), key, int64(1700000000000), domain.ExpireGT).Return(false, nil)
+				mockPersister.EXPECT().ExpireAt(gomock.Any(), key, int64(1700000000123), domain.ExpireNX).Return(true, nil)
+
+				results := handler.Apply(ctx, [][]byte{[]byte("EXPIREAT"), key, []byte("1700000000"), []byte("gt")})
+				Expect(results[0].Integer).To(Equal(int64(0)))
+
+				results = handler.Apply(ctx, [][]byte{[]byte("PEXPIREAT"), key, []byte("1700000000123"), []byte("NX")})
+				Expect(results[0].Integer).To(Equal(int64(1)))
 			})
 		})
 
-		Context("when setting expiration with invalid seconds", func() {
-			It("should handle gracefully", func() {
+		Context("when the arguments are invalid", func() {
+			It("should reject them before touching storage", func() {
 				key := []byte("testkey")
-				seconds := []byte("invalid")
-				args := [][]byte{[]byte("EXPIRE"), key, seconds}
 
-				mockPersister.EXPECT().
-					Expire(gomock.Any(), key, uint32(0)).
-					Return()
+				results := handler.Apply(ctx, [][]byte{[]byte("EXPIRE"), key, []byte("invalid")})
+				Expect(results[0].Error).To(MatchError(domain.ErrInvalidInteger))
 
-				results := handler.Apply(ctx, args)
+				results = handler.Apply(ctx, [][]byte{[]byte("EXPIRE"), key, []byte("10"), []byte("NX"), []byte("XX")})
+				Expect(results[0].Error).To(MatchError("ERR NX and XX, GT or LT options at the same time are not compatible"))
 
-				Expect(results).To(HaveLen(1))
-				Expect(results[0].Error).To(BeNil())
-				Expect(results[0].Response).To(Equal([]byte("OK")))
+				results = handler.Apply(ctx, [][]byte{[]byte("PEXPIRE"), key, []byte("10"), []byte("GT"), []byte("LT")})
+				Expect(results[0].Error).To(MatchError("ERR GT and LT options at the same time are not compatible"))
+
+				results = handler.Apply(ctx, [][]byte{[]byte("EXPIRE"), key, []byte("10"), []byte("KEEP")})
+				Expect(results[0].Error).To(MatchError("ERR Unsupported option KEEP"))
+
+				results = handler.Apply(ctx, [][]byte{[]byte("EXPIRE"), key, []byte("9223372036854775807")})
+				Expect(results[0].Error).To(MatchError("ERR invalid expire time in 'expire' command"))
 			})
 		})
 	})
@@ -330,45 +641,46 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(results[0].Response).To(Equal([]byte("1")))
+				Expect(results[0].Integer).To(Equal(int64(1)))
 			})
 		})
 	})
 
 	Describe("TTL Command", func() {
 		Context("when getting TTL", func() {
-			It("should return TTL value", func() {
+			It("should round the remaining time to seconds or milliseconds", func() {
 				key := []byte("testkey")
-				args := [][]byte{[]byte("TTL"), key}
-				expectedTTL := uint32(120)
+				deadline := time.Now().UnixMilli() + 120000
 
-				mockPersister.EXPECT().
-					TTL(gomock.Any(), key).
-					Return(expectedTTL)
-
-				results := handler.Apply(ctx, args)
+				mockPersister.EXPECT().ExpireTime(gomock.Any(), key).Return(deadline, nil).Times(4)
 
-				Expect(results).To(HaveLen(1))
+				results := handler.Apply(ctx, [][]byte{[]byte("TTL"), key})
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("120"))
+				Expect(results[0].Integer).To(Equal(int64(120)))
+
+				results = handler.Apply(ctx, [][]byte{[]byte("PTTL"), key})
+				Expect(results[0].Integer).To(BeNumerically("~", 120000, 1000))
+
+				results = handler.Apply(ctx, [][]byte{[]byte("EXPIRETIME"), key})
+				Expect(results[0].Integer).To(Equal(deadline / 1000))
+
+				results = handler.Apply(ctx, [][]byte{[]byte("PEXPIRETIME"), key})
+				Expect(results[0].Integer).To(Equal(deadline))
 			})
 		})
 
-		Context("when key has no expiration", func() {
-			It("should return -1", func() {
+		Context("when key has no expiration or does not exist", func() {
+			It("should return -1 and -2", func() {
 				key := []byte("testkey")
-				args := [][]byte{[]byte("TTL"), key}
-				expectedTTL := uint32(0xFFFFFFFF)
 
-				mockPersister.EXPECT().
-					TTL(gomock.Any(), key).
-					Return(expectedTTL)
+				mockPersister.EXPECT().ExpireTime(gomock.Any(), key).Return(int64(-1), nil)
+				mockPersister.EXPECT().ExpireTime(gomock.Any(), key).Return(int64(-2), nil)
 
-				results := handler.Apply(ctx, args)
+				results := handler.Apply(ctx, [][]byte{[]byte("TTL"), key})
+				Expect(results[0].Integer).To(Equal(int64(-1)))
 
-				Expect(results).To(HaveLen(1))
-				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("-1"))
+				results = handler.Apply(ctx, [][]byte{[]byte("PEXPIRETIME"), key})
+				Expect(results[0].Integer).To(Equal(int64(-2)))
 			})
 		})
 	})
@@ -423,26 +735,261 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				handler.Apply(ctx, [][]byte{[]byte("MULTI")})
 
-				mockPersister.EXPECT().Set(gomock.Any(), key, value).Return(nil)
+				mockPersister.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				mockPersister.EXPECT().SetWith(gomock.Any(), key, value, domain.SetOptions{}).Return(nil, true, nil)
 				handler.Apply(ctx, [][]byte{[]byte("SET"), key, value})
 
 				results := handler.Apply(ctx, [][]byte{[]byte("EXEC")})
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(results[0].Response).To(Equal([]byte("OK")))
+				Expect(results[0].Kind).To(Equal(domain.ReplyArray))
+				Expect(itemsOf(results[0])).To(Equal([]string{"OK"}))
+			})
+
+			It("should run queued commands with the transaction context", func() {
+				txCtx := context.WithValue(context.Background(), domain.DB, uint8(0))
+
+				handler.Apply(ctx, [][]byte{[]byte("MULTI")})
+				handler.Apply(ctx, [][]byte{[]byte("SEL"), []byte("3")})
+				handler.Apply(ctx, [][]byte{[]byte("INCR"), []byte("counter")})
+
+				mockPersister.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, fn func(context.Context) error) error {
+						return fn(txCtx)
+					})
+				mockPersister.EXPECT().
+					Incr(gomock.Any(), []byte("counter")).
+					DoAndReturn(func(ctx context.Context, _ []byte) (int64, error) {
+						Expect(ctx.Value(domain.DB)).To(Equal(uint8(3)))
+						return 1, nil
+					})
+
+				results := handler.Apply(ctx, [][]byte{[]byte("EXEC")})
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Items).To(HaveLen(2))
+				Expect(results[0].Items[0].Response).To(Equal([]byte("OK")))
+				Expect(results[0].Items[1].Integer).To(Equal(int64(1)))
+
+				mockPersister.EXPECT().
+					Get(gomock.Any(), []byte("counter")).
+					DoAndReturn(func(ctx context.Context, _ []byte) ([]byte, error) {
+						Expect(ctx.Value(domain.DB)).To(Equal(uint8(3)))
+						return []byte("1"), nil
+					})
+
+				results = handler.Apply(ctx, [][]byte{[]byte("GET"), []byte("counter")})
+				Expect(string(results[0].Response)).To(Equal("1"))
+			})
+
+			It("should end the transaction after EXEC", func() {
+				handler.Apply(ctx, [][]byte{[]byte("MULTI")})
+
+				mockPersister.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				handler.Apply(ctx, [][]byte{[]byte("EXEC")})
+
+				mockPersister.EXPECT().Get(gomock.Any(), []byte("key")).Return([]byte("value"), nil)
+
+				results := handler.Apply(ctx, [][]byte{[]byte("GET"), []byte("key")})
+				Expect(string(results[0].Response)).To(Equal("value"))
+			})
+		})
+
+		Context("when the transaction fails to commit", func() {
+			It("should return the storage error", func() {
+				handler.Apply(ctx, [][]byte{[]byte("MULTI")})
+				handler.Apply(ctx, [][]byte{[]byte("SET"), []byte("key"), []byte("value")})
+
+				mockPersister.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						_ = fn(ctx)
+						return errors.New("mdb_txn_commit: MDB_MAP_FULL")
+					})
+				mockPersister.EXPECT().SetWith(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, true, nil)
+
+				results := handler.Apply(ctx, [][]byte{[]byte("EXEC")})
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(MatchError(ContainSubstring("MDB_MAP_FULL")))
 			})
 		})
 
 		Context("when no transaction is active", func() {
-			It("should return empty results", func() {
+			It("should return an error", func() {
 				args := [][]byte{[]byte("EXEC")}
 
 				results := handler.Apply(ctx, args)
 
-				Expect(results).To(HaveLen(0))
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(Equal(domain.ErrExecNoMulti))
+			})
+		})
+
+		Context("when queued commands reply with errors and arrays", func() {
+			It("should nest every reply in a single array", func() {
+				handler.Apply(ctx, [][]byte{[]byte("MULTI")})
+				handler.Apply(ctx, [][]byte{[]byte("GET"), []byte("missing")})
+				handler.Apply(ctx, [][]byte{[]byte("SMEMBERS"), []byte("set")})
+				handler.Apply(ctx, [][]byte{[]byte("INCR"), []byte("text")})
+
+				mockPersister.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				mockPersister.EXPECT().Get(gomock.Any(), []byte("missing")).Return(nil, errors.New("key not found"))
+				mockPersister.EXPECT().SMembers(gomock.Any(), []byte("set")).Return([][]byte{[]byte("a"), []byte("b")}, nil)
+				mockPersister.EXPECT().Incr(gomock.Any(), []byte("text")).Return(int64(0), domain.ErrInvalidInteger)
+
+				results := handler.Apply(ctx, [][]byte{[]byte("EXEC")})
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(BeNil())
+				Expect(results[0].Kind).To(Equal(domain.ReplyArray))
+				Expect(results[0].Items).To(HaveLen(3))
+				Expect(results[0].Items[0].Response).To(BeNil())
+				Expect(itemsOf(results[0].Items[1])).To(Equal([]string{"a", "b"}))
+				Expect(results[0].Items[2].Error).To(MatchError(domain.ErrInvalidInteger))
+			})
+		})
+
+		Context("when a command failed while queuing", func() {
+			It("should discard the transaction", func() {
+				handler.Apply(ctx, [][]byte{[]byte("MULTI")})
+				handler.Apply(ctx, [][]byte{[]byte("SET"), []byte("key"), []byte("value")})
+
+				results := handler.Apply(ctx, [][]byte{[]byte("NOPE")})
+				Expect(results[0].Error).To(HaveOccurred())
+
+				results = handler.Apply(ctx, [][]byte{[]byte("GET")})
+				Expect(results[0].Error).To(HaveOccurred())
+
+				results = handler.Apply(ctx, [][]byte{[]byte("EXEC")})
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(Equal(domain.ErrExecAbort))
+
+				results = handler.Apply(ctx, [][]byte{[]byte("EXEC")})
+				Expect(results[0].Error).To(Equal(domain.ErrExecNoMulti))
+			})
+		})
+
+		Context("when recording stats", func() {
+			It("should count MULTI, each queued command and EXEC once", func() {
+				mockPersister.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				mockPersister.EXPECT().SetWith(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, true, nil)
+
+				handler.Apply(ctx, [][]byte{[]byte("MULTI")})
+				handler.Apply(ctx, [][]byte{[]byte("SET"), []byte("key"), []byte("value")})
+				handler.Apply(ctx, [][]byte{[]byte("WATCH"), []byte("key")})
+				handler.Apply(ctx, [][]byte{[]byte("EXEC")})
+
+				calls := map[string]int64{}
+				rejected := map[string]int64{}
+
+				for _, command := range stats.Commands() {
+					calls[command.Name] = command.Calls
+					rejected[command.Name] = command.Rejected
+				}
+
+				Expect(calls).To(Equal(map[string]int64{"MULTI": 1, "SET": 1, "WATCH": 0, "EXEC": 1}))
+				Expect(rejected["WATCH"]).To(Equal(int64(1)))
 			})
 		})
+
+		Context("when SHUTDOWN is sent", func() {
+			It("should reject it and abort the transaction", func() {
+				handler.Apply(ctx, [][]byte{[]byte("MULTI")})
+				Expect(handler.InMulti()).To(BeTrue())
+
+				results := handler.Apply(ctx, [][]byte{[]byte("SHUTDOWN"), []byte("NOSAVE")})
+				Expect(results[0].Error).To(Equal(domain.ErrNotInMulti))
+
+				results = handler.Apply(ctx, [][]byte{[]byte("EXEC")})
+				Expect(results[0].Error).To(Equal(domain.ErrExecAbort))
+				Expect(handler.InMulti()).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("WATCH Command", func() {
+		It("should register the keys with the storage", func() {
+			mockPersister.EXPECT().
+				Watch(gomock.Any(), uint64(0), []byte("k1"), []byte("k2")).
+				Return(uint64(4), nil)
+
+			results := handler.Apply(ctx, [][]byte{[]byte("WATCH"), []byte("k1"), []byte("k2")})
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Response).To(Equal([]byte("OK")))
+
+			mockPersister.EXPECT().
+				Watch(gomock.Any(), uint64(4), []byte("k3")).
+				Return(uint64(4), nil)
+
+			handler.Apply(ctx, [][]byte{[]byte("WATCH"), []byte("k3")})
+
+			mockPersister.EXPECT().Unwatch(uint64(4))
+
+			results = handler.Apply(ctx, [][]byte{[]byte("UNWATCH")})
+			Expect(results[0].Response).To(Equal([]byte("OK")))
+		})
+
+		It("should not be allowed inside MULTI", func() {
+			handler.Apply(ctx, [][]byte{[]byte("MULTI")})
+
+			results := handler.Apply(ctx, [][]byte{[]byte("WATCH"), []byte("k1")})
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Error).To(Equal(domain.ErrWatchInMulti))
+		})
+
+		It("should abort EXEC when a watched key changed", func() {
+			mockPersister.EXPECT().Watch(gomock.Any(), uint64(0), []byte("k1")).Return(uint64(7), nil)
+			handler.Apply(ctx, [][]byte{[]byte("WATCH"), []byte("k1")})
+
+			handler.Apply(ctx, [][]byte{[]byte("MULTI")})
+			handler.Apply(ctx, [][]byte{[]byte("SET"), []byte("k1"), []byte("v1")})
+
+			mockPersister.EXPECT().
+				Atomic(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+			mockPersister.EXPECT().Modified(gomock.Any(), uint64(7)).Return(true)
+			mockPersister.EXPECT().Unwatch(uint64(7))
+
+			results := handler.Apply(ctx, [][]byte{[]byte("EXEC")})
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Error).To(BeNil())
+			Expect(results[0].Kind).To(Equal(domain.ReplyNullArray))
+		})
+
+		It("should release watches on DISCARD", func() {
+			mockPersister.EXPECT().Watch(gomock.Any(), uint64(0), []byte("k1")).Return(uint64(2), nil)
+			handler.Apply(ctx, [][]byte{[]byte("WATCH"), []byte("k1")})
+			handler.Apply(ctx, [][]byte{[]byte("MULTI")})
+
+			mockPersister.EXPECT().Unwatch(uint64(2))
+			handler.Apply(ctx, [][]byte{[]byte("DISCARD")})
+		})
 	})
 
 	Describe("DISCARD Command", func() {
@@ -468,12 +1015,12 @@ var _ = Describe("Handler Unit Tests", func() {
 		var pool *service.Pool
 
 		BeforeEach(func() {
-			pool = service.NewPool(mockPersister)
+			pool = service.NewPool(mockPersister, mockConfigurer, domain.NewStats())
 		})
 
 		Describe("NewPool", func() {
 			It("should create a new pool", func() {
-				newPool := service.NewPool(mockPersister)
+				newPool := service.NewPool(mockPersister, mockConfigurer, domain.NewStats())
 				Expect(newPool).NotTo(BeNil())
 			})
 		})
@@ -508,65 +1055,293 @@ var _ = Describe("Handler Unit Tests", func() {
 		})
 	})
 
-	Describe("Handler Clear", func() {
-		It("should reset handler state", func() {
-			multiArgs := [][]byte{[]byte("MULTI")}
-			handler.Apply(ctx, multiArgs)
+	Describe("Handler Clear", func() {
+		It("should reset handler state", func() {
+			multiArgs := [][]byte{[]byte("MULTI")}
+			handler.Apply(ctx, multiArgs)
+
+			setArgs := [][]byte{[]byte("SET"), []byte("key"), []byte("value")}
+			handler.Apply(ctx, setArgs)
+
+			handler.Clear()
+
+			execArgs := [][]byte{[]byte("EXEC")}
+			results := handler.Apply(ctx, execArgs)
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Error).To(Equal(domain.ErrExecNoMulti))
+		})
+
+		It("should clear multi state", func() {
+			multiArgs := [][]byte{[]byte("MULTI")}
+			handler.Apply(ctx, multiArgs)
+
+			handler.Clear()
+
+			execArgs := [][]byte{[]byte("EXEC")}
+			results := handler.Apply(ctx, execArgs)
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Error).To(Equal(domain.ErrExecNoMulti))
+		})
+	})
+
+	Describe("EXISTS Command", func() {
+		Context("when checking key existence", func() {
+			It("should return 1 for existing key", func() {
+				key := []byte("exists-key")
+				args := [][]byte{[]byte("EXISTS"), key}
+
+				mockPersister.EXPECT().
+					Exists(gomock.Any(), key).
+					Return(true)
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(BeNil())
+				Expect(results[0].Integer).To(Equal(int64(1)))
+			})
+
+			It("should return 0 for non-existing key", func() {
+				key := []byte("non-exists-key")
+				args := [][]byte{[]byte("EXISTS"), key}
+
+				mockPersister.EXPECT().
+					Exists(gomock.Any(), key).
+					Return(false)
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(BeNil())
+				Expect(results[0].Integer).To(Equal(int64(0)))
+			})
+		})
+	})
+
+	Describe("TYPE Command", func() {
+		Context("when checking key type", func() {
+			It("should return the stored type name", func() {
+				key := []byte("type-key")
+				args := [][]byte{[]byte("TYPE"), key}
+
+				mockPersister.EXPECT().
+					Type(gomock.Any(), key).
+					Return("list")
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(BeNil())
+				Expect(string(results[0].Response)).To(Equal("list"))
+			})
+		})
+	})
+
+	Describe("OBJECT Command", func() {
+		Context("when inspecting a key", func() {
+			It("should return the encoding", func() {
+				key := []byte("object-key")
+				args := [][]byte{[]byte("OBJECT"), []byte("encoding"), key}
+
+				mockPersister.EXPECT().
+					Encoding(gomock.Any(), key).
+					Return("embstr", nil)
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(BeNil())
+				Expect(string(results[0].Response)).To(Equal("embstr"))
+			})
+
+			It("should return the idle time", func() {
+				key := []byte("object-key")
+				args := [][]byte{[]byte("OBJECT"), []byte("IDLETIME"), key}
+
+				mockPersister.EXPECT().
+					IdleTime(gomock.Any(), key).
+					Return(int64(42), nil)
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(BeNil())
+				Expect(results[0].Integer).To(Equal(int64(42)))
+			})
+
+			It("should return the access frequency", func() {
+				key := []byte("object-key")
+				args := [][]byte{[]byte("OBJECT"), []byte("FREQ"), key}
+
+				mockPersister.EXPECT().
+					Frequency(gomock.Any(), key).
+					Return(int64(7), nil)
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(BeNil())
+				Expect(results[0].Integer).To(Equal(int64(7)))
+			})
+
+			It("should return nil for missing key", func() {
+				key := []byte("missing-key")
+				args := [][]byte{[]byte("OBJECT"), []byte("ENCODING"), key}
+
+				mockPersister.EXPECT().
+					Encoding(gomock.Any(), key).
+					Return("", errors.New("key not found"))
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(BeNil())
+				Expect(results[0].Response).To(BeNil())
+			})
+		})
+
+		Context("with invalid arguments", func() {
+			It("should reject unknown subcommands", func() {
+				args := [][]byte{[]byte("OBJECT"), []byte("NOPE"), []byte("key")}
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(HaveOccurred())
+				Expect(results[0].Error.Error()).To(ContainSubstring("unknown subcommand 'NOPE'"))
+			})
+
+			It("should reject a subcommand without key", func() {
+				args := [][]byte{[]byte("OBJECT"), []byte("ENCODING")}
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(HaveOccurred())
+				Expect(results[0].Error.Error()).To(ContainSubstring("wrong number of arguments"))
+			})
+		})
+	})
+
+	Describe("SCAN Command", func() {
+		Context("when scanning keys", func() {
+			It("should pass options to storage and return cursor with keys", func() {
+				args := [][]byte{[]byte("SCAN"), []byte("0"), []byte("MATCH"), []byte("user:*"), []byte("COUNT"), []byte("5"), []byte("TYPE"), []byte("string")}
+
+				mockPersister.EXPECT().
+					Scan(gomock.Any(), gomock.Nil(), []byte("user:*"), int64(5), "string").
+					Return([]byte("user:1"), [][]byte{[]byte("user:1")}, nil)
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(BeNil())
+				Expect(results[0].Items).To(HaveLen(2))
+				Expect(results[0].Items[0].Response).To(Equal([]byte("1")))
+				Expect(itemsOf(results[0].Items[1])).To(Equal([]string{"user:1"}))
+			})
+
+			It("should resume after the key the cursor was issued for", func() {
+				mockPersister.EXPECT().
+					Scan(gomock.Any(), gomock.Nil(), gomock.Nil(), int64(0), "").
+					Return([]byte("b"), [][]byte{[]byte("a"), []byte("b")}, nil)
+				mockPersister.EXPECT().
+					Scan(gomock.Any(), []byte("b"), gomock.Nil(), int64(0), "").
+					Return(nil, [][]byte{[]byte("c")}, nil)
+
+				results := handler.Apply(ctx, [][]byte{[]byte("SCAN"), []byte("0")})
+				Expect(results[0].Items[0].Response).To(Equal([]byte("1")))
+
+				results = handler.Apply(ctx, [][]byte{[]byte("SCAN"), []byte("1")})
+				Expect(results[0].Error).To(BeNil())
+				Expect(results[0].Items[0].Response).To(Equal([]byte("0")))
+				Expect(itemsOf(results[0].Items[1])).To(Equal([]string{"c"}))
+			})
+
+			It("should reject unknown cursors", func() {
+				results := handler.Apply(ctx, [][]byte{[]byte("SCAN"), []byte("99")})
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(Equal(domain.ErrInvalidCursor))
+			})
 
-			setArgs := [][]byte{[]byte("SET"), []byte("key"), []byte("value")}
-			handler.Apply(ctx, setArgs)
+			It("should resume a cursor on another connection of the pool", func() {
+				mockPersister.EXPECT().
+					Scan(gomock.Any(), gomock.Nil(), gomock.Nil(), int64(0), "").
+					Return([]byte("b"), [][]byte{[]byte("a"), []byte("b")}, nil)
+				mockPersister.EXPECT().
+					Scan(gomock.Any(), []byte("b"), gomock.Nil(), int64(0), "").
+					Return(nil, [][]byte{[]byte("c")}, nil)
 
-			handler.Clear()
+				pool := service.NewPool(mockPersister, mockConfigurer, domain.NewStats())
+				dbCtx := context.WithValue(ctx, domain.DB, uint8(0))
+				first := pool.Get(dbCtx)
+				second := pool.Get(dbCtx)
 
-			execArgs := [][]byte{[]byte("EXEC")}
-			results := handler.Apply(ctx, execArgs)
+				results := first.Apply(dbCtx, [][]byte{[]byte("SCAN"), []byte("0")})
+				Expect(results[0].Items[0].Response).To(Equal([]byte("1")))
 
-			Expect(results).To(HaveLen(0))
+				results = second.Apply(dbCtx, [][]byte{[]byte("SCAN"), []byte("1")})
+				Expect(results[0].Error).To(BeNil())
+				Expect(itemsOf(results[0].Items[1])).To(Equal([]string{"c"}))
+			})
 		})
 
-		It("should clear multi state", func() {
-			multiArgs := [][]byte{[]byte("MULTI")}
-			handler.Apply(ctx, multiArgs)
+		Context("with invalid arguments", func() {
+			It("should reject a non-numeric cursor", func() {
+				results := handler.Apply(ctx, [][]byte{[]byte("SCAN"), []byte("abc")})
 
-			handler.Clear()
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(Equal(domain.ErrInvalidCursor))
+			})
 
-			execArgs := [][]byte{[]byte("EXEC")}
-			results := handler.Apply(ctx, execArgs)
+			It("should reject an invalid count", func() {
+				results := handler.Apply(ctx, [][]byte{[]byte("SCAN"), []byte("0"), []byte("COUNT"), []byte("0")})
 
-			Expect(results).To(HaveLen(0))
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(Equal(domain.ErrSyntax))
+			})
+
+			It("should reject unknown options", func() {
+				results := handler.Apply(ctx, [][]byte{[]byte("SCAN"), []byte("0"), []byte("LIMIT"), []byte("1")})
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(Equal(domain.ErrSyntax))
+			})
 		})
 	})
 
-	Describe("EXISTS Command", func() {
-		Context("when checking key existence", func() {
-			It("should return 1 for existing key", func() {
-				key := []byte("exists-key")
-				args := [][]byte{[]byte("EXISTS"), key}
+	Describe("KEYS Command", func() {
+		Context("when listing keys", func() {
+			It("should return matching keys", func() {
+				args := [][]byte{[]byte("KEYS"), []byte("*")}
 
 				mockPersister.EXPECT().
-					Exists(gomock.Any(), key).
-					Return(true)
+					Keys(gomock.Any(), []byte("*")).
+					Return([][]byte{[]byte("a"), []byte("b")}, nil)
 
 				results := handler.Apply(ctx, args)
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("1"))
+				Expect(itemsOf(results[0])).To(Equal([]string{"a", "b"}))
 			})
+		})
+	})
 
-			It("should return 0 for non-existing key", func() {
-				key := []byte("non-exists-key")
-				args := [][]byte{[]byte("EXISTS"), key}
-
+	Describe("DBSIZE Command", func() {
+		Context("when counting keys", func() {
+			It("should return the number of keys", func() {
 				mockPersister.EXPECT().
-					Exists(gomock.Any(), key).
-					Return(false)
+					DBSize(gomock.Any()).
+					Return(int64(3), nil)
 
-				results := handler.Apply(ctx, args)
+				results := handler.Apply(ctx, [][]byte{[]byte("DBSIZE")})
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("0"))
+				Expect(results[0].Integer).To(Equal(int64(3)))
 			})
 		})
 	})
@@ -586,7 +1361,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("5"))
+				Expect(results[0].Integer).To(Equal(int64(5)))
 			})
 
 			It("should return 0 for non-existing list", func() {
@@ -601,7 +1376,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("0"))
+				Expect(results[0].Integer).To(Equal(int64(0)))
 			})
 		})
 	})
@@ -697,7 +1472,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("3"))
+				Expect(results[0].Integer).To(Equal(int64(3)))
 			})
 
 			It("should push multiple elements", func() {
@@ -715,7 +1490,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("5"))
+				Expect(results[0].Integer).To(Equal(int64(5)))
 			})
 		})
 	})
@@ -736,7 +1511,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("4"))
+				Expect(results[0].Integer).To(Equal(int64(4)))
 			})
 		})
 	})
@@ -817,9 +1592,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-
-				expectedRedisFormat := "*3\r\n$5\r\nelem1\r\n$5\r\nelem2\r\n$5\r\nelem3\r\n"
-				Expect(string(results[0].Response)).To(Equal(expectedRedisFormat))
+				Expect(itemsOf(results[0])).To(Equal([]string{"elem1", "elem2", "elem3"}))
 			})
 
 			It("should return empty array for non-existing key", func() {
@@ -836,7 +1609,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("*0\r\n"))
+				Expect(results[0].Items).To(BeEmpty())
 			})
 		})
 	})
@@ -883,13 +1656,13 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				mockPersister.EXPECT().
 					SAdd(gomock.Any(), key, member).
-					Return(expectedCount)
+					Return(expectedCount, nil)
 
 				results := handler.Apply(ctx, args)
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("1"))
+				Expect(results[0].Integer).To(Equal(int64(1)))
 			})
 
 			It("should add multiple members", func() {
@@ -901,13 +1674,28 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				mockPersister.EXPECT().
 					SAdd(gomock.Any(), key, member1, member2).
-					Return(expectedCount)
+					Return(expectedCount, nil)
 
 				results := handler.Apply(ctx, args)
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("2"))
+				Expect(results[0].Integer).To(Equal(int64(2)))
+			})
+
+			It("should return the storage error", func() {
+				key := []byte("set-key")
+				member := []byte("member1")
+				args := [][]byte{[]byte("SADD"), key, member}
+
+				mockPersister.EXPECT().
+					SAdd(gomock.Any(), key, member).
+					Return(int64(0), errors.New("MDB_BAD_VALSIZE"))
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(MatchError("MDB_BAD_VALSIZE"))
 			})
 		})
 	})
@@ -922,13 +1710,13 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				mockPersister.EXPECT().
 					SRem(gomock.Any(), key, member).
-					Return(expectedCount)
+					Return(expectedCount, nil)
 
 				results := handler.Apply(ctx, args)
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("1"))
+				Expect(results[0].Integer).To(Equal(int64(1)))
 			})
 
 			It("should return 0 for non-existing member", func() {
@@ -938,13 +1726,13 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				mockPersister.EXPECT().
 					SRem(gomock.Any(), key, member).
-					Return(int64(0))
+					Return(int64(0), nil)
 
 				results := handler.Apply(ctx, args)
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("0"))
+				Expect(results[0].Integer).To(Equal(int64(0)))
 			})
 		})
 	})
@@ -968,9 +1756,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-
-				expectedRedisFormat := "*3\r\n$7\r\nmember1\r\n$7\r\nmember2\r\n$7\r\nmember3\r\n"
-				Expect(string(results[0].Response)).To(Equal(expectedRedisFormat))
+				Expect(itemsOf(results[0])).To(Equal([]string{"member1", "member2", "member3"}))
 			})
 
 			It("should return empty response for non-existing set", func() {
@@ -985,7 +1771,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("*0\r\n"))
+				Expect(results[0].Items).To(BeEmpty())
 			})
 
 			It("should return error when operation fails", func() {
@@ -1020,7 +1806,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("1"))
+				Expect(results[0].Integer).To(Equal(int64(1)))
 			})
 
 			It("should return 0 for non-existing member", func() {
@@ -1036,7 +1822,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("0"))
+				Expect(results[0].Integer).To(Equal(int64(0)))
 			})
 		})
 	})
@@ -1057,7 +1843,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("1"))
+				Expect(results[0].Integer).To(Equal(int64(1)))
 			})
 
 			It("should return error for invalid score", func() {
@@ -1095,9 +1881,34 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
+				Expect(itemsOf(results[0])).To(Equal([]string{"member1", "member2", "member3"}))
+			})
+
+			It("should pair members with double scores when WITHSCORES is given", func() {
+				key := []byte("zset-key")
+				args := [][]byte{[]byte("ZRANGE"), key, []byte("0"), []byte("-1"), []byte("withscores")}
+
+				mockPersister.EXPECT().
+					ZRangeWithScores(gomock.Any(), key, int64(0), int64(-1)).
+					Return([][]byte{[]byte("a"), []byte("b")}, []float64{1.5, 2}, nil)
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results[0].Error).To(BeNil())
+				Expect(results[0].Kind).To(Equal(domain.ReplyPairs))
+				Expect(results[0].Items).To(HaveLen(4))
+				Expect(results[0].Items[0].Response).To(Equal([]byte("a")))
+				Expect(results[0].Items[1].Kind).To(Equal(domain.ReplyDouble))
+				Expect(results[0].Items[1].Double).To(Equal(1.5))
+				Expect(results[0].Items[3].Double).To(Equal(2.0))
+			})
+
+			It("should reject unknown options", func() {
+				args := [][]byte{[]byte("ZRANGE"), []byte("zset-key"), []byte("0"), []byte("-1"), []byte("REV")}
 
-				expectedRedisFormat := "*3\r\n$7\r\nmember1\r\n$7\r\nmember2\r\n$7\r\nmember3\r\n"
-				Expect(string(results[0].Response)).To(Equal(expectedRedisFormat))
+				results := handler.Apply(ctx, args)
+
+				Expect(results[0].Error).To(Equal(domain.ErrSyntax))
 			})
 
 			It("should return error for invalid start index", func() {
@@ -1130,7 +1941,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("5"))
+				Expect(results[0].Integer).To(Equal(int64(5)))
 			})
 
 			It("should return error for invalid min score", func() {
@@ -1147,6 +1958,274 @@ var _ = Describe("Handler Unit Tests", func() {
 		})
 	})
 
+	Describe("HSET Command", func() {
+		Context("when setting hash fields", func() {
+			It("should return the number of added fields", func() {
+				key := []byte("hash-key")
+				args := [][]byte{[]byte("HSET"), key, []byte("f1"), []byte("v1"), []byte("f2"), []byte("v2")}
+
+				mockPersister.EXPECT().
+					HSet(gomock.Any(), key, []byte("f1"), []byte("v1"), []byte("f2"), []byte("v2")).
+					Return(int64(2), nil)
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(BeNil())
+				Expect(results[0].Integer).To(Equal(int64(2)))
+			})
+
+			It("should reject unpaired fields", func() {
+				args := [][]byte{[]byte("HSET"), []byte("hash-key"), []byte("f1"), []byte("v1"), []byte("f2")}
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error.Error()).To(Equal("ERR wrong number of arguments for 'HSET' command"))
+			})
+
+			It("should return wrong type errors", func() {
+				key := []byte("string-key")
+				args := [][]byte{[]byte("HSET"), key, []byte("f1"), []byte("v1")}
+
+				mockPersister.EXPECT().
+					HSet(gomock.Any(), key, []byte("f1"), []byte("v1")).
+					Return(int64(0), domain.ErrWrongType)
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Error).To(Equal(domain.ErrWrongType))
+			})
+		})
+	})
+
+	Describe("HSETNX Command", func() {
+		It("should report whether the field was created", func() {
+			key := []byte("hash-key")
+			args := [][]byte{[]byte("HSETNX"), key, []byte("f1"), []byte("v1")}
+
+			mockPersister.EXPECT().
+				HSetNX(gomock.Any(), key, []byte("f1"), []byte("v1")).
+				Return(false, nil)
+
+			results := handler.Apply(ctx, args)
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Integer).To(Equal(int64(0)))
+		})
+	})
+
+	Describe("HGET Command", func() {
+		It("should return the field value", func() {
+			key := []byte("hash-key")
+			args := [][]byte{[]byte("HGET"), key, []byte("f1")}
+
+			mockPersister.EXPECT().
+				HGet(gomock.Any(), key, []byte("f1")).
+				Return([]byte("v1"), nil)
+
+			results := handler.Apply(ctx, args)
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Error).To(BeNil())
+			Expect(string(results[0].Response)).To(Equal("v1"))
+		})
+
+		It("should return nil for missing fields", func() {
+			key := []byte("hash-key")
+			args := [][]byte{[]byte("HGET"), key, []byte("missing")}
+
+			mockPersister.EXPECT().
+				HGet(gomock.Any(), key, []byte("missing")).
+				Return(nil, errors.New("key not found"))
+
+			results := handler.Apply(ctx, args)
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Error).To(BeNil())
+			Expect(results[0].Response).To(BeNil())
+		})
+	})
+
+	Describe("HMGET Command", func() {
+		It("should return null entries for missing fields", func() {
+			key := []byte("hash-key")
+			args := [][]byte{[]byte("HMGET"), key, []byte("f1"), []byte("missing")}
+
+			mockPersister.EXPECT().
+				HMGet(gomock.Any(), key, []byte("f1"), []byte("missing")).
+				Return([][]byte{[]byte("v1"), nil}, nil)
+
+			results := handler.Apply(ctx, args)
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Items).To(HaveLen(2))
+			Expect(results[0].Items[0].Response).To(Equal([]byte("v1")))
+			Expect(results[0].Items[1].Response).To(BeNil())
+		})
+	})
+
+	Describe("HDEL Command", func() {
+		It("should return the number of removed fields", func() {
+			key := []byte("hash-key")
+			args := [][]byte{[]byte("HDEL"), key, []byte("f1"), []byte("f2")}
+
+			mockPersister.EXPECT().
+				HDel(gomock.Any(), key, []byte("f1"), []byte("f2")).
+				Return(int64(1), nil)
+
+			results := handler.Apply(ctx, args)
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Integer).To(Equal(int64(1)))
+		})
+	})
+
+	Describe("HEXISTS, HLEN and HSTRLEN Commands", func() {
+		It("should report field presence", func() {
+			key := []byte("hash-key")
+
+			mockPersister.EXPECT().HExists(gomock.Any(), key, []byte("f1")).Return(true, nil)
+			mockPersister.EXPECT().HLen(gomock.Any(), key).Return(int64(3), nil)
+			mockPersister.EXPECT().HStrLen(gomock.Any(), key, []byte("f1")).Return(int64(5), nil)
+
+			Expect(handler.Apply(ctx, [][]byte{[]byte("HEXISTS"), key, []byte("f1")})[0].Integer).To(Equal(int64(1)))
+			Expect(handler.Apply(ctx, [][]byte{[]byte("HLEN"), key})[0].Integer).To(Equal(int64(3)))
+			Expect(handler.Apply(ctx, [][]byte{[]byte("HSTRLEN"), key, []byte("f1")})[0].Integer).To(Equal(int64(5)))
+		})
+	})
+
+	Describe("HKEYS, HVALS and HGETALL Commands", func() {
+		It("should return arrays", func() {
+			key := []byte("hash-key")
+
+			mockPersister.EXPECT().HKeys(gomock.Any(), key).Return([][]byte{[]byte("f1")}, nil)
+			mockPersister.EXPECT().HVals(gomock.Any(), key).Return([][]byte{}, nil)
+			mockPersister.EXPECT().HGetAll(gomock.Any(), key).Return([][]byte{[]byte("f1"), []byte("v1")}, nil)
+
+			Expect(itemsOf(handler.Apply(ctx, [][]byte{[]byte("HKEYS"), key})[0])).To(Equal([]string{"f1"}))
+			Expect(handler.Apply(ctx, [][]byte{[]byte("HVALS"), key})[0].Items).To(BeEmpty())
+			Expect(itemsOf(handler.Apply(ctx, [][]byte{[]byte("HGETALL"), key})[0])).To(Equal([]string{"f1", "v1"}))
+		})
+	})
+
+	Describe("HINCRBY Command", func() {
+		It("should return the incremented value", func() {
+			key := []byte("hash-key")
+			args := [][]byte{[]byte("HINCRBY"), key, []byte("f1"), []byte("-3")}
+
+			mockPersister.EXPECT().
+				HIncrBy(gomock.Any(), key, []byte("f1"), int64(-3)).
+				Return(int64(7), nil)
+
+			results := handler.Apply(ctx, args)
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Integer).To(Equal(int64(7)))
+		})
+
+		It("should reject a non-integer increment", func() {
+			args := [][]byte{[]byte("HINCRBY"), []byte("hash-key"), []byte("f1"), []byte("1.5")}
+
+			results := handler.Apply(ctx, args)
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Error).To(Equal(domain.ErrInvalidInteger))
+		})
+	})
+
+	Describe("HINCRBYFLOAT Command", func() {
+		It("should return the incremented value", func() {
+			key := []byte("hash-key")
+			args := [][]byte{[]byte("HINCRBYFLOAT"), key, []byte("f1"), []byte("0.5")}
+
+			mockPersister.EXPECT().
+				HIncrByFloat(gomock.Any(), key, []byte("f1"), 0.5).
+				Return([]byte("10.75"), nil)
+
+			results := handler.Apply(ctx, args)
+
+			Expect(results).To(HaveLen(1))
+			Expect(string(results[0].Response)).To(Equal("10.75"))
+		})
+
+		It("should reject a non-float increment", func() {
+			args := [][]byte{[]byte("HINCRBYFLOAT"), []byte("hash-key"), []byte("f1"), []byte("abc")}
+
+			results := handler.Apply(ctx, args)
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Error).To(Equal(domain.ErrInvalidFloat))
+		})
+	})
+
+	Describe("INCRBYFLOAT Command", func() {
+		It("should return the incremented value", func() {
+			key := []byte("counter")
+			args := [][]byte{[]byte("INCRBYFLOAT"), key, []byte("-2.5e1")}
+
+			mockPersister.EXPECT().
+				IncrByFloat(gomock.Any(), key, -25.0).
+				Return([]byte("1000000000000000000000"), nil)
+
+			results := handler.Apply(ctx, args)
+
+			Expect(results).To(HaveLen(1))
+			Expect(string(results[0].Response)).To(Equal("1000000000000000000000"))
+		})
+
+		It("should reject non-finite increments", func() {
+			for _, delta := range []string{"abc", "nan", "inf", "-inf"} {
+				args := [][]byte{[]byte("INCRBYFLOAT"), []byte("counter"), []byte(delta)}
+
+				results := handler.Apply(ctx, args)
+
+				Expect(results[0].Error).To(Equal(domain.ErrInvalidFloat))
+			}
+		})
+	})
+
+	Describe("HSCAN Command", func() {
+		It("should pass cursor and options through", func() {
+			key := []byte("hash-key")
+			args := [][]byte{[]byte("HSCAN"), key, []byte("0"), []byte("MATCH"), []byte("f*"), []byte("COUNT"), []byte("5")}
+
+			mockPersister.EXPECT().
+				HScan(gomock.Any(), key, gomock.Nil(), []byte("f*"), int64(5)).
+				Return([]byte("f1"), [][]byte{[]byte("f1"), []byte("v1")}, nil)
+
+			results := handler.Apply(ctx, args)
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Error).To(BeNil())
+			Expect(results[0].Items).To(HaveLen(2))
+			Expect(results[0].Items[0].Response).To(Equal([]byte("1")))
+			Expect(itemsOf(results[0].Items[1])).To(Equal([]string{"f1", "v1"}))
+		})
+
+		It("should reject cursors issued for another key", func() {
+			mockPersister.EXPECT().
+				HScan(gomock.Any(), []byte("hash"), gomock.Nil(), gomock.Nil(), int64(0)).
+				Return([]byte("f1"), [][]byte{[]byte("f1"), []byte("v1")}, nil)
+
+			results := handler.Apply(ctx, [][]byte{[]byte("HSCAN"), []byte("hash"), []byte("0")})
+			Expect(results[0].Items[0].Response).To(Equal([]byte("1")))
+
+			results = handler.Apply(ctx, [][]byte{[]byte("HSCAN"), []byte("other"), []byte("1")})
+			Expect(results[0].Error).To(Equal(domain.ErrInvalidCursor))
+		})
+
+		It("should reject the TYPE option", func() {
+			args := [][]byte{[]byte("HSCAN"), []byte("hash-key"), []byte("0"), []byte("TYPE"), []byte("string")}
+
+			results := handler.Apply(ctx, args)
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Error).To(Equal(domain.ErrSyntax))
+		})
+	})
+
 	Describe("INCR Command", func() {
 		Context("when incrementing key", func() {
 			It("should return incremented value", func() {
@@ -1161,7 +2240,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("1"))
+				Expect(results[0].Integer).To(Equal(int64(1)))
 			})
 
 			It("should return error when operation fails", func() {
@@ -1196,7 +2275,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("15"))
+				Expect(results[0].Integer).To(Equal(int64(15)))
 			})
 
 			It("should return error for invalid increment", func() {
@@ -1209,6 +2288,16 @@ var _ = Describe("Handler Unit Tests", func() {
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(Equal(domain.ErrInvalidInteger))
 			})
+
+			It("should reject increments that are not canonical integers", func() {
+				for _, increment := range []string{" 5", "5 ", "+5", "05", "-0", "9223372036854775808", "000000000000000000001"} {
+					args := [][]byte{[]byte("INCRBY"), []byte("counter"), []byte(increment)}
+
+					results := handler.Apply(ctx, args)
+
+					Expect(results[0].Error).To(Equal(domain.ErrInvalidInteger))
+				}
+			})
 		})
 	})
 
@@ -1226,7 +2315,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("-1"))
+				Expect(results[0].Integer).To(Equal(int64(-1)))
 			})
 
 			It("should return error when operation fails", func() {
@@ -1261,7 +2350,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("7"))
+				Expect(results[0].Integer).To(Equal(int64(7)))
 			})
 
 			It("should return error for invalid decrement", func() {
@@ -1277,6 +2366,97 @@ var _ = Describe("Handler Unit Tests", func() {
 		})
 	})
 
+	Describe("CONFIG Command", func() {
+		It("should return the matching settings as pairs", func() {
+			pairs := [][]byte{[]byte("databases"), []byte("16")}
+			mockConfigurer.EXPECT().Get([]byte("data*")).Return(pairs)
+
+			results := handler.Apply(ctx, [][]byte{[]byte("CONFIG"), []byte("get"), []byte("data*")})
+
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Kind).To(Equal(domain.ReplyMap))
+			Expect(itemsOf(results[0])).To(Equal([]string{"databases", "16"}))
+		})
+
+		It("should pass every directive and value to SET", func() {
+			mockConfigurer.EXPECT().Set([]byte("max-clients"), []byte("10")).Return(nil)
+
+			results := handler.Apply(ctx, [][]byte{[]byte("CONFIG"), []byte("SET"), []byte("max-clients"), []byte("10")})
+
+			Expect(results[0].Error).To(BeNil())
+			Expect(results[0].Response).To(Equal([]byte("OK")))
+		})
+
+		It("should report errors from REWRITE", func() {
+			mockConfigurer.EXPECT().Rewrite().Return(domain.ErrNoConfigFile)
+
+			results := handler.Apply(ctx, [][]byte{[]byte("CONFIG"), []byte("REWRITE")})
+
+			Expect(results[0].Error).To(Equal(domain.ErrNoConfigFile))
+		})
+
+		It("should reject a directive without a value", func() {
+			results := handler.Apply(ctx, [][]byte{[]byte("CONFIG"), []byte("SET"), []byte("max-clients")})
+
+			Expect(results[0].Error).To(MatchError("ERR wrong number of arguments for 'config|SET' command"))
+		})
+
+		It("should reject unknown subcommands", func() {
+			results := handler.Apply(ctx, [][]byte{[]byte("CONFIG"), []byte("RESET")})
+
+			Expect(results[0].Error).To(MatchError("ERR unknown subcommand 'RESET'. Try CONFIG HELP."))
+		})
+	})
+
+	Describe("INFO Command", func() {
+		BeforeEach(func() {
+			mockConfigurer.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return([][]byte{[]byte("address"), []byte("0.0.0.0:6379"), []byte("max-clients"), []byte("10")}).
+				AnyTimes()
+		})
+
+		It("should render the requested sections only", func() {
+			results := handler.Apply(ctx, [][]byte{[]byte("INFO"), []byte("Clients")})
+
+			Expect(results[0].Error).To(BeNil())
+			Expect(string(results[0].Response)).To(Equal("# Clients\r\nconnected_clients:0\r\nmaxclients:10\r\nblocked_clients:0\r\n"))
+		})
+
+		It("should include storage sections and command statistics", func() {
+			mockPersister.EXPECT().Get(gomock.Any(), []byte("key")).Return([]byte("value"), nil)
+			mockPersister.EXPECT().Info(gomock.Any()).Return(domain.StorageInfo{
+				MapSize:    1 << 20,
+				LastSync:   time.Unix(1700000000, 0),
+				SyncFailed: true,
+				ExpireRuns: 4,
+				ExpireTime: 3 * time.Millisecond,
+				Keyspaces:  []domain.KeyspaceInfo{{DB: 2, Keys: 5, Expires: 1}},
+			}, nil)
+
+			handler.Apply(ctx, [][]byte{[]byte("GET"), []byte("key")})
+			handler.Apply(ctx, [][]byte{[]byte("GET")})
+			results := handler.Apply(ctx, [][]byte{[]byte("INFO"), []byte("everything")})
+			report := string(results[0].Response)
+
+			Expect(results[0].Error).To(BeNil())
+			Expect(report).To(HavePrefix("# Server\r\nredis_version:7.2.0\r\n"))
+			Expect(report).To(ContainSubstring("lmdb_map_size_human:1.00M\r\n"))
+			Expect(report).To(ContainSubstring("last_sync_time:1700000000\r\nlast_sync_status:err\r\n"))
+			Expect(report).To(ContainSubstring("total_commands_processed:1\r\n"))
+			Expect(report).To(ContainSubstring("expire_cycles:4\r\nexpire_cycle_cpu_milliseconds:3\r\n"))
+			Expect(report).To(MatchRegexp(`cmdstat_get:calls=1,usec=\d+,usec_per_call=[\d.]+,rejected_calls=1,failed_calls=0`))
+			Expect(report).To(HaveSuffix("# Keyspace\r\ndb2:keys=5,expires=1,avg_ttl=0\r\n"))
+		})
+
+		It("should report storage failures", func() {
+			mockPersister.EXPECT().Info(gomock.Any()).Return(domain.StorageInfo{}, errors.New("env closed"))
+
+			results := handler.Apply(ctx, [][]byte{[]byte("INFO"), []byte("keyspace")})
+
+			Expect(results[0].Error).To(MatchError("env closed"))
+		})
+	})
+
 	Describe("APPEND Command", func() {
 		Context("when appending to key", func() {
 			It("should return new length", func() {
@@ -1292,7 +2472,7 @@ var _ = Describe("Handler Unit Tests", func() {
 
 				Expect(results).To(HaveLen(1))
 				Expect(results[0].Error).To(BeNil())
-				Expect(string(results[0].Response)).To(Equal("10"))
+				Expect(results[0].Integer).To(Equal(int64(10)))
 			})
 		})
 	})
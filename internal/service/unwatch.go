@@ -0,0 +1,8 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) unwatch(_ Args) *Result {
+	handler.release()
+	return domain.NewResult().SetOK()
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"math"
 	"strconv"
 	"strings"
 
@@ -29,6 +30,10 @@ func newInvalidArgsError(commandName string) error {
 	return errors.New("ERR wrong number of arguments for '" + commandName + "' command")
 }
 
+func newInvalidExpireError(commandName string) error {
+	return errors.New("ERR invalid expire time in '" + commandName + "' command")
+}
+
 func normalizeCommandName(name string) string {
 	return strings.ToUpper(strings.TrimSpace(name))
 }
@@ -52,10 +57,8 @@ func isValid(validation *domain.Validation, cmdName string, argCount int) error
 func processIntegerModification(args Args, storageMethod func(context.Context, []byte, int64) (int64, error), handler *Handler) *Result {
 	res := domain.NewResult()
 	key := args[domain.FirstArg]
-	valueStr := string(args[domain.SecondArg])
-
-	value, err := strconv.ParseInt(valueStr, 10, 64)
-	if hasError(err) {
+	value, valid := domain.ParseInteger(args[domain.SecondArg])
+	if !valid {
 		res.Error = domain.ErrInvalidInteger
 		return res
 	}
@@ -66,35 +69,35 @@ func processIntegerModification(args Args, storageMethod func(context.Context, [
 		return res
 	}
 
-	res.Response = formatInt64(result)
+	res.SetInteger(result)
 	return res
 }
 
-func formatInt64(value int64) []byte {
-	return []byte(strconv.FormatInt(value, 10))
+func setScan(res *Result, cursor uint64, items [][]byte) *Result {
+	next := []byte(strconv.FormatUint(cursor, 10))
+	return res.SetItems(domain.ReplyArray, Results{{Response: next}, domain.NewResult().SetArray(items)})
 }
 
-func formatUint32(value uint32) []byte {
-	return []byte(strconv.FormatUint(uint64(value), 10))
-}
+func processHashCollection(args Args, storageMethod func(context.Context, []byte) ([][]byte, error), handler *Handler) *Result {
+	res := domain.NewResult()
+	key := args[domain.FirstArg]
 
-func formatBool(value bool) []byte {
-	if value {
-		return []byte("1")
+	items, err := storageMethod(handler.context, key)
+	if hasError(err) {
+		res.Error = err
+		return res
 	}
-	return []byte("0")
+
+	res.SetArray(items)
+	return res
 }
 
-func formatArray(items [][]byte) []byte {
-	if len(items) == 0 {
-		return []byte("*0\r\n")
-	}
+func parseIncrement(arg []byte) (float64, error) {
+	delta, err := strconv.ParseFloat(string(arg), 64)
 
-	result := []byte("*" + strconv.Itoa(len(items)) + "\r\n")
-	for _, item := range items {
-		result = append(result, []byte("$"+strconv.Itoa(len(item))+"\r\n")...)
-		result = append(result, item...)
-		result = append(result, []byte("\r\n")...)
+	if hasError(err) || math.IsNaN(delta) || math.IsInf(delta, 0) {
+		return 0, domain.ErrInvalidFloat
 	}
-	return result
+
+	return delta, nil
 }
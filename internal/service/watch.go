@@ -0,0 +1,22 @@
+package service
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+func (handler *Handler) watch(args Args) *Result {
+	res := domain.NewResult()
+	keys := args[domain.FirstArg:]
+
+	watching, err := handler.storage.Watch(handler.context, handler.watching, keys...)
+
+	if isContextCanceled(err) {
+		return res.SetCanceled()
+	}
+
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	handler.watching = watching
+	return res.SetOK()
+}
@@ -19,6 +19,6 @@ func (handler *Handler) zadd(args Args) *Result {
 	}
 
 	count := handler.storage.ZAdd(handler.context, key, score, member)
-	res.Response = formatInt64(count)
+	res.SetInteger(count)
 	return res
 }
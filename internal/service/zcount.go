@@ -25,6 +25,6 @@ func (handler *Handler) zcount(args Args) *Result {
 	}
 
 	count := handler.storage.ZCount(handler.context, key, min, max)
-	res.Response = formatInt64(count)
+	res.SetInteger(count)
 	return res
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
+const withScoresArg = 4
+
 func (handler *Handler) zrange(args Args) *Result {
 	res := domain.NewResult()
 	key := args[domain.FirstArg]
@@ -24,12 +26,37 @@ func (handler *Handler) zrange(args Args) *Result {
 		return res
 	}
 
+	if len(args) > withScoresArg {
+		if normalizeCommandName(string(args[withScoresArg])) != "WITHSCORES" {
+			res.Error = domain.ErrSyntax
+			return res
+		}
+
+		return handler.zrangeWithScores(res, key, start, stop)
+	}
+
 	members, err := handler.storage.ZRange(handler.context, key, start, stop)
 	if hasError(err) {
 		res.Error = err
 		return res
 	}
 
-	res.Response = formatArray(members)
+	res.SetArray(members)
 	return res
 }
+
+func (handler *Handler) zrangeWithScores(res *Result, key []byte, start, stop int64) *Result {
+	members, scores, err := handler.storage.ZRangeWithScores(handler.context, key, start, stop)
+	if hasError(err) {
+		res.Error = err
+		return res
+	}
+
+	items := make(Results, 0, len(members)*2)
+
+	for index, member := range members {
+		items = append(items, &Result{Response: member}, domain.NewResult().SetDouble(scores[index]))
+	}
+
+	return res.SetItems(domain.ReplyPairs, items)
+}
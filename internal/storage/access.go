@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"hash/maphash"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	lfuInitValue  = 5
+	lfuMaxValue   = 255
+	lfuLogFactor  = 10
+	lfuDecayRange = time.Minute
+	trackerSlots  = 1 << 16
+	emptySlot     = 0
+)
+
+type (
+	usage struct {
+		tag      atomic.Uint64
+		db       atomic.Uint32
+		accessed atomic.Int64
+		counter  atomic.Uint32
+	}
+
+	// tracker keeps access data in a fixed table; keys sharing a slot evict each other.
+	tracker struct {
+		started int64
+		seed    maphash.Seed
+		slots   []usage
+	}
+)
+
+func newTracker() *tracker {
+	return &tracker{
+		started: time.Now().UnixMilli(),
+		seed:    maphash.MakeSeed(),
+		slots:   make([]usage, trackerSlots),
+	}
+}
+
+func (track *tracker) slot(db uint8, key []byte) (*usage, uint64) {
+	tag := maphash.Bytes(track.seed, key) ^ uint64(db)<<56 | 1
+	return &track.slots[tag%trackerSlots], tag
+}
+
+func (track *tracker) lookup(db uint8, key []byte) (*usage, bool) {
+	item, tag := track.slot(db, key)
+	return item, item.tag.Load() == tag
+}
+
+func (track *tracker) touch(db uint8, key []byte) {
+	now := time.Now().UnixMilli()
+	item, tag := track.slot(db, key)
+
+	if item.tag.Load() == tag {
+		counter := decayCounter(item.counter.Load(), item.accessed.Load(), now)
+		item.counter.Store(incrementCounter(counter))
+		item.accessed.Store(now)
+		return
+	}
+
+	item.counter.Store(lfuInitValue)
+	item.accessed.Store(now)
+	item.db.Store(uint32(db))
+	item.tag.Store(tag)
+}
+
+func (track *tracker) forget(db uint8, key []byte) {
+	item, tag := track.slot(db, key)
+	item.tag.CompareAndSwap(tag, emptySlot)
+}
+
+func (track *tracker) forgetAll(db uint8) {
+	for index := range track.slots {
+		item := &track.slots[index]
+
+		if item.db.Load() == uint32(db) {
+			item.tag.Store(emptySlot)
+		}
+	}
+}
+
+func (track *tracker) idle(db uint8, key []byte) int64 {
+	accessed := track.started
+
+	if item, found := track.lookup(db, key); found {
+		accessed = item.accessed.Load()
+	}
+
+	return (time.Now().UnixMilli() - accessed) / int64(time.Second/time.Millisecond)
+}
+
+func (track *tracker) frequency(db uint8, key []byte) int64 {
+	item, found := track.lookup(db, key)
+
+	if !found {
+		return emptyCount
+	}
+
+	return int64(decayCounter(item.counter.Load(), item.accessed.Load(), time.Now().UnixMilli()))
+}
+
+func decayCounter(counter uint32, accessed, now int64) uint32 {
+	periods := uint32((now - accessed) / lfuDecayRange.Milliseconds())
+
+	if periods >= counter {
+		return 0
+	}
+
+	return counter - periods
+}
+
+func incrementCounter(counter uint32) uint32 {
+	if counter >= lfuMaxValue {
+		return lfuMaxValue
+	}
+
+	base := float64(0)
+
+	if counter > lfuInitValue {
+		base = float64(counter - lfuInitValue)
+	}
+
+	if rand.Float64() < 1.0/(base*lfuLogFactor+1) {
+		return counter + 1
+	}
+
+	return counter
+}
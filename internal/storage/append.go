@@ -22,14 +22,12 @@ func (client *Client) Append(ctx context.Context, key, value []byte) int64 {
 
 	var newLength int64
 
-	err = client.env.Update(func(txn *lmdb.Txn) error {
-		data, txnErr := txn.Get(db, key)
+	err = client.update(ctx, func(txn *lmdb.Txn) error {
+		data, txnErr := db.load(txn, key, kindString)
 
 		if isNotFound(txnErr) {
-			newData := make([]byte, len(value))
-			copy(newData, value)
-			newLength = int64(len(newData))
-			return txn.Put(db, key, newData, noFlags)
+			newLength = int64(len(value))
+			return db.put(txn, key, kindString, encodingRaw, value)
 		}
 
 		if hasError(txnErr) {
@@ -40,7 +38,7 @@ func (client *Client) Append(ctx context.Context, key, value []byte) int64 {
 		copy(newData, data)
 		copy(newData[len(data):], value)
 		newLength = int64(len(newData))
-		return txn.Put(db, key, newData, noFlags)
+		return db.put(txn, key, kindString, encodingRaw, newData)
 	})
 
 	if hasError(err) {
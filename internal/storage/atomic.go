@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+type (
+	transactionKey struct{}
+
+	transaction struct {
+		txn    *lmdb.Txn
+		spaces map[uint8]*keyspace
+		err    error
+	}
+)
+
+func (client *Client) Atomic(ctx context.Context, fn func(context.Context) error) error {
+	if hasError(ctxFlush(ctx)) {
+		return ctx.Err()
+	}
+
+	if _, active := transactionOf(ctx); active {
+		return fn(ctx)
+	}
+
+	tx := &transaction{spaces: make(map[uint8]*keyspace)}
+
+	err := client.env.Update(func(txn *lmdb.Txn) error {
+		tx.txn = txn
+
+		if err := fn(context.WithValue(ctx, transactionKey{}, tx)); hasError(err) {
+			return err
+		}
+
+		return tx.err
+	})
+
+	if hasError(err) {
+		return err
+	}
+
+	client.mtx.Lock()
+	defer client.mtx.Unlock()
+
+	for db, space := range tx.spaces {
+		if _, hasDB := client.dbs[db]; !hasDB {
+			client.dbs[db] = space
+		}
+	}
+
+	return nil
+}
+
+func transactionOf(ctx context.Context) (*transaction, bool) {
+	tx, active := ctx.Value(transactionKey{}).(*transaction)
+	return tx, active
+}
+
+func (client *Client) update(ctx context.Context, fn lmdb.TxnOp) error {
+	tx, active := transactionOf(ctx)
+
+	if !active {
+		return client.commit(fn)
+	}
+
+	return tx.run(fn)
+}
+
+func (client *Client) view(ctx context.Context, fn lmdb.TxnOp) error {
+	tx, active := transactionOf(ctx)
+
+	if !active {
+		return client.env.View(fn)
+	}
+
+	return tx.run(fn)
+}
+
+func (tx *transaction) run(fn lmdb.TxnOp) error {
+	err := fn(tx.txn)
+
+	if isOpError(err) && noError(tx.err) {
+		tx.err = err
+	}
+
+	return err
+}
+
+func (tx *transaction) keyspace(client *Client, db uint8) (*keyspace, error) {
+	if space, opened := tx.spaces[db]; opened {
+		return space, nil
+	}
+
+	space, err := client.openSpace(tx.txn, db)
+	if hasError(err) {
+		return nil, err
+	}
+
+	tx.spaces[db] = space
+	return space, nil
+}
@@ -2,7 +2,9 @@ package storage_test
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -62,6 +64,96 @@ var _ = Describe("Basic Storage Commands", func() {
 		})
 	})
 
+	Describe("Type", func() {
+		It("should return none for non-existent key", func() {
+			Expect(client.Type(ctx, []byte("missing"))).To(Equal("none"))
+		})
+
+		It("should return the name of each stored kind", func() {
+			Expect(client.Set(ctx, []byte("str"), []byte("value"))).To(Succeed())
+			client.LPush(ctx, []byte("list"), []byte("a"))
+			client.SAdd(ctx, []byte("set"), []byte("a"))
+			client.ZAdd(ctx, []byte("zset"), 1, []byte("a"))
+
+			Expect(client.Type(ctx, []byte("str"))).To(Equal("string"))
+			Expect(client.Type(ctx, []byte("list"))).To(Equal("list"))
+			Expect(client.Type(ctx, []byte("set"))).To(Equal("set"))
+			Expect(client.Type(ctx, []byte("zset"))).To(Equal("zset"))
+		})
+	})
+
+	Describe("Object", func() {
+		It("should report string encodings", func() {
+			Expect(client.Set(ctx, []byte("int"), []byte("12345"))).To(Succeed())
+			Expect(client.Set(ctx, []byte("short"), []byte("hello"))).To(Succeed())
+			Expect(client.Set(ctx, []byte("long"), make([]byte, 64))).To(Succeed())
+
+			encoding, err := client.Encoding(ctx, []byte("int"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoding).To(Equal("int"))
+
+			encoding, err = client.Encoding(ctx, []byte("short"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoding).To(Equal("embstr"))
+
+			encoding, err = client.Encoding(ctx, []byte("long"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoding).To(Equal("raw"))
+		})
+
+		It("should report collection encodings", func() {
+			client.RPush(ctx, []byte("list"), []byte("a"), []byte("b"))
+
+			encoding, err := client.Encoding(ctx, []byte("list"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoding).To(Equal("listpack"))
+		})
+
+		It("should track access frequency and idle time", func() {
+			Expect(client.Set(ctx, []byte("hot"), []byte("value"))).To(Succeed())
+
+			for range 100 {
+				_, err := client.Get(ctx, []byte("hot"))
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			frequency, err := client.Frequency(ctx, []byte("hot"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(frequency).To(BeNumerically(">", 5))
+
+			idle, err := client.IdleTime(ctx, []byte("hot"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(idle).To(BeZero())
+		})
+
+		It("should reset access tracking when a key is recreated", func() {
+			Expect(client.Set(ctx, []byte("hot"), []byte("value"))).To(Succeed())
+
+			for range 100 {
+				_, err := client.Get(ctx, []byte("hot"))
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(client.Del(ctx, []byte("hot"))).To(Equal(uint32(1)))
+			Expect(client.Set(ctx, []byte("hot"), []byte("value"))).To(Succeed())
+
+			frequency, err := client.Frequency(ctx, []byte("hot"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(frequency).To(Equal(int64(5)))
+		})
+
+		It("should return ErrKeyNotFound for missing key", func() {
+			_, err := client.Encoding(ctx, []byte("missing"))
+			Expect(err).To(Equal(storage.ErrKeyNotFound))
+
+			_, err = client.IdleTime(ctx, []byte("missing"))
+			Expect(err).To(Equal(storage.ErrKeyNotFound))
+
+			_, err = client.Frequency(ctx, []byte("missing"))
+			Expect(err).To(Equal(storage.ErrKeyNotFound))
+		})
+	})
+
 	Describe("Incr", func() {
 		It("should increment non-existent key to 1", func() {
 			result, err := client.Incr(ctx, []byte("counter"))
@@ -141,6 +233,50 @@ var _ = Describe("Basic Storage Commands", func() {
 		})
 	})
 
+	Describe("IncrByFloat", func() {
+		It("should start from zero and store the sum without trailing zeros", func() {
+			Expect(client.IncrByFloat(ctx, []byte("counter"), 10.5)).To(Equal([]byte("10.5")))
+			Expect(client.IncrByFloat(ctx, []byte("counter"), 0.1)).To(Equal([]byte("10.6")))
+			Expect(client.Get(ctx, []byte("counter"))).To(Equal([]byte("10.6")))
+
+			Expect(client.IncrByFloat(ctx, []byte("counter"), -5.6)).To(Equal([]byte("5")))
+			Expect(client.Get(ctx, []byte("counter"))).To(Equal([]byte("5")))
+		})
+
+		It("should add in long double precision as Redis does", func() {
+			Expect(client.Set(ctx, []byte("counter"), []byte("0.2"))).To(Succeed())
+
+			Expect(client.IncrByFloat(ctx, []byte("counter"), 0.1)).To(Equal([]byte("0.3")))
+			Expect(client.Get(ctx, []byte("counter"))).To(Equal([]byte("0.3")))
+		})
+
+		It("should write large values without an exponent and keep the TTL", func() {
+			Expect(client.Set(ctx, []byte("counter"), []byte("5.0e3"))).To(Succeed())
+			deadline := time.Now().Add(time.Hour).UnixMilli()
+			Expect(client.ExpireAt(ctx, []byte("counter"), deadline, domain.ExpireAlways)).To(BeTrue())
+
+			Expect(client.IncrByFloat(ctx, []byte("counter"), 2.0e20)).To(Equal([]byte("200000000000000004992")))
+			Expect(client.Get(ctx, []byte("counter"))).To(Equal([]byte("200000000000000004992")))
+			Expect(client.ExpireTime(ctx, []byte("counter"))).To(Equal(deadline))
+		})
+
+		It("should refuse values that are not finite floats", func() {
+			Expect(client.Set(ctx, []byte("text"), []byte("abc"))).To(Succeed())
+			Expect(client.Set(ctx, []byte("inf"), []byte("inf"))).To(Succeed())
+			Expect(client.Set(ctx, []byte("max"), []byte("1.7e308"))).To(Succeed())
+
+			_, err := client.IncrByFloat(ctx, []byte("text"), 1)
+			Expect(err).To(MatchError(domain.ErrInvalidFloat))
+
+			_, err = client.IncrByFloat(ctx, []byte("inf"), 1)
+			Expect(err).To(MatchError(domain.ErrInvalidFloat))
+
+			_, err = client.IncrByFloat(ctx, []byte("max"), 1.7e308)
+			Expect(err).To(MatchError(storage.ErrNotFinite))
+			Expect(client.Get(ctx, []byte("max"))).To(Equal([]byte("1.7e308")))
+		})
+	})
+
 	Describe("Decr", func() {
 		It("should decrement non-existent key to -1", func() {
 			result, err := client.Decr(ctx, []byte("counter"))
@@ -233,6 +369,29 @@ var _ = Describe("Basic Storage Commands", func() {
 		})
 	})
 
+	Describe("Info", func() {
+		It("should report the LMDB environment and populated keyspaces", func() {
+			other := context.WithValue(context.Background(), domain.DB, uint8(3))
+
+			Expect(client.Set(ctx, []byte("key1"), []byte("value1"))).To(Succeed())
+			Expect(client.Set(ctx, []byte("key2"), []byte("value2"))).To(Succeed())
+			Expect(client.Set(other, []byte("key3"), []byte("value3"))).To(Succeed())
+			client.Expire(other, []byte("key3"), 100)
+
+			info, err := client.Info(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.MapSize).To(Equal(int64(storage.DefaultMapSize)))
+			Expect(info.PageSize).To(BeNumerically(">", 0))
+			Expect(info.UsedBytes).To(BeNumerically(">=", info.PageSize))
+			Expect(info.MaxReaders).To(Equal(int64(storage.DefaultMaxReaders)))
+			Expect(info.Keyspaces).To(Equal([]domain.KeyspaceInfo{
+				{DB: 0, Keys: 2, Expires: 0},
+				{DB: 3, Keys: 1, Expires: 1},
+			}))
+		})
+	})
+
 	Describe("FlushAll", func() {
 		It("should clear all keys in database", func() {
 			// Set some keys
@@ -263,4 +422,302 @@ var _ = Describe("Basic Storage Commands", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Describe("Scan", func() {
+		seed := func(count int) {
+			for i := range count {
+				Expect(client.Set(ctx, fmt.Appendf(nil, "user:%03d", i), []byte("v"))).To(Succeed())
+			}
+		}
+
+		scanAll := func(pattern []byte, count int64, kind string) [][]byte {
+			var after []byte
+			keys := make([][]byte, 0)
+
+			for {
+				next, page, err := client.Scan(ctx, after, pattern, count, kind)
+				Expect(err).NotTo(HaveOccurred())
+				keys = append(keys, page...)
+
+				if next == nil {
+					return keys
+				}
+
+				after = next
+			}
+		}
+
+		It("should return no keys for empty database", func() {
+			next, keys, err := client.Scan(ctx, nil, nil, 10, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(BeNil())
+			Expect(keys).To(BeEmpty())
+		})
+
+		It("should page through every key exactly once", func() {
+			seed(25)
+
+			next, keys, err := client.Scan(ctx, nil, nil, 10, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(Equal(keys[9]))
+			Expect(keys).To(HaveLen(10))
+
+			Expect(scanAll(nil, 10, "")).To(HaveLen(25))
+		})
+
+		It("should filter by pattern and type", func() {
+			seed(5)
+			client.LPush(ctx, []byte("user:list"), []byte("a"))
+			Expect(client.Set(ctx, []byte("other"), []byte("v"))).To(Succeed())
+
+			Expect(scanAll([]byte("user:*"), 3, "")).To(HaveLen(6))
+			Expect(scanAll([]byte("user:*"), 3, "list")).To(Equal([][]byte{[]byte("user:list")}))
+			Expect(scanAll([]byte("user:00[0-1]"), 3, "string")).To(HaveLen(2))
+		})
+
+		It("should resume across concurrent writes", func() {
+			seed(30)
+
+			after, first, err := client.Scan(ctx, nil, nil, 10, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			for i := range 30 {
+				Expect(client.Set(ctx, fmt.Appendf(nil, "added:%03d", i), []byte("v"))).To(Succeed())
+			}
+			_, err = client.Del(ctx, first[0])
+			Expect(err).NotTo(HaveOccurred())
+
+			seen := make(map[string]bool)
+			for _, key := range first {
+				seen[string(key)] = true
+			}
+
+			for after != nil {
+				var page [][]byte
+				after, page, err = client.Scan(ctx, after, []byte("user:*"), 10, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, key := range page {
+					Expect(seen).NotTo(HaveKey(string(key)))
+					seen[string(key)] = true
+				}
+			}
+
+			Expect(seen).To(HaveLen(30))
+		})
+	})
+
+	Describe("Keys", func() {
+		It("should match glob patterns", func() {
+			for _, key := range []string{"hello", "hallo", "hxllo", "hllo", "heeeello", "h*llo"} {
+				Expect(client.Set(ctx, []byte(key), []byte("v"))).To(Succeed())
+			}
+
+			keys, err := client.Keys(ctx, []byte("h?llo"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(ConsistOf([]byte("hello"), []byte("hallo"), []byte("hxllo"), []byte("h*llo")))
+
+			keys, err = client.Keys(ctx, []byte("h*llo"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(6))
+
+			keys, err = client.Keys(ctx, []byte("h[^e]llo"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(ConsistOf([]byte("hallo"), []byte("hxllo"), []byte("h*llo")))
+
+			keys, err = client.Keys(ctx, []byte("h\\*llo"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([][]byte{[]byte("h*llo")}))
+		})
+
+		It("should hide expired keys", func() {
+			Expect(client.Set(ctx, []byte("short"), []byte("v"))).To(Succeed())
+			Expect(client.Set(ctx, []byte("long"), []byte("v"))).To(Succeed())
+			client.Expire(ctx, []byte("short"), 1)
+
+			Eventually(func() [][]byte {
+				keys, _ := client.Keys(ctx, []byte("*"))
+				return keys
+			}, "3s", "100ms").Should(Equal([][]byte{[]byte("long")}))
+		})
+	})
+
+	Describe("SetWith", func() {
+		It("should only write when the NX or XX condition holds", func() {
+			_, applied, err := client.SetWith(ctx, []byte("lock"), []byte("a"), domain.SetOptions{NX: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(applied).To(BeTrue())
+
+			_, applied, err = client.SetWith(ctx, []byte("lock"), []byte("b"), domain.SetOptions{NX: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(applied).To(BeFalse())
+
+			_, applied, _ = client.SetWith(ctx, []byte("missing"), []byte("b"), domain.SetOptions{XX: true})
+			Expect(applied).To(BeFalse())
+			Expect(client.Exists(ctx, []byte("missing"))).To(BeFalse())
+
+			Expect(client.Get(ctx, []byte("lock"))).To(Equal([]byte("a")))
+		})
+
+		It("should return the previous value on GET and refuse other types", func() {
+			previous, _, err := client.SetWith(ctx, []byte("key"), []byte("a"), domain.SetOptions{Get: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(previous).To(BeNil())
+
+			previous, _, err = client.SetWith(ctx, []byte("key"), []byte("b"), domain.SetOptions{Get: true, NX: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(previous).To(Equal([]byte("a")))
+
+			client.SAdd(ctx, []byte("set"), []byte("member"))
+			_, _, err = client.SetWith(ctx, []byte("set"), []byte("b"), domain.SetOptions{Get: true})
+			Expect(err).To(MatchError(storage.ErrWrongType))
+			Expect(client.Type(ctx, []byte("set"))).To(Equal("set"))
+		})
+
+		It("should clear, keep or replace the deadline", func() {
+			deadline := time.Now().Add(time.Hour).UnixMilli()
+
+			_, _, err := client.SetWith(ctx, []byte("key"), []byte("a"), domain.SetOptions{Deadline: deadline})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.ExpireTime(ctx, []byte("key"))).To(Equal(deadline))
+
+			_, _, err = client.SetWith(ctx, []byte("key"), []byte("b"), domain.SetOptions{KeepTTL: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.ExpireTime(ctx, []byte("key"))).To(Equal(deadline))
+
+			Expect(client.Set(ctx, []byte("key"), []byte("c"))).To(Succeed())
+			Expect(client.ExpireTime(ctx, []byte("key"))).To(Equal(int64(-1)))
+		})
+	})
+
+	Describe("MGet and MSet", func() {
+		It("should write and read many keys at once", func() {
+			Expect(client.MSet(ctx, []byte("a"), []byte("1"), []byte("b"), []byte("2"))).To(Succeed())
+			client.SAdd(ctx, []byte("set"), []byte("member"))
+
+			values, err := client.MGet(ctx, []byte("a"), []byte("missing"), []byte("set"), []byte("b"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([][]byte{[]byte("1"), nil, nil, []byte("2")}))
+		})
+
+		It("should drop deadlines and replace other types", func() {
+			Expect(client.Set(ctx, []byte("a"), []byte("1"))).To(Succeed())
+			Expect(client.ExpireAt(ctx, []byte("a"), time.Now().Add(time.Hour).UnixMilli(), domain.ExpireAlways)).To(BeTrue())
+			client.SAdd(ctx, []byte("set"), []byte("member"))
+
+			Expect(client.MSet(ctx, []byte("a"), []byte("2"), []byte("set"), []byte("3"))).To(Succeed())
+			Expect(client.ExpireTime(ctx, []byte("a"))).To(Equal(int64(-1)))
+			Expect(client.Get(ctx, []byte("set"))).To(Equal([]byte("3")))
+		})
+
+		It("should only write with MSetNX when no key exists", func() {
+			Expect(client.MSetNX(ctx, []byte("a"), []byte("1"), []byte("b"), []byte("2"))).To(BeTrue())
+			Expect(client.MSetNX(ctx, []byte("c"), []byte("3"), []byte("a"), []byte("4"))).To(BeFalse())
+
+			Expect(client.Exists(ctx, []byte("c"))).To(BeFalse())
+			Expect(client.Get(ctx, []byte("a"))).To(Equal([]byte("1")))
+		})
+	})
+
+	Describe("GetDel and GetEx", func() {
+		It("should return the value and delete the key", func() {
+			Expect(client.Set(ctx, []byte("key"), []byte("v"))).To(Succeed())
+
+			Expect(client.GetDel(ctx, []byte("key"))).To(Equal([]byte("v")))
+			Expect(client.Exists(ctx, []byte("key"))).To(BeFalse())
+
+			_, err := client.GetDel(ctx, []byte("key"))
+			Expect(err).To(MatchError(storage.ErrKeyNotFound))
+		})
+
+		It("should set or drop the deadline while reading", func() {
+			Expect(client.Set(ctx, []byte("key"), []byte("v"))).To(Succeed())
+			deadline := time.Now().Add(time.Hour).UnixMilli()
+
+			Expect(client.GetEx(ctx, []byte("key"), domain.GetExOptions{})).To(Equal([]byte("v")))
+			Expect(client.ExpireTime(ctx, []byte("key"))).To(Equal(int64(-1)))
+
+			Expect(client.GetEx(ctx, []byte("key"), domain.GetExOptions{Deadline: deadline})).To(Equal([]byte("v")))
+			Expect(client.ExpireTime(ctx, []byte("key"))).To(Equal(deadline))
+
+			Expect(client.GetEx(ctx, []byte("key"), domain.GetExOptions{Persist: true})).To(Equal([]byte("v")))
+			Expect(client.ExpireTime(ctx, []byte("key"))).To(Equal(int64(-1)))
+
+			Expect(client.GetEx(ctx, []byte("key"), domain.GetExOptions{Deadline: 1})).To(Equal([]byte("v")))
+			Expect(client.Exists(ctx, []byte("key"))).To(BeFalse())
+		})
+
+		It("should refuse keys of another type", func() {
+			client.SAdd(ctx, []byte("set"), []byte("member"))
+
+			_, err := client.GetDel(ctx, []byte("set"))
+			Expect(err).To(MatchError(storage.ErrWrongType))
+
+			_, err = client.GetEx(ctx, []byte("set"), domain.GetExOptions{Persist: true})
+			Expect(err).To(MatchError(storage.ErrWrongType))
+			Expect(client.Exists(ctx, []byte("set"))).To(BeTrue())
+		})
+	})
+
+	Describe("ExpireAt", func() {
+		inMillis := func(delay time.Duration) int64 {
+			return time.Now().Add(delay).UnixMilli()
+		}
+
+		It("should keep millisecond deadlines", func() {
+			Expect(client.Set(ctx, []byte("key"), []byte("v"))).To(Succeed())
+			deadline := inMillis(500 * time.Millisecond)
+
+			Expect(client.ExpireAt(ctx, []byte("key"), deadline, domain.ExpireAlways)).To(BeTrue())
+			Expect(client.ExpireTime(ctx, []byte("key"))).To(Equal(deadline))
+
+			Eventually(func() error {
+				_, err := client.Get(ctx, []byte("key"))
+				return err
+			}, "2s", "50ms").Should(HaveOccurred())
+			Expect(client.ExpireTime(ctx, []byte("key"))).To(Equal(int64(-2)))
+		})
+
+		It("should apply the NX, XX, GT and LT conditions", func() {
+			Expect(client.Set(ctx, []byte("key"), []byte("v"))).To(Succeed())
+			early, late := inMillis(time.Hour), inMillis(2*time.Hour)
+
+			Expect(client.ExpireTime(ctx, []byte("key"))).To(Equal(int64(-1)))
+			Expect(client.ExpireAt(ctx, []byte("key"), late, domain.ExpireXX)).To(BeFalse())
+			Expect(client.ExpireAt(ctx, []byte("key"), late, domain.ExpireGT)).To(BeFalse())
+			Expect(client.ExpireAt(ctx, []byte("key"), late, domain.ExpireNX)).To(BeTrue())
+			Expect(client.ExpireAt(ctx, []byte("key"), early, domain.ExpireNX)).To(BeFalse())
+			Expect(client.ExpireAt(ctx, []byte("key"), early, domain.ExpireGT)).To(BeFalse())
+			Expect(client.ExpireAt(ctx, []byte("key"), early, domain.ExpireLT)).To(BeTrue())
+			Expect(client.ExpireAt(ctx, []byte("key"), late, domain.ExpireXX)).To(BeTrue())
+			Expect(client.ExpireTime(ctx, []byte("key"))).To(Equal(late))
+
+			Expect(client.Set(ctx, []byte("plain"), []byte("v"))).To(Succeed())
+			Expect(client.ExpireAt(ctx, []byte("plain"), early, domain.ExpireLT)).To(BeTrue())
+		})
+
+		It("should delete the key when the deadline has passed", func() {
+			Expect(client.Set(ctx, []byte("key"), []byte("v"))).To(Succeed())
+
+			Expect(client.ExpireAt(ctx, []byte("key"), inMillis(-time.Second), domain.ExpireAlways)).To(BeTrue())
+			Expect(client.Exists(ctx, []byte("key"))).To(BeFalse())
+			Expect(client.ExpireAt(ctx, []byte("missing"), inMillis(time.Hour), domain.ExpireAlways)).To(BeFalse())
+		})
+	})
+
+	Describe("DBSize", func() {
+		It("should count keys in the selected database", func() {
+			Expect(client.Set(ctx, []byte("a"), []byte("v"))).To(Succeed())
+			client.SAdd(ctx, []byte("b"), []byte("m"))
+
+			size, err := client.DBSize(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(Equal(int64(2)))
+
+			other := context.WithValue(context.Background(), domain.DB, uint8(1))
+			size, err = client.DBSize(other)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(BeZero())
+		})
+	})
 })
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+const (
+	DefaultMaxBatchSize = 128
+	DefaultMaxBatchWait = 0
+)
+
+type write struct {
+	op   lmdb.TxnOp
+	done chan error
+}
+
+// commit may run op twice when its batch fails, so op must reset its results.
+func (client *Client) commit(op lmdb.TxnOp) error {
+	pending := &write{op: op, done: make(chan error, 1)}
+
+	select {
+	case client.writes <- pending:
+		return <-pending.done
+	case <-client.stopCommit:
+		return ErrClosed
+	}
+}
+
+func (client *Client) SetBatchLimits(size int64, wait time.Duration) {
+	client.batchSize.Store(max(size, 1))
+	client.batchWait.Store(int64(max(wait, 0)))
+}
+
+func (client *Client) commitLoop() {
+	defer close(client.commitDone)
+
+	for {
+		select {
+		case <-client.stopCommit:
+			return
+		case first := <-client.writes:
+			client.commitBatch(client.collect(first))
+		}
+	}
+}
+
+func (client *Client) collect(first *write) []*write {
+	size := int(client.batchSize.Load())
+	batch := append(make([]*write, 0, size), first)
+	wait := time.Duration(client.batchWait.Load())
+
+	if wait == 0 {
+		for len(batch) < size {
+			select {
+			case next := <-client.writes:
+				batch = append(batch, next)
+			default:
+				return batch
+			}
+		}
+
+		return batch
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for len(batch) < size {
+		select {
+		case next := <-client.writes:
+			batch = append(batch, next)
+		case <-timer.C:
+			return batch
+		case <-client.stopCommit:
+			return batch
+		}
+	}
+
+	return batch
+}
+
+// commitBatch retries every write alone when LMDB fails the whole batch.
+func (client *Client) commitBatch(batch []*write) {
+	results := make([]error, len(batch))
+
+	err := client.env.Update(func(txn *lmdb.Txn) error {
+		for index, pending := range batch {
+			results[index] = pending.op(txn)
+
+			if isOpError(results[index]) {
+				return results[index]
+			}
+		}
+
+		return nil
+	})
+
+	if hasError(err) && len(batch) > 1 {
+		for _, pending := range batch {
+			pending.done <- client.env.Update(pending.op)
+		}
+
+		return
+	}
+
+	for index, pending := range batch {
+		if hasError(err) {
+			results[index] = err
+		}
+
+		pending.done <- results[index]
+	}
+}
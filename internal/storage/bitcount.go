@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+func (client *Client) BitCount(ctx context.Context, key []byte, span domain.BitRange) (int64, error) {
+	var count int64
+
+	err := client.viewString(ctx, key, func(payload []byte, _ bool) error {
+		first, last, ok := bitSpan(int64(len(payload)), span)
+
+		if ok {
+			count = countBits(payload, first, last)
+		}
+
+		return nil
+	})
+
+	return count, err
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"math"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+const wordBits = 64
+
+// BitField grows the value only when one of the ops writes.
+func (client *Client) BitField(ctx context.Context, key []byte, ops []domain.BitFieldOp) ([]*int64, error) {
+	var results []*int64
+
+	err := client.updateString(ctx, key, func(payload []byte, _ bool) ([]byte, error) {
+		results = make([]*int64, 0, len(ops))
+		size := int64(-1)
+
+		for _, op := range ops {
+			if op.Kind != domain.BitFieldGet {
+				size = max(size, int64((op.Offset+uint64(op.Width)+lastBitIndex)/bitsPerByte))
+			}
+		}
+
+		data := payload
+
+		if size >= 0 {
+			data = grow(payload, size)
+		}
+
+		for _, op := range ops {
+			current := fieldValue(data, op)
+
+			if op.Kind == domain.BitFieldGet {
+				results = append(results, &current)
+				continue
+			}
+
+			base := current
+
+			if op.Kind == domain.BitFieldSet {
+				base = 0
+			}
+
+			next, ok := fieldOverflow(op, base, op.Value)
+
+			if !ok {
+				results = append(results, nil)
+				continue
+			}
+
+			writeBits(data, op.Offset, op.Width, uint64(next))
+
+			if op.Kind == domain.BitFieldSet {
+				results = append(results, &current)
+			} else {
+				results = append(results, &next)
+			}
+		}
+
+		if size < 0 {
+			return nil, nil
+		}
+
+		return data, nil
+	})
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func fieldValue(data []byte, op domain.BitFieldOp) int64 {
+	return truncateField(readBits(data, op.Offset, op.Width), op)
+}
+
+func truncateField(raw uint64, op domain.BitFieldOp) int64 {
+	shift := wordBits - uint64(op.Width)
+
+	if op.Signed {
+		return int64(raw<<shift) >> shift
+	}
+
+	return int64(raw << shift >> shift)
+}
+
+func fieldBounds(op domain.BitFieldOp) (int64, int64) {
+	if !op.Signed {
+		return 0, int64(uint64(math.MaxUint64) >> (wordBits - uint64(op.Width)))
+	}
+
+	upper := int64(math.MaxInt64) >> (wordBits - uint64(op.Width))
+	return -upper - 1, upper
+}
+
+func fieldOverflow(op domain.BitFieldOp, current, delta int64) (int64, bool) {
+	lower, upper := fieldBounds(op)
+	sum, ok := addInt64(current, delta)
+
+	if ok && sum >= lower && sum <= upper {
+		return sum, true
+	}
+
+	switch op.Overflow {
+	case domain.OverflowFail:
+		return 0, false
+	case domain.OverflowSat:
+		if (ok && sum > upper) || (!ok && delta > 0) {
+			return upper, true
+		}
+
+		return lower, true
+	}
+
+	return truncateField(uint64(current)+uint64(delta), op), true
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"math/bits"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+const (
+	bitsPerByte   = 8
+	lastBitIndex  = bitsPerByte - 1
+	maxStringSize = 512 << 20
+	allBitsSet    = byte(0xff)
+
+	noPosition int64 = -1
+)
+
+// grow copies payload, since values read under WriteMap point into the map.
+func grow(payload []byte, size int64) []byte {
+	data := make([]byte, max(int64(len(payload)), size))
+	copy(data, payload)
+	return data
+}
+
+func bitAt(data []byte, offset uint64) int64 {
+	index := offset / bitsPerByte
+
+	if index >= uint64(len(data)) {
+		return 0
+	}
+
+	return int64(data[index]>>(lastBitIndex-offset%bitsPerByte)) & 1
+}
+
+func setBitAt(data []byte, offset uint64, bit int64) {
+	mask := byte(1) << (lastBitIndex - offset%bitsPerByte)
+
+	if bit == 1 {
+		data[offset/bitsPerByte] |= mask
+		return
+	}
+
+	data[offset/bitsPerByte] &^= mask
+}
+
+func readBits(data []byte, offset uint64, width uint8) uint64 {
+	var value uint64
+
+	for index := range uint64(width) {
+		value = value<<1 | uint64(bitAt(data, offset+index))
+	}
+
+	return value
+}
+
+func writeBits(data []byte, offset uint64, width uint8, value uint64) {
+	for index := range uint64(width) {
+		setBitAt(data, offset+index, int64(value>>(uint64(width)-1-index))&1)
+	}
+}
+
+func bitSpan(length int64, span domain.BitRange) (int64, int64, bool) {
+	if span.Bit {
+		return normalizeRange(span.Start, span.End, length*bitsPerByte)
+	}
+
+	first, last, ok := normalizeRange(span.Start, span.End, length)
+	return first * bitsPerByte, last*bitsPerByte + lastBitIndex, ok
+}
+
+func countBits(data []byte, first, last int64) int64 {
+	var count int64
+
+	for offset := first; offset <= last; {
+		if offset%bitsPerByte == 0 && offset+lastBitIndex <= last {
+			count += int64(bits.OnesCount8(data[offset/bitsPerByte]))
+			offset += bitsPerByte
+			continue
+		}
+
+		count += bitAt(data, uint64(offset))
+		offset++
+	}
+
+	return count
+}
+
+func findBit(data []byte, bit, first, last int64) int64 {
+	skip := byte(0)
+
+	if bit == 0 {
+		skip = allBitsSet
+	}
+
+	for offset := first; offset <= last; {
+		if offset%bitsPerByte == 0 && offset+lastBitIndex <= last && data[offset/bitsPerByte] == skip {
+			offset += bitsPerByte
+			continue
+		}
+
+		if bitAt(data, uint64(offset)) == bit {
+			return offset
+		}
+
+		offset++
+	}
+
+	return noPosition
+}
@@ -0,0 +1,233 @@
+package storage_test
+
+import (
+	"context"
+	"math"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+	"github.com/luiz-simples/keyp.git/internal/storage"
+)
+
+var _ = Describe("Bitmap Storage Commands", func() {
+	var (
+		client  *storage.Client
+		ctx     context.Context
+		tempDir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "keyp-test-bitmap-*")
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err = storage.NewClient(tempDir)
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx = context.WithValue(context.Background(), domain.DB, 0)
+	})
+
+	AfterEach(func() {
+		if client != nil {
+			client.Close()
+		}
+		os.RemoveAll(tempDir)
+	})
+
+	Describe("StrLen, GetRange and SetRange", func() {
+		It("should read substrings with negative offsets", func() {
+			Expect(client.Set(ctx, []byte("key"), []byte("This is a string"))).To(Succeed())
+
+			Expect(client.StrLen(ctx, []byte("key"))).To(Equal(int64(16)))
+			Expect(client.GetRange(ctx, []byte("key"), 0, 3)).To(Equal([]byte("This")))
+			Expect(client.GetRange(ctx, []byte("key"), -3, -1)).To(Equal([]byte("ing")))
+			Expect(client.GetRange(ctx, []byte("key"), 10, 100)).To(Equal([]byte("string")))
+			Expect(client.GetRange(ctx, []byte("key"), 5, 2)).To(BeEmpty())
+			Expect(client.GetRange(ctx, []byte("key"), -100, -50)).To(Equal([]byte("T")))
+			Expect(client.GetRange(ctx, []byte("key"), -1, -5)).To(BeEmpty())
+
+			Expect(client.Set(ctx, []byte("digits"), []byte("0123456789"))).To(Succeed())
+			Expect(client.GetRange(ctx, []byte("digits"), -100, -50)).To(Equal([]byte("0")))
+			Expect(client.GetRange(ctx, []byte("missing"), 0, -1)).To(Equal([]byte{}))
+			Expect(client.StrLen(ctx, []byte("missing"))).To(BeZero())
+		})
+
+		It("should overwrite and pad with zero bytes while keeping the TTL", func() {
+			Expect(client.Set(ctx, []byte("key"), []byte("Hello World"))).To(Succeed())
+			deadline := time.Now().Add(time.Hour).UnixMilli()
+			Expect(client.ExpireAt(ctx, []byte("key"), deadline, domain.ExpireAlways)).To(BeTrue())
+
+			Expect(client.SetRange(ctx, []byte("key"), 6, []byte("Redis"))).To(Equal(int64(11)))
+			Expect(client.Get(ctx, []byte("key"))).To(Equal([]byte("Hello Redis")))
+			Expect(client.ExpireTime(ctx, []byte("key"))).To(Equal(deadline))
+
+			Expect(client.SetRange(ctx, []byte("padded"), 3, []byte("x"))).To(Equal(int64(4)))
+			Expect(client.Get(ctx, []byte("padded"))).To(Equal([]byte("\x00\x00\x00x")))
+		})
+
+		It("should not create a key for an empty value or exceed the size limit", func() {
+			Expect(client.SetRange(ctx, []byte("missing"), 10, []byte{})).To(BeZero())
+			Expect(client.Exists(ctx, []byte("missing"))).To(BeFalse())
+
+			_, err := client.SetRange(ctx, []byte("huge"), 512<<20, []byte("x"))
+			Expect(err).To(MatchError(storage.ErrStringTooLong))
+		})
+
+		It("should refuse other types", func() {
+			client.SAdd(ctx, []byte("set"), []byte("member"))
+
+			_, err := client.StrLen(ctx, []byte("set"))
+			Expect(err).To(MatchError(storage.ErrWrongType))
+
+			_, err = client.SetRange(ctx, []byte("set"), 0, []byte("x"))
+			Expect(err).To(MatchError(storage.ErrWrongType))
+		})
+	})
+
+	Describe("GetBit and SetBit", func() {
+		It("should set bits most significant first and grow the value", func() {
+			Expect(client.SetBit(ctx, []byte("key"), 7, 1)).To(BeZero())
+			Expect(client.Get(ctx, []byte("key"))).To(Equal([]byte{0x01}))
+
+			Expect(client.SetBit(ctx, []byte("key"), 7, 0)).To(Equal(int64(1)))
+			Expect(client.SetBit(ctx, []byte("key"), 17, 1)).To(BeZero())
+			Expect(client.Get(ctx, []byte("key"))).To(Equal([]byte{0x00, 0x00, 0x40}))
+
+			Expect(client.GetBit(ctx, []byte("key"), 17)).To(Equal(int64(1)))
+			Expect(client.GetBit(ctx, []byte("key"), 1000)).To(BeZero())
+			Expect(client.GetBit(ctx, []byte("missing"), 0)).To(BeZero())
+		})
+	})
+
+	Describe("BitCount and BitPos", func() {
+		BeforeEach(func() {
+			Expect(client.Set(ctx, []byte("key"), []byte{0xff, 0xf0, 0x00})).To(Succeed())
+		})
+
+		It("should count bits in byte and bit ranges", func() {
+			Expect(client.BitCount(ctx, []byte("key"), domain.BitRange{End: -1})).To(Equal(int64(12)))
+			Expect(client.BitCount(ctx, []byte("key"), domain.BitRange{Start: 1, End: 1})).To(Equal(int64(4)))
+			Expect(client.BitCount(ctx, []byte("key"), domain.BitRange{Start: 5, End: 10, Bit: true})).To(Equal(int64(6)))
+			Expect(client.BitCount(ctx, []byte("key"), domain.BitRange{Start: -2, End: -1})).To(Equal(int64(4)))
+			Expect(client.BitCount(ctx, []byte("missing"), domain.BitRange{End: -1})).To(BeZero())
+		})
+
+		It("should find the first set or clear bit", func() {
+			Expect(client.BitPos(ctx, []byte("key"), 0, domain.BitRange{End: -1})).To(Equal(int64(12)))
+			Expect(client.BitPos(ctx, []byte("key"), 1, domain.BitRange{Start: 2, End: -1})).To(Equal(int64(-1)))
+			Expect(client.BitPos(ctx, []byte("key"), 1, domain.BitRange{Start: 3, End: -1, Bit: true})).To(Equal(int64(3)))
+
+			Expect(client.BitPos(ctx, []byte("missing"), 1, domain.BitRange{End: -1})).To(Equal(int64(-1)))
+			Expect(client.BitPos(ctx, []byte("missing"), 0, domain.BitRange{End: -1})).To(BeZero())
+		})
+
+		It("should treat a value as zero padded only without an explicit end", func() {
+			Expect(client.Set(ctx, []byte("ones"), []byte{0xff, 0xff})).To(Succeed())
+
+			Expect(client.BitPos(ctx, []byte("ones"), 0, domain.BitRange{End: -1})).To(Equal(int64(16)))
+			Expect(client.BitPos(ctx, []byte("ones"), 0, domain.BitRange{End: -1, HasEnd: true})).To(Equal(int64(-1)))
+		})
+	})
+
+	Describe("BitOp", func() {
+		It("should combine sources of different lengths", func() {
+			Expect(client.Set(ctx, []byte("a"), []byte{0xf0, 0x0f})).To(Succeed())
+			Expect(client.Set(ctx, []byte("b"), []byte{0xff})).To(Succeed())
+
+			Expect(client.BitOp(ctx, domain.BitAnd, []byte("dest"), []byte("a"), []byte("b"))).To(Equal(int64(2)))
+			Expect(client.Get(ctx, []byte("dest"))).To(Equal([]byte{0xf0, 0x00}))
+
+			Expect(client.BitOp(ctx, domain.BitOr, []byte("dest"), []byte("a"), []byte("b"), []byte("missing"))).To(Equal(int64(2)))
+			Expect(client.Get(ctx, []byte("dest"))).To(Equal([]byte{0xff, 0x0f}))
+
+			Expect(client.BitOp(ctx, domain.BitXor, []byte("dest"), []byte("a"), []byte("b"))).To(Equal(int64(2)))
+			Expect(client.Get(ctx, []byte("dest"))).To(Equal([]byte{0x0f, 0x0f}))
+
+			Expect(client.BitOp(ctx, domain.BitNot, []byte("dest"), []byte("a"))).To(Equal(int64(2)))
+			Expect(client.Get(ctx, []byte("dest"))).To(Equal([]byte{0x0f, 0xf0}))
+		})
+
+		It("should delete dest when every source is missing", func() {
+			Expect(client.Set(ctx, []byte("dest"), []byte("old"))).To(Succeed())
+
+			Expect(client.BitOp(ctx, domain.BitOr, []byte("dest"), []byte("missing"))).To(BeZero())
+			Expect(client.Exists(ctx, []byte("dest"))).To(BeFalse())
+		})
+
+		It("should refuse sources of another type", func() {
+			client.SAdd(ctx, []byte("set"), []byte("member"))
+
+			_, err := client.BitOp(ctx, domain.BitAnd, []byte("dest"), []byte("set"))
+			Expect(err).To(MatchError(storage.ErrWrongType))
+		})
+	})
+
+	Describe("BitField", func() {
+		value := func(result *int64) int64 {
+			Expect(result).NotTo(BeNil())
+			return *result
+		}
+
+		It("should get, set and increment signed and unsigned fields", func() {
+			results, err := client.BitField(ctx, []byte("key"), []domain.BitFieldOp{
+				{Kind: domain.BitFieldSet, Width: 8, Offset: 0, Value: 200},
+				{Kind: domain.BitFieldGet, Width: 8, Offset: 0},
+				{Kind: domain.BitFieldGet, Signed: true, Width: 8, Offset: 0},
+				{Kind: domain.BitFieldIncrBy, Width: 4, Offset: 8, Value: 3},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value(results[0])).To(BeZero())
+			Expect(value(results[1])).To(Equal(int64(200)))
+			Expect(value(results[2])).To(Equal(int64(-56)))
+			Expect(value(results[3])).To(Equal(int64(3)))
+			Expect(client.Get(ctx, []byte("key"))).To(Equal([]byte{200, 0x30}))
+		})
+
+		It("should wrap, saturate or fail on overflow", func() {
+			ops := func(overflow domain.BitOverflow) []domain.BitFieldOp {
+				return []domain.BitFieldOp{
+					{Kind: domain.BitFieldSet, Width: 8, Value: 250},
+					{Kind: domain.BitFieldIncrBy, Width: 8, Value: 10, Overflow: overflow},
+				}
+			}
+
+			results, err := client.BitField(ctx, []byte("wrap"), ops(domain.OverflowWrap))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value(results[1])).To(Equal(int64(4)))
+
+			results, _ = client.BitField(ctx, []byte("sat"), ops(domain.OverflowSat))
+			Expect(value(results[1])).To(Equal(int64(255)))
+
+			results, _ = client.BitField(ctx, []byte("fail"), ops(domain.OverflowFail))
+			Expect(results[1]).To(BeNil())
+			Expect(client.Get(ctx, []byte("fail"))).To(Equal([]byte{250}))
+
+			results, _ = client.BitField(ctx, []byte("signed"), []domain.BitFieldOp{
+				{Kind: domain.BitFieldIncrBy, Signed: true, Width: 64, Value: math.MaxInt64},
+				{Kind: domain.BitFieldIncrBy, Signed: true, Width: 64, Value: 1, Overflow: domain.OverflowSat},
+				{Kind: domain.BitFieldIncrBy, Signed: true, Width: 64, Value: 1},
+				{Kind: domain.BitFieldSet, Signed: true, Width: 4, Offset: 64, Value: -9, Overflow: domain.OverflowSat},
+			})
+			Expect(value(results[1])).To(Equal(int64(math.MaxInt64)))
+			Expect(value(results[2])).To(Equal(int64(math.MinInt64)))
+			Expect(client.BitField(ctx, []byte("signed"), []domain.BitFieldOp{
+				{Kind: domain.BitFieldGet, Signed: true, Width: 4, Offset: 64},
+			})).To(HaveExactElements(HaveValue(Equal(int64(-8)))))
+		})
+
+		It("should not create the key for reads only", func() {
+			results, err := client.BitField(ctx, []byte("missing"), []domain.BitFieldOp{
+				{Kind: domain.BitFieldGet, Width: 16, Offset: 100},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value(results[0])).To(BeZero())
+			Expect(client.Exists(ctx, []byte("missing"))).To(BeFalse())
+		})
+	})
+})
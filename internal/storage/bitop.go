@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+func (client *Client) BitOp(ctx context.Context, op domain.BitOperation, dest []byte, keys ...[]byte) (int64, error) {
+	db, err := client.sel(ctx)
+
+	if noError(err) {
+		err = ctxFlush(ctx)
+	}
+
+	if hasError(err) {
+		return 0, err
+	}
+
+	var length int64
+
+	err = client.update(ctx, func(txn *lmdb.Txn) error {
+		length = 0
+		sources := make([][]byte, 0, len(keys))
+
+		for _, key := range keys {
+			payload, txnErr := db.load(txn, key, kindString)
+
+			if hasError(txnErr) && !isNotFound(txnErr) {
+				return txnErr
+			}
+
+			sources = append(sources, payload)
+			length = max(length, int64(len(payload)))
+		}
+
+		result := combine(op, sources, length)
+
+		if isEmpty(result) {
+			delErr := db.del(txn, dest)
+
+			if isNotFound(delErr) {
+				return nil
+			}
+
+			return delErr
+		}
+
+		if txnErr := db.clearDeadline(txn, dest); hasError(txnErr) {
+			return txnErr
+		}
+
+		return db.overwrite(txn, dest, kindString, encodingRaw, result)
+	})
+
+	if hasError(err) {
+		return 0, err
+	}
+
+	return length, nil
+}
+
+func combine(op domain.BitOperation, sources [][]byte, length int64) []byte {
+	result := make([]byte, length)
+
+	if op == domain.BitNot {
+		for index := range result {
+			result[index] = ^byteAt(sources[0], index)
+		}
+
+		return result
+	}
+
+	for index := range result {
+		value := byteAt(sources[0], index)
+
+		for _, source := range sources[1:] {
+			switch op {
+			case domain.BitAnd:
+				value &= byteAt(source, index)
+			case domain.BitOr:
+				value |= byteAt(source, index)
+			case domain.BitXor:
+				value ^= byteAt(source, index)
+			}
+		}
+
+		result[index] = value
+	}
+
+	return result
+}
+
+func byteAt(data []byte, index int) byte {
+	if index >= len(data) {
+		return 0
+	}
+
+	return data[index]
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+// BitPos pads the value with zeros when looking for a clear bit without an end.
+func (client *Client) BitPos(ctx context.Context, key []byte, bit int64, span domain.BitRange) (int64, error) {
+	position := noPosition
+
+	err := client.viewString(ctx, key, func(payload []byte, found bool) error {
+		if !found {
+			if bit == 0 {
+				position = 0
+			}
+
+			return nil
+		}
+
+		first, last, ok := bitSpan(int64(len(payload)), span)
+
+		if !ok {
+			return nil
+		}
+
+		position = findBit(payload, bit, first, last)
+
+		if position == noPosition && bit == 0 && !span.HasEnd {
+			position = last + 1
+		}
+
+		return nil
+	})
+
+	if hasError(err) {
+		return noPosition, err
+	}
+
+	return position, nil
+}
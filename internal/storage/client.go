@@ -4,42 +4,114 @@ import (
 	"errors"
 	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
 var (
 	ErrKeyNotFound     = errors.New("key not found")
-	ErrNotInteger      = errors.New("value is not an integer or out of range")
+	ErrNotInteger      = errors.New("ERR value is not an integer or out of range")
 	ErrContextCanceled = errors.New("context canceled")
 	ErrWrongType       = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+	ErrHashNotInteger  = errors.New("ERR hash value is not an integer")
+	ErrHashNotFloat    = errors.New("ERR hash value is not a float")
+	ErrOverflow        = errors.New("ERR increment or decrement would overflow")
+	ErrDecrOverflow    = errors.New("ERR decrement would overflow")
+	ErrNotFinite       = errors.New("ERR increment would produce NaN or Infinity")
+	ErrDBIndex         = errors.New("ERR DB index is out of range")
+	ErrSyncMode        = errors.New("ERR invalid sync mode")
+	ErrClosed          = errors.New("ERR storage is closed")
+	ErrStringTooLong   = errors.New("ERR string exceeds maximum allowed size (proto-max-bulk-len)")
 )
 
 const (
-	dirPerm      = 0o755
-	filePerm     = 0o644
-	maxDatabases = 100
-	mapSizeBytes = 4 << 30
-	noFlags      = 0
-	performFlags = lmdb.WriteMap | lmdb.NoMetaSync | lmdb.NoSync | lmdb.MapAsync | lmdb.NoReadahead
+	dirPerm     = 0o755
+	filePerm    = 0o644
+	dbisPerDB   = 5
+	noFlags     = 0
+	safeFlags   = lmdb.WriteMap | lmdb.NoReadahead
+	syncBits    = lmdb.NoMetaSync | lmdb.NoSync | lmdb.MapAsync
+	noSyncFlags = safeFlags | syncBits
+
+	DefaultMapSize    = 4 << 30
+	DefaultMaxReaders = 128
+	DefaultDatabases  = 100
+	MaxDatabases      = 256
 )
 
 type (
-	TTL struct {
-		Expire uint32
-		Cancel func()
+	Options struct {
+		MapSize          int64
+		MaxReaders       int
+		Databases        int
+		SyncMode         string
+		MaxPackedEntries int64
+		MaxBatchSize     int64
+		MaxBatchWait     time.Duration
 	}
 
 	Client struct {
-		env *lmdb.Env
-		dbi map[uint8]lmdb.DBI
-		ttl map[uint8]map[string]*TTL
-		mtx sync.RWMutex
+		env        *lmdb.Env
+		dbs        map[uint8]*keyspace
+		access     *tracker
+		watches    *watchers
+		maxPacked  atomic.Int64
+		expired    atomic.Int64
+		syncMode   atomic.Value
+		lastSync   atomic.Int64
+		failed     atomic.Bool
+		stopSync   chan struct{}
+		syncDone   chan struct{}
+		stopping   sync.Once
+		writes     chan *write
+		batchSize  atomic.Int64
+		batchWait  atomic.Int64
+		stopCommit chan struct{}
+		commitDone chan struct{}
+		stopExpire chan struct{}
+		expireDone chan struct{}
+		cycles     atomic.Int64
+		cycleTime  atomic.Int64
+		lastCycle  atomic.Int64
+		databases  int
+		mtx        sync.RWMutex
+		gate       sync.RWMutex
+		closed     bool
 	}
 )
 
+func DefaultOptions() Options {
+	return Options{
+		MapSize:          DefaultMapSize,
+		MaxReaders:       DefaultMaxReaders,
+		Databases:        DefaultDatabases,
+		SyncMode:         domain.SyncEverySec,
+		MaxPackedEntries: defaultMaxPackedEntries,
+		MaxBatchSize:     DefaultMaxBatchSize,
+		MaxBatchWait:     DefaultMaxBatchWait,
+	}
+}
+
 func NewClient(dataDir string) (*Client, error) {
-	err := os.MkdirAll(dataDir, dirPerm)
+	return NewClientWithOptions(dataDir, DefaultOptions())
+}
+
+func NewClientWithOptions(dataDir string, options Options) (*Client, error) {
+	if options.Databases < 1 || options.Databases > MaxDatabases {
+		return nil, ErrDBIndex
+	}
+
+	flags, err := syncFlags(options.SyncMode)
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	err = os.MkdirAll(dataDir, dirPerm)
 
 	if hasError(err) {
 		return nil, err
@@ -48,19 +120,19 @@ func NewClient(dataDir string) (*Client, error) {
 	env, err := lmdb.NewEnv()
 
 	if noError(err) {
-		err = env.SetMaxDBs(maxDatabases + 1)
+		err = env.SetMaxDBs(options.Databases*dbisPerDB + 1)
 	}
 
 	if noError(err) {
-		err = env.SetMapSize(mapSizeBytes)
+		err = env.SetMapSize(options.MapSize)
 	}
 
 	if noError(err) {
-		err = env.SetMaxReaders(128)
+		err = env.SetMaxReaders(options.MaxReaders)
 	}
 
 	if noError(err) {
-		err = env.Open(dataDir, performFlags, filePerm)
+		err = env.Open(dataDir, flags, filePerm)
 	}
 
 	if hasError(err) {
@@ -68,9 +140,80 @@ func NewClient(dataDir string) (*Client, error) {
 		return nil, err
 	}
 
-	storage := &Client{env: env}
-	storage.dbi = make(map[uint8]lmdb.DBI)
-	storage.ttl = make(map[uint8]map[string]*TTL)
+	storage := &Client{env: env, databases: options.Databases}
+	storage.dbs = make(map[uint8]*keyspace)
+	storage.access = newTracker()
+	storage.watches = newWatchers()
+	storage.SetMaxPackedEntries(options.MaxPackedEntries)
+	storage.syncMode.Store(options.SyncMode)
+	storage.lastSync.Store(time.Now().UnixNano())
+	storage.stopSync = make(chan struct{})
+	storage.syncDone = make(chan struct{})
+	go storage.syncLoop()
+	storage.SetBatchLimits(options.MaxBatchSize, options.MaxBatchWait)
+	storage.writes = make(chan *write)
+	storage.stopCommit = make(chan struct{})
+	storage.commitDone = make(chan struct{})
+	go storage.commitLoop()
+	storage.stopExpire = make(chan struct{})
+	storage.expireDone = make(chan struct{})
+	go storage.expireLoop()
+
+	err = storage.migrate()
+
+	if noError(err) {
+		err = storage.restore()
+	}
+
+	if hasError(err) {
+		storage.Close()
+		return nil, err
+	}
 
 	return storage, nil
 }
+
+func (client *Client) SetMaxPackedEntries(entries int64) {
+	client.maxPacked.Store(max(entries, 0))
+}
+
+func (client *Client) Configure(options Options) error {
+	flags, err := syncFlags(options.SyncMode)
+
+	if hasError(err) {
+		return err
+	}
+
+	client.gate.RLock()
+	defer client.gate.RUnlock()
+
+	if client.closed {
+		return ErrClosed
+	}
+
+	err = client.env.UnsetFlags(syncBits)
+
+	if noError(err) && flags&syncBits != noFlags {
+		err = client.env.SetFlags(flags & syncBits)
+	}
+
+	if hasError(err) {
+		return err
+	}
+
+	client.SetMaxPackedEntries(options.MaxPackedEntries)
+	client.syncMode.Store(options.SyncMode)
+	client.SetBatchLimits(options.MaxBatchSize, options.MaxBatchWait)
+	return nil
+}
+
+func syncFlags(mode string) (uint, error) {
+	switch mode {
+	case domain.SyncAlways:
+		return safeFlags, nil
+	case domain.SyncEverySec, domain.SyncNo:
+		return noSyncFlags, nil
+	}
+
+	return noFlags, ErrSyncMode
+}
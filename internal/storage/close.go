@@ -1,5 +1,23 @@
 package storage
 
 func (client *Client) Close() {
+	client.stopping.Do(func() {
+		close(client.stopSync)
+		close(client.stopCommit)
+		close(client.stopExpire)
+		<-client.syncDone
+		<-client.expireDone
+		<-client.commitDone
+	})
+
+	client.gate.Lock()
+	defer client.gate.Unlock()
+
+	if client.closed {
+		return
+	}
+
+	client.closed = true
+
 	client.env.Close()
 }
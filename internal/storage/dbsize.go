@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+func (client *Client) DBSize(ctx context.Context) (int64, error) {
+	if hasError(ctxFlush(ctx)) {
+		return emptyCount, ctx.Err()
+	}
+
+	db, err := client.sel(ctx)
+	if hasError(err) {
+		return emptyCount, err
+	}
+
+	var size int64
+
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		stat, statErr := txn.Stat(db.data)
+		if hasError(statErr) {
+			return statErr
+		}
+
+		expired, expiredErr := db.countExpired(txn)
+		size = int64(stat.Entries) - expired
+		return expiredErr
+	})
+
+	if hasError(err) {
+		return emptyCount, err
+	}
+
+	return size, nil
+}
+
+func (space *keyspace) countExpired(txn *lmdb.Txn) (int64, error) {
+	cursor, err := txn.OpenCursor(space.expiry)
+	if hasError(err) {
+		return emptyCount, err
+	}
+	defer cursor.Close()
+
+	var expired int64
+
+	for {
+		entry, _, cursorErr := cursor.Get(nil, nil, lmdb.Next)
+
+		if isNotFound(cursorErr) {
+			return expired, nil
+		}
+
+		if hasError(cursorErr) {
+			return emptyCount, cursorErr
+		}
+
+		if len(entry) < deadlineSize || !isDeadlineReached(int64(binary.BigEndian.Uint64(entry))) {
+			return expired, nil
+		}
+
+		expired++
+	}
+}
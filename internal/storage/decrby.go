@@ -2,10 +2,14 @@ package storage
 
 import (
 	"context"
+	"math"
 )
 
+// DecrBy refuses math.MinInt64, which cannot be negated.
 func (client *Client) DecrBy(ctx context.Context, key []byte, decrement int64) (int64, error) {
-	return modifyIntegerBy(client, ctx, key, decrement, func(current, delta int64) int64 {
-		return current - delta
-	})
+	if decrement == math.MinInt64 {
+		return emptyCount, ErrDecrOverflow
+	}
+
+	return modifyIntegerBy(client, ctx, key, -decrement, addInt64)
 }
@@ -25,13 +25,19 @@ func (client *Client) Del(ctx context.Context, keys ...[]byte) (uint32, error) {
 
 	deleted := EMPTY
 
-	err = client.env.Update(func(txn *lmdb.Txn) error {
+	err = client.update(ctx, func(txn *lmdb.Txn) error {
+		deleted = EMPTY
+
 		if errFlush := ctxFlush(ctx); hasError(errFlush) {
 			return errFlush
 		}
 
 		for _, key := range keys {
-			delErr := txn.Del(db, key, nil)
+			delErr := db.purge(txn, key)
+
+			if noError(delErr) {
+				delErr = db.del(txn, key)
+			}
 
 			if noError(delErr) {
 				deleted++
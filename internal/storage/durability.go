@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+const syncInterval = time.Second
+
+func (client *Client) syncLoop() {
+	defer close(client.syncDone)
+
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-client.stopSync:
+			return
+		case <-ticker.C:
+			if client.syncMode.Load() == domain.SyncEverySec {
+				client.Sync()
+			}
+		}
+	}
+}
+
+func (client *Client) Sync() error {
+	client.gate.RLock()
+	defer client.gate.RUnlock()
+
+	if client.closed {
+		return ErrClosed
+	}
+
+	err := client.env.Sync(true)
+	client.failed.Store(hasError(err))
+
+	if noError(err) {
+		client.lastSync.Store(time.Now().UnixNano())
+	}
+
+	return err
+}
+
+func (client *Client) LastSync() time.Time {
+	if client.syncMode.Load() == domain.SyncAlways {
+		return time.Now()
+	}
+
+	return time.Unix(0, client.lastSync.Load())
+}
@@ -0,0 +1,224 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+const (
+	elementsName = "elems_%d"
+	scoresName   = "scores_%d"
+
+	keyLengthSize     = 4
+	memberLengthSize  = 4
+	positionSize      = 8
+	signBit           = uint64(1) << 63
+	maxElementKeySize = 511
+
+	defaultMaxPackedEntries = 128
+)
+
+func elementPrefix(key []byte) []byte {
+	prefix := make([]byte, keyLengthSize, keyLengthSize+len(key))
+	binary.BigEndian.PutUint32(prefix, uint32(len(key)))
+	return append(prefix, key...)
+}
+
+func elementKey(prefix []byte, parts ...[]byte) []byte {
+	size := len(prefix)
+	for _, part := range parts {
+		size += len(part)
+	}
+
+	composite := make([]byte, len(prefix), size)
+	copy(composite, prefix)
+
+	for _, part := range parts {
+		composite = append(composite, part...)
+	}
+
+	return composite
+}
+
+// memberKey keys members too long for LMDB by a prefix of them plus their digest.
+func memberKey(prefix, member []byte) []byte {
+	if len(prefix)+len(member) < maxElementKeySize {
+		return elementKey(prefix, member)
+	}
+
+	digest := sha256.Sum256(member)
+	cut := max(maxElementKeySize-len(prefix)-len(digest), 0)
+
+	return elementKey(prefix, member[:cut], digest[:])
+}
+
+func isDigestKey(prefix, suffix []byte) bool {
+	return len(prefix)+len(suffix) == maxElementKeySize
+}
+
+func packEntry(prefix, key, member, value []byte) []byte {
+	if !isDigestKey(prefix, key[len(prefix):]) {
+		return value
+	}
+
+	entry := make([]byte, memberLengthSize, memberLengthSize+len(member)+len(value))
+	binary.BigEndian.PutUint32(entry, uint32(len(member)))
+	entry = append(entry, member...)
+	return append(entry, value...)
+}
+
+func unpackEntry(prefix, suffix, entry []byte) ([]byte, []byte) {
+	if !isDigestKey(prefix, suffix) || len(entry) < memberLengthSize {
+		return suffix, entry
+	}
+
+	end := memberLengthSize + int(binary.BigEndian.Uint32(entry))
+	if end > len(entry) {
+		return suffix, entry
+	}
+
+	return entry[memberLengthSize:end], entry[end:]
+}
+
+func fitsElements(key []byte) bool {
+	return keyLengthSize+len(key)+scoreSize+sha256.Size < maxElementKeySize
+}
+
+func encodePosition(position int64) []byte {
+	data := make([]byte, positionSize)
+	binary.BigEndian.PutUint64(data, uint64(position)^signBit)
+	return data
+}
+
+func encodeSortableScore(score float64) []byte {
+	bits := math.Float64bits(score)
+
+	if bits&signBit != 0 {
+		bits = ^bits
+	} else {
+		bits |= signBit
+	}
+
+	data := make([]byte, scoreSize)
+	binary.BigEndian.PutUint64(data, bits)
+	return data
+}
+
+func decodeSortableScore(data []byte) float64 {
+	bits := binary.BigEndian.Uint64(data)
+
+	if bits&signBit != 0 {
+		bits &^= signBit
+	} else {
+		bits = ^bits
+	}
+
+	return math.Float64frombits(bits)
+}
+
+func encodeScore(score float64) []byte {
+	data := make([]byte, scoreSize)
+	binary.LittleEndian.PutUint64(data, math.Float64bits(score))
+	return data
+}
+
+func decodeScore(data []byte) float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(data))
+}
+
+func (space *keyspace) maxPackedEntries() int64 {
+	if space.limits == nil {
+		return defaultMaxPackedEntries
+	}
+
+	return space.limits.Load()
+}
+
+func (space *keyspace) keepsPacked(key []byte, size int64) bool {
+	return size <= space.maxPackedEntries() || !fitsElements(key)
+}
+
+func (space *keyspace) getMember(txn *lmdb.Txn, dbi lmdb.DBI, prefix, member []byte) ([]byte, bool, error) {
+	key := memberKey(prefix, member)
+	entry, err := txn.Get(dbi, key)
+
+	if isNotFound(err) {
+		return nil, false, nil
+	}
+
+	if hasError(err) {
+		return nil, false, err
+	}
+
+	stored, value := unpackEntry(prefix, key[len(prefix):], entry)
+	return value, bytes.Equal(stored, member), nil
+}
+
+func (space *keyspace) putMember(txn *lmdb.Txn, dbi lmdb.DBI, prefix, member, value []byte) error {
+	key := memberKey(prefix, member)
+	return txn.Put(dbi, key, packEntry(prefix, key, member, value), noFlags)
+}
+
+func (space *keyspace) eachElement(txn *lmdb.Txn, dbi lmdb.DBI, from, prefix []byte, fn func(suffix, value []byte) bool) error {
+	cursor, err := txn.OpenCursor(dbi)
+	if hasError(err) {
+		return err
+	}
+	defer cursor.Close()
+
+	key, value, err := cursor.Get(from, nil, lmdb.SetRange)
+
+	for noError(err) && bytes.HasPrefix(key, prefix) {
+		if !fn(key[len(prefix):], value) {
+			return nil
+		}
+
+		key, value, err = cursor.Get(nil, nil, lmdb.Next)
+	}
+
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (space *keyspace) dropElements(txn *lmdb.Txn, key []byte) error {
+	prefix := elementPrefix(key)
+
+	for _, dbi := range []lmdb.DBI{space.elements, space.scores} {
+		if err := dropPrefix(txn, dbi, prefix); hasError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func dropPrefix(txn *lmdb.Txn, dbi lmdb.DBI, prefix []byte) error {
+	cursor, err := txn.OpenCursor(dbi)
+	if hasError(err) {
+		return err
+	}
+	defer cursor.Close()
+
+	key, _, err := cursor.Get(prefix, nil, lmdb.SetRange)
+
+	for noError(err) && bytes.HasPrefix(key, prefix) {
+		if err = cursor.Del(noFlags); hasError(err) {
+			return err
+		}
+
+		key, _, err = cursor.Get(nil, nil, lmdb.Next)
+	}
+
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
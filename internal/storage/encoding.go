@@ -0,0 +1,137 @@
+package storage
+
+import "github.com/luiz-simples/keyp.git/internal/domain"
+
+const (
+	headerSize = 2
+
+	kindNone   byte = 0
+	kindString byte = 1
+	kindList   byte = 2
+	kindSet    byte = 3
+	kindZSet   byte = 4
+	kindHash   byte = 5
+	// kindListOrSet tags legacy values that may be lists or sets until written.
+	kindListOrSet byte = 6
+
+	encodingRaw    byte = 0
+	encodingPacked byte = 1
+	encodingTable  byte = 2
+
+	embeddedStringSize = 44
+)
+
+var kindNames = map[byte]string{
+	kindNone:   "none",
+	kindString: "string",
+	kindList:   "list",
+	kindSet:    "set",
+	kindZSet:   "zset",
+	kindHash:   "hash",
+
+	kindListOrSet: "list",
+}
+
+var tableEncodingNames = map[byte]string{
+	kindList: "quicklist",
+	kindSet:  "hashtable",
+	kindZSet: "skiplist",
+	kindHash: "hashtable",
+}
+
+func encode(kind, encoding byte, payload []byte) []byte {
+	data := make([]byte, headerSize+len(payload))
+	data[0] = kind
+	data[1] = encoding
+	copy(data[headerSize:], payload)
+	return data
+}
+
+func kindOf(data []byte) byte {
+	if len(data) < headerSize {
+		return kindNone
+	}
+
+	return data[0]
+}
+
+func kindName(kind byte) string {
+	name, known := kindNames[kind]
+
+	if !known {
+		return kindNames[kindNone]
+	}
+
+	return name
+}
+
+func encodingName(data []byte) string {
+	if kindOf(data) != kindString {
+		return collectionEncodingName(kindOf(data), encodingOf(data))
+	}
+
+	payload := payloadOf(data)
+
+	if isIntegerString(payload) {
+		return "int"
+	}
+
+	if len(payload) <= embeddedStringSize {
+		return "embstr"
+	}
+
+	return "raw"
+}
+
+func collectionEncodingName(kind, encoding byte) string {
+	if encoding != encodingTable {
+		return "listpack"
+	}
+
+	return tableEncodingNames[kind]
+}
+
+func isIntegerString(payload []byte) bool {
+	_, valid := domain.ParseInteger(payload)
+	return valid
+}
+
+func encodingOf(data []byte) byte {
+	if len(data) < headerSize {
+		return encodingRaw
+	}
+
+	return data[1]
+}
+
+func payloadOf(data []byte) []byte {
+	if len(data) < headerSize {
+		return nil
+	}
+
+	return data[headerSize:]
+}
+
+func checkKeyType(data []byte, kind byte) error {
+	if !isKind(data, kind) {
+		return ErrWrongType
+	}
+
+	return nil
+}
+
+func isKind(data []byte, kind byte) bool {
+	if kindOf(data) == kindListOrSet {
+		return kind == kindList || kind == kindSet
+	}
+
+	return kindOf(data) == kind
+}
+
+func hasKindName(data []byte, name string) bool {
+	if kindOf(data) == kindListOrSet {
+		return name == kindNames[kindList] || name == kindNames[kindSet]
+	}
+
+	return kindName(kindOf(data)) == name
+}
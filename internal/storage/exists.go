@@ -21,8 +21,8 @@ func (client *Client) Exists(ctx context.Context, key []byte) bool {
 		return false
 	}
 
-	err = client.env.View(func(txn *lmdb.Txn) error {
-		_, txnErr := txn.Get(db, key)
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		_, txnErr := db.get(txn, key)
 		return txnErr
 	})
 
@@ -4,30 +4,74 @@ import (
 	"context"
 	"time"
 
+	"github.com/PowerDNS/lmdb-go/lmdb"
+
 	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
-func (client *Client) Expire(ctx context.Context, keyBytes []byte, secs uint32) {
-	client.mtx.Lock()
-	defer client.mtx.Unlock()
-
-	db, _ := ctx.Value(domain.DB).(uint8)
-	keys, hasKeys := client.ttl[db]
+func (client *Client) Expire(ctx context.Context, key []byte, secs uint32) {
+	deadline := time.Now().Add(time.Duration(secs) * time.Second).UnixMilli()
+	_, _ = client.ExpireAt(ctx, key, deadline, domain.ExpireAlways)
+}
 
-	if !hasKeys {
-		client.ttl[db] = make(map[string]*TTL)
+func (client *Client) ExpireAt(ctx context.Context, key []byte, deadline int64, condition domain.ExpireCondition) (bool, error) {
+	if hasError(ctxFlush(ctx)) {
+		return false, ctx.Err()
 	}
 
-	key := string(keyBytes)
+	if isEmpty(key) {
+		return false, nil
+	}
 
-	if ttl, hasTTL := keys[key]; hasTTL {
-		ttl.Cancel()
+	db, err := client.sel(ctx)
+	if hasError(err) {
+		return false, err
 	}
 
-	client.ttl[db][key] = &TTL{
-		Expire: uint32(time.Now().Unix()) + secs,
-		Cancel: setTimeout(secs, func() {
-			_, _ = client.Del(ctx, keyBytes)
-		}),
+	var applied bool
+
+	err = client.update(ctx, func(txn *lmdb.Txn) error {
+		applied = false
+		_, txnErr := db.fetch(txn, key)
+
+		if isNotFound(txnErr) {
+			return nil
+		}
+
+		if hasError(txnErr) {
+			return txnErr
+		}
+
+		current, hasDeadline := db.deadline(txn, key)
+
+		if !allowsDeadline(condition, current, hasDeadline, deadline) {
+			return nil
+		}
+
+		applied = true
+
+		if isDeadlineReached(deadline) {
+			return db.del(txn, key)
+		}
+
+		return db.setDeadline(txn, key, deadline)
+	})
+
+	return applied, err
+}
+
+// allowsDeadline treats a key without deadline as never expiring for GT and LT.
+func allowsDeadline(condition domain.ExpireCondition, current int64, hasDeadline bool, deadline int64) bool {
+	switch condition {
+	case domain.ExpireNX:
+		return !hasDeadline
+	case domain.ExpireXX:
+		return hasDeadline
+	case domain.ExpireGT:
+		return hasDeadline && deadline > current
+	case domain.ExpireLT:
+		return !hasDeadline || deadline < current
 	}
+
+	return true
 }
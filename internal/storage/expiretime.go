@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+const (
+	noDeadline = -1
+	missingKey = -2
+)
+
+func (client *Client) ExpireTime(ctx context.Context, key []byte) (int64, error) {
+	if hasError(ctxFlush(ctx)) {
+		return missingKey, ctx.Err()
+	}
+
+	db, err := client.sel(ctx)
+	if hasError(err) {
+		return missingKey, err
+	}
+
+	deadline := int64(missingKey)
+
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		_, txnErr := db.peek(txn, key)
+
+		if isNotFound(txnErr) {
+			return nil
+		}
+
+		if hasError(txnErr) {
+			return txnErr
+		}
+
+		current, hasDeadline := db.deadline(txn, key)
+		deadline = noDeadline
+
+		if hasDeadline {
+			deadline = current
+		}
+
+		return nil
+	})
+
+	return deadline, err
+}
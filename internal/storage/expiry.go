@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+const (
+	expiryName     = "exp_%d"
+	expireInterval = 100 * time.Millisecond
+	expireBudget   = 25 * time.Millisecond
+	expireBatch    = 256
+)
+
+func (client *Client) expireLoop() {
+	defer close(client.expireDone)
+
+	ticker := time.NewTicker(expireInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-client.stopExpire:
+			return
+		case <-ticker.C:
+			client.expireCycle()
+		}
+	}
+}
+
+// expireCycle stops once its time budget is used so writers are not starved.
+func (client *Client) expireCycle() {
+	started := time.Now()
+	defer func() {
+		elapsed := int64(time.Since(started))
+		client.cycles.Add(1)
+		client.cycleTime.Add(elapsed)
+		client.lastCycle.Store(elapsed)
+	}()
+
+	for _, space := range client.openSpaces() {
+		for time.Since(started) < expireBudget {
+			reaped, err := client.reap(space)
+
+			if hasError(err) || reaped < expireBatch {
+				break
+			}
+		}
+	}
+}
+
+func (client *Client) openSpaces() []*keyspace {
+	client.mtx.RLock()
+	defer client.mtx.RUnlock()
+
+	spaces := make([]*keyspace, 0, len(client.dbs))
+
+	for _, space := range client.dbs {
+		spaces = append(spaces, space)
+	}
+
+	return spaces
+}
+
+func (client *Client) reap(space *keyspace) (int, error) {
+	var reaped int
+
+	err := client.commit(func(txn *lmdb.Txn) error {
+		reaped = 0
+		keys, err := space.dueKeys(txn, expireBatch)
+
+		for _, key := range keys {
+			if noError(err) {
+				err = space.purge(txn, key)
+				reaped++
+			}
+		}
+
+		return err
+	})
+
+	return reaped, err
+}
+
+func (space *keyspace) dueKeys(txn *lmdb.Txn, limit int) ([][]byte, error) {
+	cursor, err := txn.OpenCursor(space.expiry)
+	if hasError(err) {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var keys [][]byte
+	now := time.Now().UnixMilli()
+
+	for len(keys) < limit {
+		entry, _, cursorErr := cursor.Get(nil, nil, lmdb.Next)
+
+		if isNotFound(cursorErr) {
+			return keys, nil
+		}
+
+		if hasError(cursorErr) {
+			return nil, cursorErr
+		}
+
+		if len(entry) < deadlineSize || int64(binary.BigEndian.Uint64(entry)) > now {
+			return keys, nil
+		}
+
+		keys = append(keys, clone(entry[deadlineSize:]))
+	}
+
+	return keys, nil
+}
+
+func indexDeadlines(txn *lmdb.Txn, index uint8) error {
+	ttl, err := txn.OpenDBI(fmt.Sprintf(ttlName, index), noFlags)
+	if hasError(err) {
+		return err
+	}
+
+	expiry, err := txn.OpenDBI(fmt.Sprintf(expiryName, index), lmdb.Create)
+	if hasError(err) {
+		return err
+	}
+
+	cursor, err := txn.OpenCursor(ttl)
+	if hasError(err) {
+		return err
+	}
+	defer cursor.Close()
+
+	for {
+		key, data, cursorErr := cursor.Get(nil, nil, lmdb.Next)
+
+		if isNotFound(cursorErr) {
+			return nil
+		}
+
+		if hasError(cursorErr) {
+			return cursorErr
+		}
+
+		if len(data) != deadlineSize {
+			continue
+		}
+
+		deadline := int64(binary.BigEndian.Uint64(data))
+
+		if err = txn.Put(expiry, expiryKey(deadline, key), nil, noFlags); hasError(err) {
+			return err
+		}
+	}
+}
@@ -16,8 +16,17 @@ func (client *Client) FlushAll(ctx context.Context) error {
 		return err
 	}
 
-	return client.env.Update(func(txn *lmdb.Txn) error {
-		cursor, cursorErr := txn.OpenCursor(db)
+	defer client.access.forgetAll(db.index)
+	defer client.watches.touchAll(db.index)
+
+	return client.update(ctx, func(txn *lmdb.Txn) error {
+		for _, dbi := range []lmdb.DBI{db.ttl, db.expiry, db.elements, db.scores} {
+			if dropErr := txn.Drop(dbi, false); hasError(dropErr) {
+				return dropErr
+			}
+		}
+
+		cursor, cursorErr := txn.OpenCursor(db.data)
 		if hasError(cursorErr) {
 			return cursorErr
 		}
@@ -20,15 +20,16 @@ func (client *Client) Get(ctx context.Context, key []byte) ([]byte, error) {
 	var result []byte
 	var getErr error
 
-	err = client.env.View(func(txn *lmdb.Txn) error {
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
 		if errFlush := ctxFlush(ctx); hasError(errFlush) {
 			return errFlush
 		}
 
-		val, txnErr := txn.Get(db, key)
+		val, txnErr := db.read(txn, key, kindString)
 
 		if noError(txnErr) {
-			result = val
+			result = make([]byte, len(val))
+			copy(result, val)
 			return nil
 		}
 
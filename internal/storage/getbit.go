@@ -0,0 +1,14 @@
+package storage
+
+import "context"
+
+func (client *Client) GetBit(ctx context.Context, key []byte, offset uint64) (int64, error) {
+	var bit int64
+
+	err := client.viewString(ctx, key, func(payload []byte, _ bool) error {
+		bit = bitAt(payload, offset)
+		return nil
+	})
+
+	return bit, err
+}
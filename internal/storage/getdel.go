@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+func (client *Client) GetDel(ctx context.Context, key []byte) ([]byte, error) {
+	db, err := client.sel(ctx)
+
+	if noError(err) {
+		err = ctxFlush(ctx)
+	}
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	var result []byte
+
+	err = client.update(ctx, func(txn *lmdb.Txn) error {
+		result = nil
+
+		if errFlush := ctxFlush(ctx); hasError(errFlush) {
+			return errFlush
+		}
+
+		val, txnErr := db.load(txn, key, kindString)
+
+		if hasError(txnErr) {
+			return txnErr
+		}
+
+		result = clone(val)
+		return db.del(txn, key)
+	})
+
+	if isNotFound(err) {
+		return nil, ErrKeyNotFound
+	}
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	return result, nil
+}
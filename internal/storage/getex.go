@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+func (client *Client) GetEx(ctx context.Context, key []byte, options domain.GetExOptions) ([]byte, error) {
+	db, err := client.sel(ctx)
+
+	if noError(err) {
+		err = ctxFlush(ctx)
+	}
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	var result []byte
+
+	err = client.update(ctx, func(txn *lmdb.Txn) error {
+		result = nil
+
+		if errFlush := ctxFlush(ctx); hasError(errFlush) {
+			return errFlush
+		}
+
+		val, txnErr := db.load(txn, key, kindString)
+
+		if hasError(txnErr) {
+			return txnErr
+		}
+
+		result = clone(val)
+
+		switch {
+		case options.Persist:
+			return db.clearDeadline(txn, key)
+		case options.Deadline == domain.NoDeadline:
+			return nil
+		case isDeadlineReached(options.Deadline):
+			return db.del(txn, key)
+		}
+
+		return db.setDeadline(txn, key, options.Deadline)
+	})
+
+	if isNotFound(err) {
+		return nil, ErrKeyNotFound
+	}
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,32 @@
+package storage
+
+import "context"
+
+func (client *Client) GetRange(ctx context.Context, key []byte, start, end int64) ([]byte, error) {
+	result := []byte{}
+
+	err := client.viewString(ctx, key, func(payload []byte, _ bool) error {
+		first, last, ok := stringRange(start, end, int64(len(payload)))
+
+		if ok {
+			result = clone(payload[first : last+1])
+		}
+
+		return nil
+	})
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// stringRange clamps a negative end to the first byte, which LRANGE does not.
+func stringRange(start, end, length int64) (int64, int64, bool) {
+	if isNegativeIndex(end) {
+		end = max(length+end, firstElement)
+	}
+
+	return normalizeRange(start, end, length)
+}
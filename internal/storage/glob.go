@@ -0,0 +1,100 @@
+package storage
+
+const (
+	globAny    = '*'
+	globSingle = '?'
+	globOpen   = '['
+	globClose  = ']'
+	globNegate = '^'
+	globRange  = '-'
+	globEscape = '\\'
+)
+
+func matchAll(pattern []byte) bool {
+	return len(pattern) == 0 || (len(pattern) == 1 && pattern[0] == globAny)
+}
+
+func matchGlob(pattern, str []byte) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case globAny:
+			for len(pattern) > 1 && pattern[1] == globAny {
+				pattern = pattern[1:]
+			}
+
+			if len(pattern) == 1 {
+				return true
+			}
+
+			for offset := range len(str) + 1 {
+				if matchGlob(pattern[1:], str[offset:]) {
+					return true
+				}
+			}
+
+			return false
+		case globSingle:
+			if len(str) == 0 {
+				return false
+			}
+		case globOpen:
+			if len(str) == 0 {
+				return false
+			}
+
+			matched, rest := matchClass(pattern[1:], str[0])
+			if !matched {
+				return false
+			}
+
+			pattern, str = rest, str[1:]
+			continue
+		default:
+			if pattern[0] == globEscape && len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+
+			if len(str) == 0 || pattern[0] != str[0] {
+				return false
+			}
+		}
+
+		pattern, str = pattern[1:], str[1:]
+	}
+
+	return len(str) == 0
+}
+
+func matchClass(pattern []byte, char byte) (bool, []byte) {
+	negate := len(pattern) > 0 && pattern[0] == globNegate
+	if negate {
+		pattern = pattern[1:]
+	}
+
+	matched := false
+
+	for len(pattern) > 0 && pattern[0] != globClose {
+		switch {
+		case pattern[0] == globEscape && len(pattern) > 1:
+			pattern = pattern[1:]
+			matched = matched || pattern[0] == char
+		case len(pattern) > 2 && pattern[1] == globRange && pattern[2] != globClose:
+			low, high := pattern[0], pattern[2]
+			if low > high {
+				low, high = high, low
+			}
+			matched = matched || (char >= low && char <= high)
+			pattern = pattern[2:]
+		default:
+			matched = matched || pattern[0] == char
+		}
+
+		pattern = pattern[1:]
+	}
+
+	if len(pattern) > 0 {
+		pattern = pattern[1:]
+	}
+
+	return matched != negate, pattern
+}
@@ -0,0 +1,315 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+	"github.com/luiz-simples/keyp.git/internal/storage"
+)
+
+var _ = Describe("Hash Storage Commands", func() {
+	var (
+		client  *storage.Client
+		ctx     context.Context
+		tempDir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "keyp-test-hash-*")
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err = storage.NewClient(tempDir)
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx = context.WithValue(context.Background(), domain.DB, 0)
+	})
+
+	AfterEach(func() {
+		if client != nil {
+			client.Close()
+		}
+		os.RemoveAll(tempDir)
+	})
+
+	Describe("HSet", func() {
+		It("should count only new fields", func() {
+			added, err := client.HSet(ctx, []byte("hash"), []byte("a"), []byte("1"), []byte("b"), []byte("2"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(added).To(Equal(int64(2)))
+
+			added, err = client.HSet(ctx, []byte("hash"), []byte("a"), []byte("10"), []byte("c"), []byte("3"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(added).To(Equal(int64(1)))
+
+			value, err := client.HGet(ctx, []byte("hash"), []byte("a"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal([]byte("10")))
+		})
+
+		It("should keep empty values", func() {
+			client.HSet(ctx, []byte("hash"), []byte("empty"), []byte(""))
+
+			value, err := client.HGet(ctx, []byte("hash"), []byte("empty"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).NotTo(BeNil())
+			Expect(value).To(BeEmpty())
+		})
+
+		It("should ignore unpaired arguments", func() {
+			added, err := client.HSet(ctx, []byte("hash"), []byte("a"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(added).To(Equal(int64(0)))
+			Expect(client.Exists(ctx, []byte("hash"))).To(BeFalse())
+		})
+	})
+
+	Describe("HSetNX", func() {
+		It("should only set missing fields", func() {
+			created, err := client.HSetNX(ctx, []byte("hash"), []byte("a"), []byte("1"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			created, err = client.HSetNX(ctx, []byte("hash"), []byte("a"), []byte("2"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeFalse())
+
+			value, _ := client.HGet(ctx, []byte("hash"), []byte("a"))
+			Expect(value).To(Equal([]byte("1")))
+		})
+	})
+
+	Describe("HGet and HMGet", func() {
+		BeforeEach(func() {
+			client.HSet(ctx, []byte("hash"), []byte("a"), []byte("1"), []byte("b"), []byte("2"))
+		})
+
+		It("should report missing fields and keys", func() {
+			_, err := client.HGet(ctx, []byte("hash"), []byte("missing"))
+			Expect(err).To(Equal(storage.ErrKeyNotFound))
+
+			_, err = client.HGet(ctx, []byte("nokey"), []byte("a"))
+			Expect(err).To(Equal(storage.ErrKeyNotFound))
+		})
+
+		It("should return nil entries for missing fields", func() {
+			values, err := client.HMGet(ctx, []byte("hash"), []byte("b"), []byte("missing"), []byte("a"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([][]byte{[]byte("2"), nil, []byte("1")}))
+
+			values, err = client.HMGet(ctx, []byte("nokey"), []byte("a"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([][]byte{nil}))
+		})
+	})
+
+	Describe("HDel, HExists, HLen and HStrLen", func() {
+		BeforeEach(func() {
+			client.HSet(ctx, []byte("hash"), []byte("a"), []byte("one"), []byte("b"), []byte("two"))
+		})
+
+		It("should inspect fields", func() {
+			Expect(client.HExists(ctx, []byte("hash"), []byte("a"))).To(BeTrue())
+			Expect(client.HExists(ctx, []byte("hash"), []byte("z"))).To(BeFalse())
+			Expect(client.HLen(ctx, []byte("hash"))).To(Equal(int64(2)))
+			Expect(client.HStrLen(ctx, []byte("hash"), []byte("b"))).To(Equal(int64(3)))
+			Expect(client.HStrLen(ctx, []byte("hash"), []byte("z"))).To(Equal(int64(0)))
+			Expect(client.HLen(ctx, []byte("nokey"))).To(Equal(int64(0)))
+		})
+
+		It("should remove the key with its last field", func() {
+			removed, err := client.HDel(ctx, []byte("hash"), []byte("a"), []byte("z"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(Equal(int64(1)))
+
+			removed, err = client.HDel(ctx, []byte("hash"), []byte("b"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(Equal(int64(1)))
+			Expect(client.Exists(ctx, []byte("hash"))).To(BeFalse())
+
+			removed, err = client.HDel(ctx, []byte("hash"), []byte("b"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(Equal(int64(0)))
+		})
+	})
+
+	Describe("HKeys, HVals and HGetAll", func() {
+		It("should list fields and values in insertion order", func() {
+			client.HSet(ctx, []byte("hash"), []byte("a"), []byte("1"), []byte("b"), []byte("2"))
+
+			Expect(client.HKeys(ctx, []byte("hash"))).To(Equal([][]byte{[]byte("a"), []byte("b")}))
+			Expect(client.HVals(ctx, []byte("hash"))).To(Equal([][]byte{[]byte("1"), []byte("2")}))
+			Expect(client.HGetAll(ctx, []byte("hash"))).To(Equal([][]byte{[]byte("a"), []byte("1"), []byte("b"), []byte("2")}))
+		})
+
+		It("should return empty lists for missing keys", func() {
+			Expect(client.HKeys(ctx, []byte("nokey"))).To(BeEmpty())
+			Expect(client.HGetAll(ctx, []byte("nokey"))).To(BeEmpty())
+		})
+	})
+
+	Describe("HIncrBy and HIncrByFloat", func() {
+		It("should increment integers", func() {
+			Expect(client.HIncrBy(ctx, []byte("hash"), []byte("n"), 5)).To(Equal(int64(5)))
+			Expect(client.HIncrBy(ctx, []byte("hash"), []byte("n"), -7)).To(Equal(int64(-2)))
+		})
+
+		It("should reject non-integer values and overflow", func() {
+			client.HSet(ctx, []byte("hash"), []byte("s"), []byte("abc"), []byte("max"), []byte(fmt.Sprint(int64(math.MaxInt64))))
+
+			_, err := client.HIncrBy(ctx, []byte("hash"), []byte("s"), 1)
+			Expect(err).To(Equal(storage.ErrHashNotInteger))
+
+			_, err = client.HIncrBy(ctx, []byte("hash"), []byte("max"), 1)
+			Expect(err).To(Equal(storage.ErrOverflow))
+
+			value, _ := client.HGet(ctx, []byte("hash"), []byte("max"))
+			Expect(value).To(Equal([]byte(fmt.Sprint(int64(math.MaxInt64)))))
+		})
+
+		It("should increment floats", func() {
+			Expect(client.HIncrByFloat(ctx, []byte("hash"), []byte("f"), 10.5)).To(Equal([]byte("10.5")))
+			Expect(client.HIncrByFloat(ctx, []byte("hash"), []byte("f"), 0.1)).To(Equal([]byte("10.6")))
+			Expect(client.HIncrByFloat(ctx, []byte("hash"), []byte("f"), -10.4)).To(Equal([]byte("0.2")))
+
+			value, _ := client.HGet(ctx, []byte("hash"), []byte("f"))
+			Expect(value).To(Equal([]byte("0.2")))
+
+			client.HSet(ctx, []byte("hash"), []byte("s"), []byte("abc"))
+			_, err := client.HIncrByFloat(ctx, []byte("hash"), []byte("s"), 1)
+			Expect(err).To(Equal(storage.ErrHashNotFloat))
+
+			_, err = client.HIncrByFloat(ctx, []byte("hash"), []byte("f"), math.Inf(1))
+			Expect(err).To(Equal(storage.ErrNotFinite))
+		})
+	})
+
+	Describe("HScan", func() {
+		It("should return a small hash in one pass", func() {
+			client.HSet(ctx, []byte("hash"), []byte("a"), []byte("1"), []byte("b"), []byte("2"))
+
+			next, items, err := client.HScan(ctx, []byte("hash"), nil, nil, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(BeNil())
+			Expect(items).To(HaveLen(4))
+		})
+
+		It("should page through a large hash with a pattern", func() {
+			client.SetMaxPackedEntries(4)
+
+			for i := range 20 {
+				client.HSet(ctx, []byte("hash"), []byte(fmt.Sprintf("field:%02d", i)), []byte(fmt.Sprint(i)))
+			}
+			client.HSet(ctx, []byte("hash"), []byte("other"), []byte("x"))
+
+			seen := map[string]string{}
+			var after []byte
+
+			for {
+				next, items, err := client.HScan(ctx, []byte("hash"), after, []byte("field:*"), 3)
+				Expect(err).NotTo(HaveOccurred())
+
+				for i := 0; i < len(items); i += 2 {
+					seen[string(items[i])] = string(items[i+1])
+				}
+
+				if next == nil {
+					break
+				}
+				after = next
+			}
+
+			Expect(seen).To(HaveLen(20))
+			Expect(seen["field:07"]).To(Equal("7"))
+		})
+	})
+
+	Describe("Per-element layout", func() {
+		BeforeEach(func() {
+			client.SetMaxPackedEntries(4)
+		})
+
+		It("should convert past the threshold and keep hash semantics", func() {
+			client.HSet(ctx, []byte("hash"), []byte("a"), []byte("1"), []byte("b"), []byte("2"))
+
+			encoding, err := client.Encoding(ctx, []byte("hash"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoding).To(Equal("listpack"))
+
+			client.HSet(ctx, []byte("hash"), []byte("c"), []byte("3"), []byte("d"), []byte("4"), []byte("e"), []byte("5"))
+
+			encoding, err = client.Encoding(ctx, []byte("hash"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoding).To(Equal("hashtable"))
+
+			Expect(client.HLen(ctx, []byte("hash"))).To(Equal(int64(5)))
+			Expect(client.HIncrBy(ctx, []byte("hash"), []byte("c"), 4)).To(Equal(int64(7)))
+			Expect(client.HDel(ctx, []byte("hash"), []byte("a"))).To(Equal(int64(1)))
+			Expect(client.HKeys(ctx, []byte("hash"))).To(ConsistOf([]byte("b"), []byte("c"), []byte("d"), []byte("e")))
+		})
+
+		It("should keep fields longer than an LMDB key in both encodings", func() {
+			long := bytes.Repeat([]byte("f"), 520)
+
+			Expect(client.HSet(ctx, []byte("hash"), long, []byte("long"))).To(Equal(int64(1)))
+			Expect(client.HGet(ctx, []byte("hash"), long)).To(Equal([]byte("long")))
+
+			Expect(client.HSet(ctx, []byte("hash"), []byte("a"), []byte("1"), []byte("b"), []byte("2"),
+				[]byte("c"), []byte("3"), []byte("d"), []byte("4"))).To(Equal(int64(4)))
+			Expect(client.Encoding(ctx, []byte("hash"))).To(Equal("hashtable"))
+
+			Expect(client.HSet(ctx, []byte("hash"), long, []byte("longer"))).To(Equal(int64(0)))
+			Expect(client.HGet(ctx, []byte("hash"), long)).To(Equal([]byte("longer")))
+			Expect(client.HLen(ctx, []byte("hash"))).To(Equal(int64(5)))
+			Expect(client.HKeys(ctx, []byte("hash"))).To(ContainElement(long))
+
+			Expect(client.HDel(ctx, []byte("hash"), long)).To(Equal(int64(1)))
+			Expect(client.HExists(ctx, []byte("hash"), long)).To(BeFalse())
+		})
+
+		It("should drop fields when the key is deleted or overwritten", func() {
+			client.HSet(ctx, []byte("hash"), []byte("a"), []byte("1"), []byte("b"), []byte("2"), []byte("c"), []byte("3"),
+				[]byte("d"), []byte("4"), []byte("e"), []byte("5"))
+
+			Expect(client.Set(ctx, []byte("hash"), []byte("plain"))).To(Succeed())
+			client.Del(ctx, []byte("hash"))
+
+			client.HSet(ctx, []byte("hash"), []byte("z"), []byte("26"))
+			Expect(client.HGetAll(ctx, []byte("hash"))).To(Equal([][]byte{[]byte("z"), []byte("26")}))
+		})
+	})
+
+	Describe("Type enforcement", func() {
+		It("should not write hash fields into a string", func() {
+			Expect(client.Set(ctx, []byte("str"), []byte("value"))).To(Succeed())
+
+			_, err := client.HSet(ctx, []byte("str"), []byte("a"), []byte("1"))
+			Expect(err).To(Equal(storage.ErrWrongType))
+
+			_, err = client.HGet(ctx, []byte("str"), []byte("a"))
+			Expect(err).To(Equal(storage.ErrWrongType))
+
+			_, err = client.HIncrBy(ctx, []byte("str"), []byte("a"), 1)
+			Expect(err).To(Equal(storage.ErrWrongType))
+		})
+
+		It("should not read a hash as another type", func() {
+			client.HSet(ctx, []byte("hash"), []byte("a"), []byte("1"))
+
+			_, err := client.Get(ctx, []byte("hash"))
+			Expect(err).To(Equal(storage.ErrWrongType))
+
+			_, err = client.SMembers(ctx, []byte("hash"))
+			Expect(err).To(Equal(storage.ErrWrongType))
+
+			Expect(client.Type(ctx, []byte("hash"))).To(Equal("hash"))
+		})
+	})
+})
@@ -0,0 +1,303 @@
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"slices"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+const (
+	hashMetaSize = integerSize
+	pairSize     = 2
+)
+
+type (
+	hashStore interface {
+		size() int64
+		get([]byte) ([]byte, bool, error)
+		set([]byte, []byte) (bool, error)
+		remove([]byte) (bool, error)
+		walk([]byte, func(field, value []byte) bool) error
+		commit() error
+	}
+
+	packedHash struct {
+		space  *keyspace
+		txn    *lmdb.Txn
+		key    []byte
+		fields [][]byte
+		values [][]byte
+	}
+
+	tableHash struct {
+		space  *keyspace
+		txn    *lmdb.Txn
+		key    []byte
+		prefix []byte
+		count  int64
+	}
+)
+
+func (space *keyspace) openHash(txn *lmdb.Txn, key []byte, write bool) (hashStore, error) {
+	encoding, payload, err := space.open(txn, key, kindHash, write)
+	if hasError(err) {
+		return nil, err
+	}
+
+	if encoding == encodingTable {
+		return space.tableHash(txn, key, payload)
+	}
+
+	items, err := decodeItems(payload)
+	if hasError(err) || len(items)%pairSize != emptyCount {
+		return nil, ErrKeyNotFound
+	}
+
+	hash := &packedHash{space: space, txn: txn, key: key}
+
+	for index := 0; index < len(items); index += pairSize {
+		hash.fields = append(hash.fields, items[index])
+		hash.values = append(hash.values, items[index+1])
+	}
+
+	return hash, nil
+}
+
+func (space *keyspace) createHash(txn *lmdb.Txn, key []byte) (hashStore, error) {
+	hash, err := space.openHash(txn, key, true)
+
+	if isNotFound(err) {
+		return &packedHash{space: space, txn: txn, key: key}, nil
+	}
+
+	return hash, err
+}
+
+func (space *keyspace) tableHash(txn *lmdb.Txn, key, payload []byte) (*tableHash, error) {
+	if len(payload) < hashMetaSize {
+		return nil, ErrKeyNotFound
+	}
+
+	return &tableHash{
+		space:  space,
+		txn:    txn,
+		key:    key,
+		prefix: elementPrefix(key),
+		count:  int64(binary.LittleEndian.Uint64(payload)),
+	}, nil
+}
+
+func (hash *packedHash) size() int64 {
+	return int64(len(hash.fields))
+}
+
+func (hash *packedHash) index(field []byte) int {
+	return slices.IndexFunc(hash.fields, func(candidate []byte) bool {
+		return string(candidate) == string(field)
+	})
+}
+
+func (hash *packedHash) get(field []byte) ([]byte, bool, error) {
+	index := hash.index(field)
+
+	if index < firstElement {
+		return nil, false, nil
+	}
+
+	return hash.values[index], true, nil
+}
+
+func (hash *packedHash) set(field, value []byte) (bool, error) {
+	index := hash.index(field)
+
+	if index >= firstElement {
+		hash.values[index] = value
+		return false, nil
+	}
+
+	hash.fields = append(hash.fields, field)
+	hash.values = append(hash.values, value)
+	return true, nil
+}
+
+func (hash *packedHash) remove(field []byte) (bool, error) {
+	index := hash.index(field)
+
+	if index < firstElement {
+		return false, nil
+	}
+
+	hash.fields = slices.Delete(hash.fields, index, index+1)
+	hash.values = slices.Delete(hash.values, index, index+1)
+	return true, nil
+}
+
+func (hash *packedHash) walk(_ []byte, fn func(field, value []byte) bool) error {
+	for index, field := range hash.fields {
+		if !fn(field, hash.values[index]) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (hash *packedHash) commit() error {
+	if len(hash.fields) == emptyCount {
+		return hash.space.del(hash.txn, hash.key)
+	}
+
+	if hash.space.keepsPacked(hash.key, hash.size()) {
+		items := make([][]byte, 0, len(hash.fields)*pairSize)
+
+		for index, field := range hash.fields {
+			items = append(items, field, hash.values[index])
+		}
+
+		return hash.space.put(hash.txn, hash.key, kindHash, encodingPacked, encodeItems(items))
+	}
+
+	table := &tableHash{space: hash.space, txn: hash.txn, key: hash.key, prefix: elementPrefix(hash.key)}
+
+	for index, field := range hash.fields {
+		if _, err := table.set(field, hash.values[index]); hasError(err) {
+			return err
+		}
+	}
+
+	return table.commit()
+}
+
+func (hash *tableHash) size() int64 {
+	return hash.count
+}
+
+func (hash *tableHash) get(field []byte) ([]byte, bool, error) {
+	value, found, err := hash.space.getMember(hash.txn, hash.space.elements, hash.prefix, field)
+
+	if !found || hasError(err) {
+		return nil, false, err
+	}
+
+	return clone(value), true, nil
+}
+
+func (hash *tableHash) set(field, value []byte) (bool, error) {
+	_, found, err := hash.get(field)
+
+	if noError(err) {
+		err = hash.space.putMember(hash.txn, hash.space.elements, hash.prefix, field, value)
+	}
+
+	if hasError(err) {
+		return false, err
+	}
+
+	if !found {
+		hash.count++
+	}
+
+	return !found, nil
+}
+
+func (hash *tableHash) remove(field []byte) (bool, error) {
+	_, found, err := hash.get(field)
+
+	if found {
+		err = hash.txn.Del(hash.space.elements, memberKey(hash.prefix, field), nil)
+	}
+
+	if !found || hasError(err) {
+		return false, err
+	}
+
+	hash.count--
+	return true, nil
+}
+
+func (hash *tableHash) walk(after []byte, fn func(field, value []byte) bool) error {
+	from := memberKey(hash.prefix, after)
+
+	return hash.space.eachElement(hash.txn, hash.space.elements, from, hash.prefix, func(suffix, entry []byte) bool {
+		field, value := unpackEntry(hash.prefix, suffix, entry)
+
+		if len(after) > 0 && string(field) == string(after) {
+			return true
+		}
+
+		return fn(clone(field), clone(value))
+	})
+}
+
+func (hash *tableHash) commit() error {
+	if hash.count == emptyCount {
+		return hash.space.del(hash.txn, hash.key)
+	}
+
+	meta := make([]byte, hashMetaSize)
+	binary.LittleEndian.PutUint64(meta, uint64(hash.count))
+
+	return hash.space.put(hash.txn, hash.key, kindHash, encodingTable, meta)
+}
+
+func (client *Client) viewHash(ctx context.Context, key []byte, fn func(hashStore) error) error {
+	if hasError(ctxFlush(ctx)) {
+		return ctx.Err()
+	}
+
+	if isEmpty(key) {
+		return ErrKeyNotFound
+	}
+
+	db, err := client.sel(ctx)
+	if hasError(err) {
+		return err
+	}
+
+	return client.view(ctx, func(txn *lmdb.Txn) error {
+		hash, txnErr := db.openHash(txn, key, false)
+		if hasError(txnErr) {
+			return txnErr
+		}
+
+		return fn(hash)
+	})
+}
+
+func (client *Client) updateHash(ctx context.Context, key []byte, create bool, fn func(hashStore) error) error {
+	if hasError(ctxFlush(ctx)) {
+		return ctx.Err()
+	}
+
+	if isEmpty(key) {
+		return ErrKeyNotFound
+	}
+
+	db, err := client.sel(ctx)
+	if hasError(err) {
+		return err
+	}
+
+	return client.update(ctx, func(txn *lmdb.Txn) error {
+		var hash hashStore
+		var txnErr error
+
+		if create {
+			hash, txnErr = db.createHash(txn, key)
+		} else {
+			hash, txnErr = db.openHash(txn, key, true)
+		}
+
+		if hasError(txnErr) {
+			return txnErr
+		}
+
+		if txnErr = fn(hash); hasError(txnErr) {
+			return txnErr
+		}
+
+		return hash.commit()
+	})
+}
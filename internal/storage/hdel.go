@@ -0,0 +1,34 @@
+package storage
+
+import "context"
+
+func (client *Client) HDel(ctx context.Context, key []byte, fields ...[]byte) (int64, error) {
+	var removed int64
+
+	err := client.updateHash(ctx, key, false, func(hash hashStore) error {
+		removed = emptyCount
+
+		for _, field := range fields {
+			deleted, err := hash.remove(field)
+			if hasError(err) {
+				return err
+			}
+
+			if deleted {
+				removed++
+			}
+		}
+
+		return nil
+	})
+
+	if isNotFound(err) {
+		return emptyCount, nil
+	}
+
+	if hasError(err) {
+		return emptyCount, err
+	}
+
+	return removed, nil
+}
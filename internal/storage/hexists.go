@@ -0,0 +1,19 @@
+package storage
+
+import "context"
+
+func (client *Client) HExists(ctx context.Context, key, field []byte) (bool, error) {
+	var found bool
+
+	err := client.viewHash(ctx, key, func(hash hashStore) error {
+		var err error
+		_, found, err = hash.get(field)
+		return err
+	})
+
+	if isNotFound(err) {
+		return false, nil
+	}
+
+	return found, err
+}
@@ -0,0 +1,28 @@
+package storage
+
+import "context"
+
+func (client *Client) HGet(ctx context.Context, key, field []byte) ([]byte, error) {
+	var value []byte
+
+	err := client.viewHash(ctx, key, func(hash hashStore) error {
+		stored, found, err := hash.get(field)
+
+		if noError(err) && !found {
+			return ErrKeyNotFound
+		}
+
+		value = clone(stored)
+		return err
+	})
+
+	if isNotFound(err) {
+		return nil, ErrKeyNotFound
+	}
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	return value, nil
+}
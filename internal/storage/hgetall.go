@@ -0,0 +1,29 @@
+package storage
+
+import "context"
+
+func (client *Client) HGetAll(ctx context.Context, key []byte) ([][]byte, error) {
+	return client.collectHash(ctx, key, func(field, value []byte) [][]byte {
+		return [][]byte{field, value}
+	})
+}
+
+func (client *Client) collectHash(ctx context.Context, key []byte, pick func(field, value []byte) [][]byte) ([][]byte, error) {
+	items := [][]byte{}
+
+	err := client.viewHash(ctx, key, func(hash hashStore) error {
+		return hash.walk(nil, func(field, value []byte) bool {
+			for _, item := range pick(field, value) {
+				items = append(items, clone(item))
+			}
+
+			return true
+		})
+	})
+
+	if hasError(err) && !isNotFound(err) {
+		return nil, err
+	}
+
+	return items, nil
+}
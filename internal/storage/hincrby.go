@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"strconv"
+)
+
+func (client *Client) HIncrBy(ctx context.Context, key, field []byte, delta int64) (int64, error) {
+	var result int64
+
+	err := client.updateHash(ctx, key, true, func(hash hashStore) error {
+		value, found, err := hash.get(field)
+		if hasError(err) {
+			return err
+		}
+
+		var current int64
+
+		if found {
+			current, err = strconv.ParseInt(string(value), 10, 64)
+		}
+
+		if hasError(err) {
+			return ErrHashNotInteger
+		}
+
+		updated, inRange := addInt64(current, delta)
+		if !inRange {
+			return ErrOverflow
+		}
+
+		result = updated
+		_, err = hash.set(field, []byte(strconv.FormatInt(result, 10)))
+		return err
+	})
+
+	if hasError(err) {
+		return emptyCount, err
+	}
+
+	return result, nil
+}
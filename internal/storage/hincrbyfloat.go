@@ -0,0 +1,37 @@
+package storage
+
+import "context"
+
+func (client *Client) HIncrByFloat(ctx context.Context, key, field []byte, delta float64) ([]byte, error) {
+	var result []byte
+
+	err := client.updateHash(ctx, key, true, func(hash hashStore) error {
+		value, found, err := hash.get(field)
+		if hasError(err) {
+			return err
+		}
+
+		current := []byte(zeroFloat)
+
+		if found {
+			current = value
+		}
+
+		if _, valid := parseFloat(current); !valid {
+			return ErrHashNotFloat
+		}
+
+		if result, err = addFloat(current, delta); hasError(err) {
+			return err
+		}
+
+		_, err = hash.set(field, result)
+		return err
+	})
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	return result, nil
+}
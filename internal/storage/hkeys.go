@@ -0,0 +1,9 @@
+package storage
+
+import "context"
+
+func (client *Client) HKeys(ctx context.Context, key []byte) ([][]byte, error) {
+	return client.collectHash(ctx, key, func(field, _ []byte) [][]byte {
+		return [][]byte{field}
+	})
+}
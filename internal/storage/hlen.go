@@ -0,0 +1,18 @@
+package storage
+
+import "context"
+
+func (client *Client) HLen(ctx context.Context, key []byte) (int64, error) {
+	var length int64
+
+	err := client.viewHash(ctx, key, func(hash hashStore) error {
+		length = hash.size()
+		return nil
+	})
+
+	if isNotFound(err) {
+		return emptyCount, nil
+	}
+
+	return length, err
+}
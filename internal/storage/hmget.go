@@ -0,0 +1,28 @@
+package storage
+
+import "context"
+
+func (client *Client) HMGet(ctx context.Context, key []byte, fields ...[]byte) ([][]byte, error) {
+	values := make([][]byte, len(fields))
+
+	err := client.viewHash(ctx, key, func(hash hashStore) error {
+		for index, field := range fields {
+			value, found, err := hash.get(field)
+			if hasError(err) {
+				return err
+			}
+
+			if found {
+				values[index] = clone(value)
+			}
+		}
+
+		return nil
+	})
+
+	if hasError(err) && !isNotFound(err) {
+		return nil, err
+	}
+
+	return values, nil
+}
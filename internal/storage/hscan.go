@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+func (client *Client) HScan(ctx context.Context, key, after, pattern []byte, count int64) ([]byte, [][]byte, error) {
+	if hasError(ctxFlush(ctx)) {
+		return nil, nil, ctx.Err()
+	}
+
+	db, err := client.sel(ctx)
+	if hasError(err) {
+		return nil, nil, err
+	}
+
+	if count < 1 {
+		count = defaultScanCount
+	}
+
+	items := make([][]byte, 0)
+	var last []byte
+
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		hash, txnErr := db.openHash(txn, key, false)
+		if hasError(txnErr) {
+			return txnErr
+		}
+
+		_, paged := hash.(*tableHash)
+		var examined int64
+
+		return hash.walk(after, func(field, value []byte) bool {
+			if matchAll(pattern) || matchGlob(pattern, field) {
+				items = append(items, clone(field), clone(value))
+			}
+
+			examined++
+
+			if !paged || examined < count {
+				return true
+			}
+
+			last = clone(field)
+			return false
+		})
+	})
+
+	if isNotFound(err) {
+		return nil, items, nil
+	}
+
+	if hasError(err) {
+		return nil, nil, err
+	}
+
+	return last, items, nil
+}
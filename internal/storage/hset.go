@@ -0,0 +1,34 @@
+package storage
+
+import "context"
+
+func (client *Client) HSet(ctx context.Context, key []byte, pairs ...[]byte) (int64, error) {
+	if isEmpty(pairs) || len(pairs)%pairSize != emptyCount {
+		return emptyCount, nil
+	}
+
+	var added int64
+
+	err := client.updateHash(ctx, key, true, func(hash hashStore) error {
+		added = emptyCount
+
+		for index := 0; index < len(pairs); index += pairSize {
+			created, err := hash.set(pairs[index], pairs[index+1])
+			if hasError(err) {
+				return err
+			}
+
+			if created {
+				added++
+			}
+		}
+
+		return nil
+	})
+
+	if hasError(err) {
+		return emptyCount, err
+	}
+
+	return added, nil
+}
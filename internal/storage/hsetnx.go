@@ -0,0 +1,24 @@
+package storage
+
+import "context"
+
+func (client *Client) HSetNX(ctx context.Context, key, field, value []byte) (bool, error) {
+	var created bool
+
+	err := client.updateHash(ctx, key, true, func(hash hashStore) error {
+		created = false
+		_, found, err := hash.get(field)
+
+		if noError(err) && !found {
+			created, err = hash.set(field, value)
+		}
+
+		return err
+	})
+
+	if hasError(err) {
+		return false, err
+	}
+
+	return created, nil
+}
@@ -0,0 +1,19 @@
+package storage
+
+import "context"
+
+func (client *Client) HStrLen(ctx context.Context, key, field []byte) (int64, error) {
+	var length int64
+
+	err := client.viewHash(ctx, key, func(hash hashStore) error {
+		value, _, err := hash.get(field)
+		length = int64(len(value))
+		return err
+	})
+
+	if isNotFound(err) {
+		return emptyCount, nil
+	}
+
+	return length, err
+}
@@ -0,0 +1,9 @@
+package storage
+
+import "context"
+
+func (client *Client) HVals(ctx context.Context, key []byte) ([][]byte, error) {
+	return client.collectHash(ctx, key, func(_, value []byte) [][]byte {
+		return [][]byte{value}
+	})
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+func (client *Client) IncrByFloat(ctx context.Context, key []byte, delta float64) ([]byte, error) {
+	var result []byte
+
+	err := client.updateString(ctx, key, func(payload []byte, found bool) ([]byte, error) {
+		current := []byte(zeroFloat)
+
+		if found {
+			current = payload
+		}
+
+		if _, valid := parseFloat(current); !valid {
+			return nil, domain.ErrInvalidFloat
+		}
+
+		sum, err := addFloat(current, delta)
+		if hasError(err) {
+			return nil, err
+		}
+
+		result = sum
+		return sum, nil
+	})
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
+)
+
+func (client *Client) Info(ctx context.Context) (domain.StorageInfo, error) {
+	var info domain.StorageInfo
+
+	if hasError(ctxFlush(ctx)) {
+		return info, ctx.Err()
+	}
+
+	client.gate.RLock()
+	defer client.gate.RUnlock()
+
+	if client.closed {
+		return info, ErrClosed
+	}
+
+	envInfo, err := client.env.Info()
+	if hasError(err) {
+		return info, err
+	}
+
+	envStat, err := client.env.Stat()
+	if hasError(err) {
+		return info, err
+	}
+
+	info.MapSize = envInfo.MapSize
+	info.PageSize = int64(envStat.PSize)
+	info.UsedPages = envInfo.LastPNO + 1
+	info.UsedBytes = info.UsedPages * info.PageSize
+	info.LastTxnID = envInfo.LastTxnID
+	info.MaxReaders = int64(envInfo.MaxReaders)
+	info.Readers = int64(envInfo.NumReaders)
+	info.ExpiredKeys = client.expired.Load()
+	info.ExpireRuns = client.cycles.Load()
+	info.ExpireTime = time.Duration(client.cycleTime.Load())
+	info.ExpireLast = time.Duration(client.lastCycle.Load())
+	info.LastSync = client.LastSync()
+	info.SyncFailed = client.failed.Load()
+
+	indexes, err := client.storedDatabases(ctx)
+	if hasError(err) {
+		return info, err
+	}
+
+	for _, index := range indexes {
+		keyspace, statErr := client.keyspaceInfo(ctx, index)
+
+		if hasError(statErr) {
+			return info, statErr
+		}
+
+		if keyspace.Keys > emptyCount {
+			info.Keyspaces = append(info.Keyspaces, keyspace)
+		}
+	}
+
+	return info, nil
+}
+
+func (client *Client) storedDatabases(ctx context.Context) ([]uint8, error) {
+	var indexes []uint8
+
+	err := client.view(ctx, func(txn *lmdb.Txn) error {
+		names, err := databaseNames(txn)
+
+		for index := range client.databases {
+			if slices.Contains(names, fmt.Sprintf(dataName, index)) {
+				indexes = append(indexes, uint8(index))
+			}
+		}
+
+		return err
+	})
+
+	return indexes, err
+}
+
+// keyspaceInfo goes through sel so that inside MULTI it reads the running transaction.
+func (client *Client) keyspaceInfo(ctx context.Context, index uint8) (domain.KeyspaceInfo, error) {
+	info := domain.KeyspaceInfo{DB: index}
+	ctx = context.WithValue(ctx, domain.DB, index)
+	space, err := client.sel(ctx)
+
+	if hasError(err) {
+		return info, err
+	}
+
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		data, statErr := txn.Stat(space.data)
+		if hasError(statErr) {
+			return statErr
+		}
+
+		ttl, statErr := txn.Stat(space.ttl)
+		if hasError(statErr) {
+			return statErr
+		}
+
+		info.Keys = int64(data.Entries)
+		info.Expires = int64(ttl.Entries)
+		return nil
+	})
+
+	return info, err
+}
@@ -2,10 +2,13 @@ package storage_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/PowerDNS/lmdb-go/lmdb"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -208,6 +211,95 @@ var _ = Describe("Storage Integration Tests", Label("integration"), func() {
 				Expect(ttl).To(Equal(uint32(0)))
 			})
 		})
+
+		Context("when the client is reopened", func() {
+			It("should keep TTL values across restarts", func() {
+				key := []byte("restart-ttl-key")
+
+				err := client.Set(ctx, key, []byte("restart-ttl-value"))
+				Expect(err).NotTo(HaveOccurred())
+
+				client.Expire(ctx, key, 3600)
+				client.Close()
+
+				client, err = storage.NewClient(testDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				ttl := client.TTL(ctx, key)
+				Expect(ttl).To(BeNumerically(">", 3590))
+				Expect(ttl).To(BeNumerically("<=", 3600))
+			})
+
+			It("should not return keys that expired while closed", func() {
+				key := []byte("restart-expired-key")
+
+				err := client.Set(ctx, key, []byte("restart-expired-value"))
+				Expect(err).NotTo(HaveOccurred())
+
+				client.Expire(ctx, key, 1)
+				client.Close()
+
+				time.Sleep(1100 * time.Millisecond)
+
+				client, err = storage.NewClient(testDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.Get(ctx, key)
+				Expect(err).To(Equal(storage.ErrKeyNotFound))
+				Expect(client.Exists(ctx, key)).To(BeFalse())
+				Expect(client.TTL(ctx, key)).To(Equal(uint32(0)))
+			})
+		})
+
+		Context("when a key expires", func() {
+			It("should hide expired collections from readers", func() {
+				listKey := []byte("expired-list")
+				setKey := []byte("expired-set")
+				zsetKey := []byte("expired-zset")
+
+				client.RPush(ctx, listKey, []byte("a"), []byte("b"))
+				client.SAdd(ctx, setKey, []byte("member"))
+				client.ZAdd(ctx, zsetKey, 1, []byte("member"))
+
+				client.Expire(ctx, listKey, 0)
+				client.Expire(ctx, setKey, 0)
+				client.Expire(ctx, zsetKey, 0)
+
+				Expect(client.LLen(ctx, listKey)).To(Equal(int64(0)))
+				Expect(client.LRange(ctx, listKey, 0, -1)).To(BeEmpty())
+				Expect(client.SMembers(ctx, setKey)).To(BeEmpty())
+				Expect(client.SIsMember(ctx, setKey, []byte("member"))).To(BeFalse())
+				Expect(client.ZRange(ctx, zsetKey, 0, -1)).To(BeEmpty())
+				Expect(client.ZCount(ctx, zsetKey, 0, 10)).To(Equal(int64(0)))
+			})
+
+			It("should not carry the TTL over to a recreated key", func() {
+				key := []byte("recreated-key")
+
+				err := client.Set(ctx, key, []byte("first"))
+				Expect(err).NotTo(HaveOccurred())
+
+				client.Expire(ctx, key, 3600)
+
+				deleted, err := client.Del(ctx, key)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deleted).To(Equal(uint32(1)))
+
+				err = client.Set(ctx, key, []byte("second"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(client.TTL(ctx, key)).To(Equal(uint32(0)))
+			})
+
+			It("should ignore expire on a missing key", func() {
+				key := []byte("missing-expire-key")
+
+				client.Expire(ctx, key, 3600)
+
+				Expect(client.TTL(ctx, key)).To(Equal(uint32(0)))
+				Expect(client.Persist(ctx, key)).To(BeFalse())
+			})
+		})
 	})
 
 	Describe("Multi-Database Operations", func() {
@@ -337,6 +429,215 @@ var _ = Describe("Storage Integration Tests", Label("integration"), func() {
 		})
 	})
 
+	Describe("Atomic Transactions", func() {
+		Context("when running several operations in one transaction", func() {
+			It("should commit all writes together", func() {
+				err := client.Atomic(ctx, func(txCtx context.Context) error {
+					Expect(client.Set(txCtx, []byte("a"), []byte("1"))).To(Succeed())
+					Expect(client.Incr(txCtx, []byte("a"))).To(Equal(int64(2)))
+					Expect(client.RPush(txCtx, []byte("list"), []byte("x"), []byte("y"))).To(Equal(int64(2)))
+
+					value, err := client.Get(txCtx, []byte("a"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(value).To(Equal([]byte("2")))
+					return nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				value, err := client.Get(ctx, []byte("a"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(value).To(Equal([]byte("2")))
+				Expect(client.LLen(ctx, []byte("list"))).To(Equal(int64(2)))
+			})
+
+			It("should roll back every write when the transaction fails", func() {
+				Expect(client.Set(ctx, []byte("a"), []byte("before"))).To(Succeed())
+
+				err := client.Atomic(ctx, func(txCtx context.Context) error {
+					Expect(client.Set(txCtx, []byte("a"), []byte("after"))).To(Succeed())
+					client.SAdd(txCtx, []byte("set"), []byte("m"))
+					return errors.New("abort")
+				})
+				Expect(err).To(MatchError("abort"))
+
+				value, err := client.Get(ctx, []byte("a"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(value).To(Equal([]byte("before")))
+				Expect(client.Exists(ctx, []byte("set"))).To(BeFalse())
+			})
+
+			It("should hide uncommitted writes from other clients", func() {
+				written := make(chan struct{})
+				release := make(chan struct{})
+				done := make(chan error)
+
+				Expect(client.Set(ctx, []byte("existing"), []byte("value"))).To(Succeed())
+
+				go func() {
+					done <- client.Atomic(ctx, func(txCtx context.Context) error {
+						if err := client.Set(txCtx, []byte("pending"), []byte("value")); err != nil {
+							return err
+						}
+
+						close(written)
+						<-release
+						return nil
+					})
+				}()
+
+				<-written
+				Expect(client.Exists(ctx, []byte("pending"))).To(BeFalse())
+
+				close(release)
+				Expect(<-done).To(Succeed())
+				Expect(client.Exists(ctx, []byte("pending"))).To(BeTrue())
+			})
+
+			It("should open new databases inside the transaction", func() {
+				dbCtx := context.WithValue(ctx, domain.DB, uint8(7))
+
+				err := client.Atomic(dbCtx, func(txCtx context.Context) error {
+					return client.Set(txCtx, []byte("key"), []byte("db7"))
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				value, err := client.Get(dbCtx, []byte("key"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(value).To(Equal([]byte("db7")))
+				Expect(client.Exists(ctx, []byte("key"))).To(BeFalse())
+			})
+
+			It("should report databases not yet opened by this client", func() {
+				dbCtx := context.WithValue(ctx, domain.DB, uint8(3))
+				Expect(client.Set(dbCtx, []byte("key"), []byte("db3"))).To(Succeed())
+
+				client.Close()
+
+				env, err := lmdb.NewEnv()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env.SetMaxDBs(64)).To(Succeed())
+				Expect(env.Open(testDir, 0, 0o644)).To(Succeed())
+				Expect(env.Update(func(txn *lmdb.Txn) error {
+					ttl, txnErr := txn.OpenDBI("ttl_3", 0)
+					if txnErr != nil {
+						return txnErr
+					}
+
+					return txn.Drop(ttl, true)
+				})).To(Succeed())
+				env.Close()
+
+				client, err = storage.NewClient(testDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				done := make(chan domain.StorageInfo, 1)
+				go func() {
+					defer GinkgoRecover()
+
+					err := client.Atomic(ctx, func(txCtx context.Context) error {
+						info, err := client.Info(txCtx)
+						done <- info
+						return err
+					})
+					Expect(err).NotTo(HaveOccurred())
+				}()
+
+				var info domain.StorageInfo
+				Eventually(done, 2*time.Second).Should(Receive(&info))
+				Expect(info.Keyspaces).To(Equal([]domain.KeyspaceInfo{{DB: 3, Keys: 1, Expires: 0}}))
+			})
+
+			It("should discard databases opened by a rolled back transaction", func() {
+				dbCtx := context.WithValue(ctx, domain.DB, uint8(9))
+
+				err := client.Atomic(dbCtx, func(txCtx context.Context) error {
+					client.Set(txCtx, []byte("key"), []byte("lost"))
+					return errors.New("abort")
+				})
+				Expect(err).To(HaveOccurred())
+
+				Expect(client.Set(dbCtx, []byte("key"), []byte("kept"))).To(Succeed())
+
+				value, err := client.Get(dbCtx, []byte("key"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(value).To(Equal([]byte("kept")))
+			})
+		})
+	})
+
+	Describe("Watched Keys", func() {
+		Context("when another client writes a watched key", func() {
+			It("should report the watch as modified", func() {
+				client.Set(ctx, []byte("balance"), []byte("10"))
+
+				id, err := client.Watch(ctx, 0, []byte("balance"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(client.Modified(ctx, id)).To(BeFalse())
+
+				client.Set(ctx, []byte("other"), []byte("value"))
+				Expect(client.Modified(ctx, id)).To(BeFalse())
+
+				client.Incr(ctx, []byte("balance"))
+				Expect(client.Modified(ctx, id)).To(BeTrue())
+
+				client.Unwatch(id)
+				Expect(client.Modified(ctx, id)).To(BeFalse())
+			})
+
+			It("should track keys added by later watches on the same id", func() {
+				id, _ := client.Watch(ctx, 0, []byte("a"))
+				same, _ := client.Watch(ctx, id, []byte("b"))
+				Expect(same).To(Equal(id))
+
+				client.SAdd(ctx, []byte("b"), []byte("member"))
+				Expect(client.Modified(ctx, id)).To(BeTrue())
+			})
+
+			It("should isolate keys by database", func() {
+				otherDB := context.WithValue(ctx, domain.DB, uint8(1))
+
+				id, _ := client.Watch(ctx, 0, []byte("key"))
+				client.Set(otherDB, []byte("key"), []byte("value"))
+
+				Expect(client.Modified(ctx, id)).To(BeFalse())
+			})
+		})
+
+		Context("when a watched key is deleted, expired or flushed", func() {
+			It("should report deletes and TTL changes", func() {
+				client.Set(ctx, []byte("gone"), []byte("value"))
+				client.Set(ctx, []byte("ttl"), []byte("value"))
+
+				deleted, _ := client.Watch(ctx, 0, []byte("gone"))
+				expiring, _ := client.Watch(ctx, 0, []byte("ttl"))
+
+				client.Del(ctx, []byte("gone"))
+				client.Expire(ctx, []byte("ttl"), 100)
+
+				Expect(client.Modified(ctx, deleted)).To(BeTrue())
+				Expect(client.Modified(ctx, expiring)).To(BeTrue())
+			})
+
+			It("should report keys that expired after WATCH", func() {
+				client.Set(ctx, []byte("short"), []byte("value"))
+				client.Expire(ctx, []byte("short"), 1)
+
+				id, _ := client.Watch(ctx, 0, []byte("short"))
+				Expect(client.Modified(ctx, id)).To(BeFalse())
+
+				Eventually(func() bool {
+					return client.Modified(ctx, id)
+				}, 3*time.Second, 50*time.Millisecond).Should(BeTrue())
+			})
+
+			It("should report FLUSHALL", func() {
+				id, _ := client.Watch(ctx, 0, []byte("missing"))
+				Expect(client.FlushAll(ctx)).To(Succeed())
+				Expect(client.Modified(ctx, id)).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("Error Handling", func() {
 		Context("when operations fail", func() {
 			It("should handle context timeout", func() {
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+func (client *Client) Keys(ctx context.Context, pattern []byte) ([][]byte, error) {
+	if hasError(ctxFlush(ctx)) {
+		return nil, ctx.Err()
+	}
+
+	db, err := client.sel(ctx)
+	if hasError(err) {
+		return nil, err
+	}
+
+	keys := make([][]byte, 0)
+
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		return db.walk(txn, nil, func(key, data []byte) bool {
+			if matchesScan(key, data, pattern, "") {
+				keys = append(keys, clone(key))
+			}
+
+			return true
+		})
+	})
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,265 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+const deadlineSize = 8
+
+type keyspace struct {
+	index    uint8
+	data     lmdb.DBI
+	ttl      lmdb.DBI
+	expiry   lmdb.DBI
+	elements lmdb.DBI
+	scores   lmdb.DBI
+	access   *tracker
+	watches  *watchers
+	limits   *atomic.Int64
+	expiries *atomic.Int64
+}
+
+func (space *keyspace) peek(txn *lmdb.Txn, key []byte) ([]byte, error) {
+	if space.expired(txn, key) {
+		return nil, ErrKeyNotFound
+	}
+
+	return txn.Get(space.data, key)
+}
+
+func (space *keyspace) get(txn *lmdb.Txn, key []byte) ([]byte, error) {
+	data, err := space.peek(txn, key)
+
+	if noError(err) {
+		space.access.touch(space.index, key)
+	}
+
+	return data, err
+}
+
+func (space *keyspace) fetch(txn *lmdb.Txn, key []byte) ([]byte, error) {
+	err := space.purge(txn, key)
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	data, err := txn.Get(space.data, key)
+
+	if noError(err) {
+		space.access.touch(space.index, key)
+	}
+
+	return data, err
+}
+
+func (space *keyspace) read(txn *lmdb.Txn, key []byte, kind byte) ([]byte, error) {
+	_, payload, err := space.open(txn, key, kind, false)
+	return payload, err
+}
+
+func (space *keyspace) load(txn *lmdb.Txn, key []byte, kind byte) ([]byte, error) {
+	_, payload, err := space.open(txn, key, kind, true)
+	return payload, err
+}
+
+func (space *keyspace) open(txn *lmdb.Txn, key []byte, kind byte, write bool) (byte, []byte, error) {
+	var data []byte
+	var err error
+
+	if write {
+		data, err = space.fetch(txn, key)
+	} else {
+		data, err = space.get(txn, key)
+	}
+
+	if noError(err) {
+		err = checkKeyType(data, kind)
+	}
+
+	if hasError(err) {
+		return encodingRaw, nil, err
+	}
+
+	return encodingOf(data), payloadOf(data), nil
+}
+
+func (space *keyspace) put(txn *lmdb.Txn, key []byte, kind, encoding byte, payload []byte) error {
+	space.access.touch(space.index, key)
+	space.watches.touch(space.index, key)
+	return txn.Put(space.data, key, encode(kind, encoding, payload), noFlags)
+}
+
+func (space *keyspace) overwrite(txn *lmdb.Txn, key []byte, kind, encoding byte, payload []byte) error {
+	if err := space.release(txn, key); hasError(err) {
+		return err
+	}
+
+	return space.put(txn, key, kind, encoding, payload)
+}
+
+func (space *keyspace) release(txn *lmdb.Txn, key []byte) error {
+	data, err := txn.Get(space.data, key)
+
+	if isNotFound(err) {
+		return nil
+	}
+
+	if noError(err) && encodingOf(data) == encodingTable {
+		err = space.dropElements(txn, key)
+	}
+
+	return err
+}
+
+func (space *keyspace) purge(txn *lmdb.Txn, key []byte) error {
+	if !space.expired(txn, key) {
+		return nil
+	}
+
+	err := space.del(txn, key)
+
+	if isNotFound(err) {
+		return nil
+	}
+
+	if noError(err) {
+		space.expiries.Add(1)
+	}
+
+	return err
+}
+
+func (space *keyspace) del(txn *lmdb.Txn, key []byte) error {
+	err := space.clearDeadline(txn, key)
+
+	if hasError(err) {
+		return err
+	}
+
+	if err = space.release(txn, key); hasError(err) {
+		return err
+	}
+
+	space.access.forget(space.index, key)
+	space.watches.touch(space.index, key)
+	return txn.Del(space.data, key, nil)
+}
+
+func (space *keyspace) deadline(txn *lmdb.Txn, key []byte) (int64, bool) {
+	data, err := txn.Get(space.ttl, key)
+
+	if hasError(err) || len(data) != deadlineSize {
+		return 0, false
+	}
+
+	return int64(binary.BigEndian.Uint64(data)), true
+}
+
+func (space *keyspace) setDeadline(txn *lmdb.Txn, key []byte, deadline int64) error {
+	if err := space.unindex(txn, key); hasError(err) {
+		return err
+	}
+
+	data := make([]byte, deadlineSize)
+	binary.BigEndian.PutUint64(data, uint64(deadline))
+	space.watches.touch(space.index, key)
+
+	if err := txn.Put(space.ttl, key, data, noFlags); hasError(err) {
+		return err
+	}
+
+	return txn.Put(space.expiry, expiryKey(deadline, key), nil, noFlags)
+}
+
+func (space *keyspace) clearDeadline(txn *lmdb.Txn, key []byte) error {
+	if err := space.unindex(txn, key); hasError(err) {
+		return err
+	}
+
+	err := txn.Del(space.ttl, key, nil)
+
+	if isNotFound(err) {
+		return nil
+	}
+
+	if noError(err) {
+		space.watches.touch(space.index, key)
+	}
+
+	return err
+}
+
+func (space *keyspace) unindex(txn *lmdb.Txn, key []byte) error {
+	deadline, hasDeadline := space.deadline(txn, key)
+
+	if !hasDeadline {
+		return nil
+	}
+
+	err := txn.Del(space.expiry, expiryKey(deadline, key), nil)
+
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+func expiryKey(deadline int64, key []byte) []byte {
+	composite := make([]byte, deadlineSize, deadlineSize+len(key))
+	binary.BigEndian.PutUint64(composite, uint64(max(deadline, 0)))
+	return append(composite, key...)
+}
+
+func (space *keyspace) expired(txn *lmdb.Txn, key []byte) bool {
+	deadline, hasDeadline := space.deadline(txn, key)
+	return hasDeadline && isDeadlineReached(deadline)
+}
+
+func isDeadlineReached(deadline int64) bool {
+	return deadline <= time.Now().UnixMilli()
+}
+
+func (space *keyspace) walk(txn *lmdb.Txn, after []byte, fn func(key, data []byte) bool) error {
+	cursor, err := txn.OpenCursor(space.data)
+	if hasError(err) {
+		return err
+	}
+	defer cursor.Close()
+
+	key, data, err := seek(cursor, after)
+
+	for noError(err) {
+		if !space.expired(txn, key) && !fn(key, data) {
+			return nil
+		}
+
+		key, data, err = cursor.Get(nil, nil, lmdb.Next)
+	}
+
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+func seek(cursor *lmdb.Cursor, after []byte) ([]byte, []byte, error) {
+	if isEmpty(after) {
+		return cursor.Get(nil, nil, lmdb.First)
+	}
+
+	key, data, err := cursor.Get(after, nil, lmdb.SetRange)
+
+	if noError(err) && bytes.Equal(key, after) {
+		return cursor.Get(nil, nil, lmdb.Next)
+	}
+
+	return key, data, err
+}
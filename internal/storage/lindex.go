@@ -2,7 +2,6 @@ package storage
 
 import (
 	"context"
-	"encoding/binary"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
 )
@@ -23,49 +22,24 @@ func (client *Client) LIndex(ctx context.Context, key []byte, index int64) ([]by
 
 	var result []byte
 
-	err = client.env.View(func(txn *lmdb.Txn) error {
-		data, txnErr := txn.Get(db, key)
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		list, txnErr := db.openList(txn, key, false)
 
-		if hasError(txnErr) {
-			return ErrKeyNotFound
-		}
-
-		if !hasValidListHeader(data) {
+		if isNotFound(txnErr) {
 			return ErrKeyNotFound
 		}
 
-		length := int64(binary.LittleEndian.Uint64(data[:listHeaderSize]))
-
-		if isNegativeIndex(index) {
-			index = length + index
+		if hasError(txnErr) {
+			return txnErr
 		}
 
-		if isIndexOutOfBounds(index, length) {
+		position, inRange := normalizeIndex(index, list.length())
+		if !inRange {
 			return ErrKeyNotFound
 		}
 
-		offset := listHeaderSize
-		for i := int64(firstElement); i <= index; i++ {
-			if offset+itemLengthSize > len(data) {
-				return ErrKeyNotFound
-			}
-
-			itemLen := int(binary.LittleEndian.Uint32(data[offset:]))
-			offset += itemLengthSize
-
-			if i == index {
-				if offset+itemLen > len(data) {
-					return ErrKeyNotFound
-				}
-				result = make([]byte, itemLen)
-				copy(result, data[offset:offset+itemLen])
-				return nil
-			}
-
-			offset += itemLen
-		}
-
-		return ErrKeyNotFound
+		result, txnErr = list.at(position)
+		return txnErr
 	})
 
 	if hasError(err) {
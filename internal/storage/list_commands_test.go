@@ -2,6 +2,7 @@ package storage_test
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -274,4 +275,101 @@ var _ = Describe("List Storage Commands", func() {
 			Expect(values).To(BeEmpty())
 		})
 	})
+
+	Describe("Per-element layout", func() {
+		BeforeEach(func() {
+			client.SetMaxPackedEntries(4)
+		})
+
+		It("should keep small lists packed", func() {
+			client.RPush(ctx, []byte("list"), []byte("a"), []byte("b"))
+
+			encoding, err := client.Encoding(ctx, []byte("list"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoding).To(Equal("listpack"))
+		})
+
+		It("should convert past the threshold and keep list semantics", func() {
+			client.RPush(ctx, []byte("list"), []byte("c"), []byte("d"), []byte("e"))
+			Expect(client.LPush(ctx, []byte("list"), []byte("b"), []byte("a"))).To(Equal(int64(5)))
+			Expect(client.RPush(ctx, []byte("list"), []byte("f"))).To(Equal(int64(6)))
+
+			encoding, err := client.Encoding(ctx, []byte("list"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoding).To(Equal("quicklist"))
+
+			values, err := client.LRange(ctx, []byte("list"), 0, -1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([][]byte{
+				[]byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e"), []byte("f"),
+			}))
+
+			values, err = client.LRange(ctx, []byte("list"), 1, -2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([][]byte{[]byte("b"), []byte("c"), []byte("d"), []byte("e")}))
+
+			Expect(client.LSet(ctx, []byte("list"), -1, []byte("z"))).To(Succeed())
+
+			value, err := client.LIndex(ctx, []byte("list"), 5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal([]byte("z")))
+
+			value, err = client.LPop(ctx, []byte("list"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal([]byte("a")))
+
+			value, err = client.RPop(ctx, []byte("list"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal([]byte("z")))
+
+			Expect(client.LLen(ctx, []byte("list"))).To(Equal(int64(4)))
+		})
+
+		It("should keep per-element lists across restarts", func() {
+			client.RPush(ctx, []byte("list"), []byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e"))
+			client.Close()
+
+			var err error
+			client, err = storage.NewClient(tempDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			values, err := client.LRange(ctx, []byte("list"), 0, -1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveLen(5))
+			Expect(client.Type(ctx, []byte("list"))).To(Equal("list"))
+		})
+
+		It("should remove the key when the last element is popped", func() {
+			client.RPush(ctx, []byte("list"), []byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e"))
+
+			for range 5 {
+				_, err := client.LPop(ctx, []byte("list"))
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(client.Exists(ctx, []byte("list"))).To(BeFalse())
+		})
+
+		It("should drop every element when the key is deleted or overwritten", func() {
+			values := make([][]byte, 2000)
+			for i := range values {
+				values[i] = fmt.Appendf(nil, "item-%d", i)
+			}
+
+			client.RPush(ctx, []byte("list"), values...)
+			_, err := client.Del(ctx, []byte("list"))
+			Expect(err).NotTo(HaveOccurred())
+
+			client.RPush(ctx, []byte("list"), values...)
+			Expect(client.Set(ctx, []byte("list"), []byte("plain"))).To(Succeed())
+
+			client.RPush(ctx, []byte("fresh"), []byte("only"))
+			Expect(client.LRange(ctx, []byte("fresh"), 0, -1)).To(Equal([][]byte{[]byte("only")}))
+
+			_, err = client.Del(ctx, []byte("list"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.RPush(ctx, []byte("list"), []byte("new"))).To(Equal(int64(1)))
+			Expect(client.LRange(ctx, []byte("list"), 0, -1)).To(Equal([][]byte{[]byte("new")}))
+		})
+	})
 })
@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"encoding/binary"
+	"slices"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+const listMetaSize = 2 * integerSize
+
+type (
+	listStore interface {
+		length() int64
+		at(int64) ([]byte, error)
+		replace(int64, []byte) error
+		push(bool, [][]byte) error
+		pop(bool) ([]byte, error)
+		span(int64, int64) ([][]byte, error)
+		commit() error
+	}
+
+	packedList struct {
+		space *keyspace
+		txn   *lmdb.Txn
+		key   []byte
+		items [][]byte
+	}
+
+	tableList struct {
+		space  *keyspace
+		txn    *lmdb.Txn
+		key    []byte
+		prefix []byte
+		head   int64
+		count  int64
+	}
+)
+
+func (space *keyspace) openList(txn *lmdb.Txn, key []byte, write bool) (listStore, error) {
+	encoding, payload, err := space.open(txn, key, kindList, write)
+	if hasError(err) {
+		return nil, err
+	}
+
+	if encoding == encodingTable {
+		return space.tableList(txn, key, payload)
+	}
+
+	items, err := decodeItems(payload)
+	if hasError(err) {
+		return nil, err
+	}
+
+	return &packedList{space: space, txn: txn, key: key, items: items}, nil
+}
+
+func (space *keyspace) createList(txn *lmdb.Txn, key []byte) (listStore, error) {
+	list, err := space.openList(txn, key, true)
+
+	if isNotFound(err) {
+		return &packedList{space: space, txn: txn, key: key}, nil
+	}
+
+	return list, err
+}
+
+func (space *keyspace) tableList(txn *lmdb.Txn, key, payload []byte) (*tableList, error) {
+	if len(payload) < listMetaSize {
+		return nil, ErrKeyNotFound
+	}
+
+	return &tableList{
+		space:  space,
+		txn:    txn,
+		key:    key,
+		prefix: elementPrefix(key),
+		count:  int64(binary.LittleEndian.Uint64(payload)),
+		head:   int64(binary.LittleEndian.Uint64(payload[integerSize:])),
+	}, nil
+}
+
+func (list *packedList) length() int64 {
+	return int64(len(list.items))
+}
+
+func (list *packedList) at(index int64) ([]byte, error) {
+	return list.items[index], nil
+}
+
+func (list *packedList) replace(index int64, value []byte) error {
+	list.items[index] = value
+	return nil
+}
+
+func (list *packedList) push(front bool, values [][]byte) error {
+	if !front {
+		list.items = append(list.items, values...)
+		return nil
+	}
+
+	prepended := slices.Clone(values)
+	slices.Reverse(prepended)
+	list.items = append(prepended, list.items...)
+	return nil
+}
+
+func (list *packedList) pop(front bool) ([]byte, error) {
+	if front {
+		item := list.items[firstElement]
+		list.items = list.items[singleItem:]
+		return item, nil
+	}
+
+	last := len(list.items) - singleItem
+	item := list.items[last]
+	list.items = list.items[:last]
+	return item, nil
+}
+
+func (list *packedList) span(start, stop int64) ([][]byte, error) {
+	return list.items[start : stop+singleItem], nil
+}
+
+func (list *packedList) commit() error {
+	if len(list.items) == emptyCount {
+		return list.space.del(list.txn, list.key)
+	}
+
+	if list.space.keepsPacked(list.key, list.length()) {
+		return list.space.put(list.txn, list.key, kindList, encodingPacked, encodeItems(list.items))
+	}
+
+	table := &tableList{space: list.space, txn: list.txn, key: list.key, prefix: elementPrefix(list.key)}
+
+	if err := table.push(false, list.items); hasError(err) {
+		return err
+	}
+
+	return table.commit()
+}
+
+func (list *tableList) length() int64 {
+	return list.count
+}
+
+func (list *tableList) element(index int64) []byte {
+	return elementKey(list.prefix, encodePosition(list.head+index))
+}
+
+func (list *tableList) at(index int64) ([]byte, error) {
+	value, err := list.txn.Get(list.space.elements, list.element(index))
+	if hasError(err) {
+		return nil, err
+	}
+
+	return clone(value), nil
+}
+
+func (list *tableList) replace(index int64, value []byte) error {
+	return list.txn.Put(list.space.elements, list.element(index), value, noFlags)
+}
+
+func (list *tableList) push(front bool, values [][]byte) error {
+	for _, value := range values {
+		index := list.count
+
+		if front {
+			list.head--
+			index = firstElement
+		}
+
+		if err := list.replace(index, value); hasError(err) {
+			return err
+		}
+
+		list.count++
+	}
+
+	return nil
+}
+
+func (list *tableList) pop(front bool) ([]byte, error) {
+	index := list.count - singleItem
+
+	if front {
+		index = firstElement
+	}
+
+	value, err := list.at(index)
+
+	if noError(err) {
+		err = list.txn.Del(list.space.elements, list.element(index), nil)
+	}
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	if front {
+		list.head++
+	}
+
+	list.count--
+	return value, nil
+}
+
+func (list *tableList) span(start, stop int64) ([][]byte, error) {
+	items := make([][]byte, 0, stop-start+singleItem)
+
+	err := list.space.eachElement(list.txn, list.space.elements, list.element(start), list.prefix, func(_, value []byte) bool {
+		items = append(items, clone(value))
+		return int64(len(items)) <= stop-start
+	})
+
+	return items, err
+}
+
+func (list *tableList) commit() error {
+	if list.count == emptyCount {
+		return list.space.del(list.txn, list.key)
+	}
+
+	meta := make([]byte, listMetaSize)
+	binary.LittleEndian.PutUint64(meta, uint64(list.count))
+	binary.LittleEndian.PutUint64(meta[integerSize:], uint64(list.head))
+
+	return list.space.put(list.txn, list.key, kindList, encodingTable, meta)
+}
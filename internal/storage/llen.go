@@ -2,7 +2,6 @@ package storage
 
 import (
 	"context"
-	"encoding/binary"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
 )
@@ -23,17 +22,13 @@ func (client *Client) LLen(ctx context.Context, key []byte) int64 {
 
 	var length int64
 
-	err = client.env.View(func(txn *lmdb.Txn) error {
-		data, txnErr := txn.Get(db, key)
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		list, txnErr := db.openList(txn, key, false)
 		if hasError(txnErr) {
 			return txnErr
 		}
 
-		if len(data) < integerSize {
-			return nil
-		}
-
-		length = int64(binary.LittleEndian.Uint64(data[:integerSize]))
+		length = list.length()
 		return nil
 	})
 
@@ -2,12 +2,15 @@ package storage
 
 import (
 	"context"
-	"encoding/binary"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
 )
 
 func (client *Client) LPop(ctx context.Context, key []byte) ([]byte, error) {
+	return client.pop(ctx, key, true)
+}
+
+func (client *Client) pop(ctx context.Context, key []byte, front bool) ([]byte, error) {
 	if hasError(ctxFlush(ctx)) {
 		return nil, ctx.Err()
 	}
@@ -24,53 +27,27 @@ func (client *Client) LPop(ctx context.Context, key []byte) ([]byte, error) {
 
 	var result []byte
 
-	err = client.env.Update(func(txn *lmdb.Txn) error {
-		data, txnErr := txn.Get(db, key)
-
-		if hasError(txnErr) {
-			return ErrKeyNotFound
-		}
+	err = client.update(ctx, func(txn *lmdb.Txn) error {
+		list, txnErr := db.openList(txn, key, true)
 
-		if len(data) < integerSize {
+		if isNotFound(txnErr) {
 			return ErrKeyNotFound
 		}
 
-		length := int64(binary.LittleEndian.Uint64(data[:integerSize]))
-
-		if length == emptyCount {
-			return ErrKeyNotFound
-		}
-
-		if length == singleItem {
-			if len(data) < integerSize+itemLengthSize {
-				return ErrKeyNotFound
-			}
-			itemLen := int(binary.LittleEndian.Uint32(data[integerSize : integerSize+itemLengthSize]))
-			if len(data) < integerSize+itemLengthSize+itemLen {
-				return ErrKeyNotFound
-			}
-			result = make([]byte, itemLen)
-			copy(result, data[integerSize+itemLengthSize:integerSize+itemLengthSize+itemLen])
-			return txn.Del(db, key, nil)
+		if hasError(txnErr) {
+			return txnErr
 		}
 
-		if len(data) < integerSize+itemLengthSize {
+		if list.length() == emptyCount {
 			return ErrKeyNotFound
 		}
 
-		firstItemLen := int(binary.LittleEndian.Uint32(data[integerSize : integerSize+itemLengthSize]))
-		if len(data) < integerSize+itemLengthSize+firstItemLen {
-			return ErrKeyNotFound
+		result, txnErr = list.pop(front)
+		if hasError(txnErr) {
+			return txnErr
 		}
 
-		result = make([]byte, firstItemLen)
-		copy(result, data[integerSize+itemLengthSize:integerSize+itemLengthSize+firstItemLen])
-
-		newData := make([]byte, integerSize)
-		binary.LittleEndian.PutUint64(newData, uint64(length-singleItem))
-		newData = append(newData, data[integerSize+itemLengthSize+firstItemLen:]...)
-
-		return txn.Put(db, key, newData, noFlags)
+		return list.commit()
 	})
 
 	if hasError(err) {
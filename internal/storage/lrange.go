@@ -2,7 +2,6 @@ package storage
 
 import (
 	"context"
-	"encoding/binary"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
 )
@@ -23,61 +22,24 @@ func (client *Client) LRange(ctx context.Context, key []byte, start, stop int64)
 
 	var result [][]byte
 
-	err = client.env.View(func(txn *lmdb.Txn) error {
-		data, txnErr := txn.Get(db, key)
-		if hasError(txnErr) {
-			return nil
-		}
-
-		if len(data) < integerSize {
-			return nil
-		}
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		list, txnErr := db.openList(txn, key, false)
 
-		length := int64(binary.LittleEndian.Uint64(data[:integerSize]))
-		if length == emptyCount {
+		if isNotFound(txnErr) {
 			return nil
 		}
 
-		if start < firstElement {
-			start = length + start
-		}
-		if stop < firstElement {
-			stop = length + stop
-		}
-
-		if start < firstElement {
-			start = firstElement
-		}
-		if stop >= length {
-			stop = length - singleItem
+		if hasError(txnErr) {
+			return txnErr
 		}
 
-		if start > stop {
+		first, last, inRange := normalizeRange(start, stop, list.length())
+		if !inRange {
 			return nil
 		}
 
-		offset := integerSize
-		for i := int64(firstElement); i < length; i++ {
-			if offset+itemLengthSize > len(data) {
-				return ErrKeyNotFound
-			}
-
-			itemLen := int(binary.LittleEndian.Uint32(data[offset:]))
-			offset += itemLengthSize
-
-			if i >= start && i <= stop {
-				if offset+itemLen > len(data) {
-					return ErrKeyNotFound
-				}
-				item := make([]byte, itemLen)
-				copy(item, data[offset:offset+itemLen])
-				result = append(result, item)
-			}
-
-			offset += itemLen
-		}
-
-		return nil
+		result, txnErr = list.span(first, last)
+		return txnErr
 	})
 
 	if hasError(err) {
@@ -2,7 +2,6 @@ package storage
 
 import (
 	"context"
-	"encoding/binary"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
 )
@@ -21,55 +20,26 @@ func (client *Client) LSet(ctx context.Context, key []byte, index int64, value [
 		return ErrKeyNotFound
 	}
 
-	return client.env.Update(func(txn *lmdb.Txn) error {
-		data, err := txn.Get(db, key)
-		if hasError(err) {
-			return ErrKeyNotFound
-		}
+	return client.update(ctx, func(txn *lmdb.Txn) error {
+		list, err := db.openList(txn, key, true)
 
-		if hasInvalidListHeader(data) {
+		if isNotFound(err) {
 			return ErrKeyNotFound
 		}
 
-		length := int64(binary.LittleEndian.Uint64(data[:integerSize]))
-		if isNegativeIndex(index) {
-			index = length + index
+		if hasError(err) {
+			return err
 		}
 
-		if isIndexOutOfBounds(index, length) {
+		position, inRange := normalizeIndex(index, list.length())
+		if !inRange {
 			return ErrKeyNotFound
 		}
 
-		newData := make([]byte, integerSize)
-		binary.LittleEndian.PutUint64(newData, uint64(length))
-
-		offset := integerSize
-
-		for i := int64(firstElement); i < length; i++ {
-			if hasInsufficientData(data, offset, itemLengthSize) {
-				return ErrKeyNotFound
-			}
-
-			itemLen := int(binary.LittleEndian.Uint32(data[offset:]))
-			offset += itemLengthSize
-
-			if i == index {
-				newData = append(newData, make([]byte, itemLengthSize)...)
-				binary.LittleEndian.PutUint32(newData[len(newData)-itemLengthSize:], uint32(len(value)))
-				newData = append(newData, value...)
-				offset += itemLen
-				continue
-			}
-
-			if hasInsufficientData(data, offset, itemLen) {
-				return ErrKeyNotFound
-			}
-			newData = append(newData, make([]byte, itemLengthSize)...)
-			binary.LittleEndian.PutUint32(newData[len(newData)-itemLengthSize:], uint32(itemLen))
-			newData = append(newData, data[offset:offset+itemLen]...)
-			offset += itemLen
+		if err = list.replace(position, value); hasError(err) {
+			return err
 		}
 
-		return txn.Put(db, key, newData, noFlags)
+		return list.commit()
 	})
 }
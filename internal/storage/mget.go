@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+func (client *Client) MGet(ctx context.Context, keys ...[]byte) ([][]byte, error) {
+	db, err := client.sel(ctx)
+
+	if noError(err) {
+		err = ctxFlush(ctx)
+	}
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	values := make([][]byte, len(keys))
+
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		if errFlush := ctxFlush(ctx); hasError(errFlush) {
+			return errFlush
+		}
+
+		for index, key := range keys {
+			val, txnErr := db.read(txn, key, kindString)
+
+			if noError(txnErr) {
+				values[index] = clone(val)
+				continue
+			}
+
+			if isNotFound(txnErr) || txnErr == ErrWrongType {
+				continue
+			}
+
+			return txnErr
+		}
+
+		return nil
+	})
+
+	if hasError(err) {
+		return nil, err
+	}
+
+	return values, nil
+}
@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+const (
+	metaName      = "meta"
+	formatKey     = "format"
+	formatTagged  = byte(1)
+	formatIndexed = byte(2)
+	formatVersion = formatIndexed
+)
+
+func (client *Client) migrate() error {
+	return client.env.Update(func(txn *lmdb.Txn) error {
+		meta, err := txn.OpenDBI(metaName, lmdb.Create)
+		if hasError(err) {
+			return err
+		}
+
+		stored, err := txn.Get(meta, []byte(formatKey))
+		version := byte(0)
+
+		if noError(err) && len(stored) == 1 {
+			version = stored[0]
+		}
+
+		if version >= formatVersion {
+			return nil
+		}
+
+		if hasError(err) && !isNotFound(err) {
+			return err
+		}
+
+		names, err := databaseNames(txn)
+		if hasError(err) {
+			return err
+		}
+
+		for _, name := range names {
+			var index uint8
+
+			if _, scanErr := fmt.Sscanf(name, dataName, &index); noError(scanErr) && version < formatTagged {
+				err = tagLegacyValues(txn, name)
+			}
+
+			if _, scanErr := fmt.Sscanf(name, ttlName, &index); noError(scanErr) && version < formatIndexed {
+				err = indexDeadlines(txn, index)
+			}
+
+			if hasError(err) {
+				return err
+			}
+		}
+
+		return txn.Put(meta, []byte(formatKey), []byte{formatVersion}, noFlags)
+	})
+}
+
+func tagLegacyValues(txn *lmdb.Txn, name string) error {
+	dbi, err := txn.OpenDBI(name, noFlags)
+	if hasError(err) {
+		return err
+	}
+
+	cursor, err := txn.OpenCursor(dbi)
+	if hasError(err) {
+		return err
+	}
+	defer cursor.Close()
+
+	for {
+		key, data, cursorErr := cursor.Get(nil, nil, lmdb.Next)
+
+		if lmdb.IsNotFound(cursorErr) {
+			return nil
+		}
+
+		if hasError(cursorErr) {
+			return cursorErr
+		}
+
+		kind := legacyKind(data)
+		encoding := encodingRaw
+
+		if kind != kindString {
+			encoding = encodingPacked
+		}
+
+		if err = cursor.Put(key, encode(kind, encoding, data), lmdb.Current); hasError(err) {
+			return err
+		}
+	}
+}
+
+func legacyKind(data []byte) byte {
+	if isLegacySortedSet(data) {
+		return kindZSet
+	}
+
+	if !isLegacyList(data) {
+		return kindString
+	}
+
+	// Only a repeated item proves a list; the rest stay open until written.
+	if hasRepeatedItems(data) {
+		return kindList
+	}
+
+	return kindListOrSet
+}
+
+func hasRepeatedItems(data []byte) bool {
+	items, err := decodeItems(data)
+	seen := make(map[string]struct{}, len(items))
+
+	for _, item := range items {
+		if _, found := seen[string(item)]; found {
+			return true
+		}
+
+		seen[string(item)] = struct{}{}
+	}
+
+	return hasError(err)
+}
+
+func isLegacyList(data []byte) bool {
+	if len(data) < integerSize {
+		return false
+	}
+
+	length := binary.LittleEndian.Uint64(data[:integerSize])
+	offset := integerSize
+
+	if length == emptyCount {
+		return false
+	}
+
+	for range length {
+		if offset+itemLengthSize > len(data) {
+			return false
+		}
+
+		itemLength := int(binary.LittleEndian.Uint32(data[offset:]))
+		offset += itemLengthSize
+
+		if offset+itemLength > len(data) {
+			return false
+		}
+
+		offset += itemLength
+	}
+
+	return offset == len(data)
+}
+
+func isLegacySortedSet(data []byte) bool {
+	if len(data) < sortedSetHeaderSize {
+		return false
+	}
+
+	count := binary.LittleEndian.Uint64(data[:sortedSetHeaderSize])
+	offset := sortedSetHeaderSize
+	previous := math.Inf(-1)
+
+	if count == emptyCount {
+		return false
+	}
+
+	for range count {
+		if offset+scoreSize+itemLengthSize > len(data) {
+			return false
+		}
+
+		score := math.Float64frombits(binary.LittleEndian.Uint64(data[offset:]))
+		offset += scoreSize
+
+		if math.IsNaN(score) || score < previous {
+			return false
+		}
+
+		previous = score
+		memberLen := int(binary.LittleEndian.Uint32(data[offset:]))
+		offset += itemLengthSize
+
+		if offset+memberLen > len(data) {
+			return false
+		}
+
+		offset += memberLen
+	}
+
+	return offset == len(data)
+}
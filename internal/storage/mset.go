@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+func (client *Client) MSet(ctx context.Context, pairs ...[]byte) error {
+	_, err := client.msetWith(ctx, false, pairs)
+	return err
+}
+
+func (client *Client) msetWith(ctx context.Context, onlyNew bool, pairs [][]byte) (bool, error) {
+	db, err := client.sel(ctx)
+
+	if noError(err) {
+		err = ctxFlush(ctx)
+	}
+
+	if hasError(err) {
+		return false, err
+	}
+
+	var applied bool
+
+	err = client.update(ctx, func(txn *lmdb.Txn) error {
+		applied = false
+
+		if errFlush := ctxFlush(ctx); hasError(errFlush) {
+			return errFlush
+		}
+
+		if onlyNew {
+			for index := 0; index+1 < len(pairs); index += 2 {
+				_, txnErr := db.fetch(txn, pairs[index])
+
+				if noError(txnErr) {
+					return nil
+				}
+
+				if !isNotFound(txnErr) {
+					return txnErr
+				}
+			}
+		}
+
+		for index := 0; index+1 < len(pairs); index += 2 {
+			key := pairs[index]
+
+			if txnErr := db.clearDeadline(txn, key); hasError(txnErr) {
+				return txnErr
+			}
+
+			if txnErr := db.overwrite(txn, key, kindString, encodingRaw, pairs[index+1]); hasError(txnErr) {
+				return txnErr
+			}
+		}
+
+		applied = true
+		return nil
+	})
+
+	return applied, err
+}
@@ -0,0 +1,7 @@
+package storage
+
+import "context"
+
+func (client *Client) MSetNX(ctx context.Context, pairs ...[]byte) (bool, error) {
+	return client.msetWith(ctx, true, pairs)
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+func (client *Client) Encoding(ctx context.Context, key []byte) (string, error) {
+	var name string
+
+	err := client.inspect(ctx, key, func(db *keyspace, data []byte) {
+		name = encodingName(data)
+	})
+
+	return name, err
+}
+
+func (client *Client) IdleTime(ctx context.Context, key []byte) (int64, error) {
+	var idle int64
+
+	err := client.inspect(ctx, key, func(db *keyspace, _ []byte) {
+		idle = db.access.idle(db.index, key)
+	})
+
+	return idle, err
+}
+
+func (client *Client) Frequency(ctx context.Context, key []byte) (int64, error) {
+	var frequency int64
+
+	err := client.inspect(ctx, key, func(db *keyspace, _ []byte) {
+		frequency = db.access.frequency(db.index, key)
+	})
+
+	return frequency, err
+}
+
+func (client *Client) inspect(ctx context.Context, key []byte, fn func(*keyspace, []byte)) error {
+	if hasError(ctxFlush(ctx)) {
+		return ctx.Err()
+	}
+
+	if isEmpty(key) {
+		return ErrKeyNotFound
+	}
+
+	db, err := client.sel(ctx)
+	if hasError(err) {
+		return err
+	}
+
+	return client.view(ctx, func(txn *lmdb.Txn) error {
+		data, txnErr := db.peek(txn, key)
+
+		if isNotFound(txnErr) {
+			return ErrKeyNotFound
+		}
+
+		if hasError(txnErr) {
+			return txnErr
+		}
+
+		fn(db, data)
+		return nil
+	})
+}
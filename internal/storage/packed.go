@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+func decodeItems(payload []byte) ([][]byte, error) {
+	if len(payload) < integerSize {
+		return nil, ErrKeyNotFound
+	}
+
+	count := binary.LittleEndian.Uint64(payload)
+	items := make([][]byte, 0, min(count, uint64(len(payload))))
+	offset := integerSize
+
+	for range count {
+		if hasInsufficientData(payload, offset, itemLengthSize) {
+			return nil, ErrKeyNotFound
+		}
+
+		itemLen := int(binary.LittleEndian.Uint32(payload[offset:]))
+		offset += itemLengthSize
+
+		if hasInsufficientData(payload, offset, itemLen) {
+			return nil, ErrKeyNotFound
+		}
+
+		items = append(items, clone(payload[offset:offset+itemLen]))
+		offset += itemLen
+	}
+
+	return items, nil
+}
+
+func encodeItems(items [][]byte) []byte {
+	size := integerSize
+	for _, item := range items {
+		size += itemLengthSize + len(item)
+	}
+
+	payload := make([]byte, integerSize, size)
+	binary.LittleEndian.PutUint64(payload, uint64(len(items)))
+
+	for _, item := range items {
+		payload = binary.LittleEndian.AppendUint32(payload, uint32(len(item)))
+		payload = append(payload, item...)
+	}
+
+	return payload
+}
+
+func decodeScoredItems(payload []byte) ([]scoredMember, error) {
+	if len(payload) < integerSize {
+		return nil, ErrKeyNotFound
+	}
+
+	count := binary.LittleEndian.Uint64(payload)
+	members := make([]scoredMember, 0, min(count, uint64(len(payload))))
+	offset := integerSize
+
+	for range count {
+		if hasInsufficientData(payload, offset, scoreSize+itemLengthSize) {
+			return nil, ErrKeyNotFound
+		}
+
+		score := math.Float64frombits(binary.LittleEndian.Uint64(payload[offset:]))
+		offset += scoreSize
+
+		memberLen := int(binary.LittleEndian.Uint32(payload[offset:]))
+		offset += itemLengthSize
+
+		if hasInsufficientData(payload, offset, memberLen) {
+			return nil, ErrKeyNotFound
+		}
+
+		members = append(members, scoredMember{score: score, member: string(payload[offset : offset+memberLen])})
+		offset += memberLen
+	}
+
+	return members, nil
+}
+
+func encodeScoredItems(members []scoredMember) []byte {
+	size := integerSize
+	for _, member := range members {
+		size += scoreSize + itemLengthSize + len(member.member)
+	}
+
+	payload := make([]byte, integerSize, size)
+	binary.LittleEndian.PutUint64(payload, uint64(len(members)))
+
+	for _, member := range members {
+		payload = binary.LittleEndian.AppendUint64(payload, math.Float64bits(member.score))
+		payload = binary.LittleEndian.AppendUint32(payload, uint32(len(member.member)))
+		payload = append(payload, member.member...)
+	}
+
+	return payload
+}
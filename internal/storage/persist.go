@@ -3,28 +3,40 @@ package storage
 import (
 	"context"
 
-	"github.com/luiz-simples/keyp.git/internal/domain"
+	"github.com/PowerDNS/lmdb-go/lmdb"
 )
 
-func (client *Client) Persist(ctx context.Context, keyBytes []byte) bool {
-	client.mtx.Lock()
-	defer client.mtx.Unlock()
-
-	db, _ := ctx.Value(domain.DB).(uint8)
-	key := string(keyBytes)
-	keys, hasKeys := client.ttl[db]
-
-	if !hasKeys {
+func (client *Client) Persist(ctx context.Context, key []byte) bool {
+	if hasError(ctxFlush(ctx)) {
 		return false
 	}
 
-	ttl := keys[key]
+	if isEmpty(key) {
+		return false
+	}
 
-	if ttl == nil {
+	db, err := client.sel(ctx)
+	if hasError(err) {
 		return false
 	}
 
-	ttl.Cancel()
-	delete(keys, key)
-	return true
+	var removed bool
+
+	err = client.update(ctx, func(txn *lmdb.Txn) error {
+		removed = false
+		_, txnErr := db.fetch(txn, key)
+
+		if hasError(txnErr) {
+			return txnErr
+		}
+
+		if _, hasDeadline := db.deadline(txn, key); !hasDeadline {
+			return nil
+		}
+
+		removed = true
+		return db.clearDeadline(txn, key)
+	})
+
+	return noError(err) && removed
 }
@@ -3,6 +3,9 @@ package storage_test
 import (
 	"context"
 	"fmt"
+	"math"
+	"math/big"
+	"strconv"
 
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
@@ -397,4 +400,124 @@ var _ = Describe("Storage Property-Based Tests", Label("property"), func() {
 			})
 		})
 	})
+
+	Describe("Counter Overflow Properties", func() {
+		key := []byte("counter")
+
+		boundary := gen.OneGenOf(
+			gen.Int64(),
+			gen.Int64Range(math.MaxInt64-100, math.MaxInt64),
+			gen.Int64Range(math.MinInt64, math.MinInt64+100),
+			gen.Int64Range(-100, 100),
+		)
+
+		fits := func(value *big.Int) bool {
+			return value.IsInt64()
+		}
+
+		unchanged := func(value int64) bool {
+			stored, err := client.Get(ctx, key)
+			return err == nil && string(stored) == strconv.FormatInt(value, 10)
+		}
+
+		Context("when incrementing near the int64 limits", func() {
+			It("should satisfy: IncrBy succeeds exactly when the sum fits and never wraps", func() {
+				properties.Property("incrby is overflow checked", prop.ForAll(
+					func(value, delta int64) bool {
+						if client.Set(ctx, key, []byte(strconv.FormatInt(value, 10))) != nil {
+							return false
+						}
+
+						sum := new(big.Int).Add(big.NewInt(value), big.NewInt(delta))
+						result, err := client.IncrBy(ctx, key, delta)
+
+						if !fits(sum) {
+							return err == storage.ErrOverflow && unchanged(value)
+						}
+
+						return err == nil && result == sum.Int64() && unchanged(result)
+					},
+					boundary,
+					boundary,
+				))
+
+				Expect(properties.Run(gopter.ConsoleReporter(false))).To(BeTrue())
+			})
+
+			It("should satisfy: DecrBy succeeds exactly when the difference fits and refuses MinInt64", func() {
+				properties.Property("decrby is overflow checked", prop.ForAll(
+					func(value, delta int64) bool {
+						if client.Set(ctx, key, []byte(strconv.FormatInt(value, 10))) != nil {
+							return false
+						}
+
+						difference := new(big.Int).Sub(big.NewInt(value), big.NewInt(delta))
+						result, err := client.DecrBy(ctx, key, delta)
+
+						if delta == math.MinInt64 {
+							return err == storage.ErrDecrOverflow && unchanged(value)
+						}
+
+						if !fits(difference) {
+							return err == storage.ErrOverflow && unchanged(value)
+						}
+
+						return err == nil && result == difference.Int64() && unchanged(result)
+					},
+					boundary,
+					gen.OneGenOf(boundary, gen.Const(int64(math.MinInt64))),
+				))
+
+				Expect(properties.Run(gopter.ConsoleReporter(false))).To(BeTrue())
+			})
+
+			It("should satisfy: Incr and Decr stop at the limits", func() {
+				Expect(client.Set(ctx, key, []byte(strconv.FormatInt(math.MaxInt64, 10)))).To(Succeed())
+				_, err := client.Incr(ctx, key)
+				Expect(err).To(MatchError(storage.ErrOverflow))
+
+				Expect(client.Set(ctx, key, []byte(strconv.FormatInt(math.MinInt64, 10)))).To(Succeed())
+				_, err = client.Decr(ctx, key)
+				Expect(err).To(MatchError(storage.ErrOverflow))
+				Expect(client.Get(ctx, key)).To(Equal([]byte("-9223372036854775808")))
+			})
+		})
+
+		Context("when the stored value is not a canonical integer", func() {
+			It("should satisfy: only the canonical form of an int64 is a counter", func() {
+				properties.Property("non-canonical integers are refused", prop.ForAll(
+					func(value int64, form int) bool {
+						canonical := strconv.FormatInt(value, 10)
+						variants := []string{
+							" " + canonical,
+							canonical + " ",
+							"+" + canonical,
+							"0" + canonical,
+							canonical + "0000000000000000000000",
+						}
+
+						if client.Set(ctx, key, []byte(variants[form])) != nil {
+							return false
+						}
+
+						_, err := client.IncrBy(ctx, key, 0)
+						if err != storage.ErrNotInteger {
+							return false
+						}
+
+						if client.Set(ctx, key, []byte(canonical)) != nil {
+							return false
+						}
+
+						result, err := client.IncrBy(ctx, key, 0)
+						return err == nil && result == value
+					},
+					boundary,
+					gen.IntRange(0, 4),
+				))
+
+				Expect(properties.Run(gopter.ConsoleReporter(false))).To(BeTrue())
+			})
+		})
+	})
 })
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+func (client *Client) restore() error {
+	indexes, err := client.storedTTLs()
+
+	if hasError(err) {
+		return err
+	}
+
+	for _, index := range indexes {
+		// Opened keyspaces are covered by the active expiry cycle.
+		if _, err = client.keyspace(index); hasError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (client *Client) storedTTLs() ([]uint8, error) {
+	var indexes []uint8
+
+	err := client.env.View(func(txn *lmdb.Txn) error {
+		names, err := databaseNames(txn)
+
+		for _, name := range names {
+			var index uint8
+
+			if _, scanErr := fmt.Sscanf(name, ttlName, &index); noError(scanErr) && int(index) < client.databases {
+				indexes = append(indexes, index)
+			}
+		}
+
+		return err
+	})
+
+	return indexes, err
+}
@@ -2,80 +2,50 @@ package storage
 
 import (
 	"context"
-	"encoding/binary"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
 )
 
-func (client *Client) SAdd(ctx context.Context, key []byte, members ...[]byte) int64 {
+func (client *Client) SAdd(ctx context.Context, key []byte, members ...[]byte) (int64, error) {
 	if hasError(ctxFlush(ctx)) {
-		return emptyCount
+		return emptyCount, ctx.Err()
 	}
 
 	if isEmpty(key) || isEmpty(members) {
-		return emptyCount
+		return emptyCount, nil
 	}
 
 	db, err := client.sel(ctx)
 	if hasError(err) {
-		return emptyCount
+		return emptyCount, err
 	}
 
 	var addedCount int64
 
-	err = client.env.Update(func(txn *lmdb.Txn) error {
-		data, txnErr := txn.Get(db, key)
-
-		existingMembers := make(map[string]bool)
-
-		if isEmpty(txnErr) && hasValidSetHeader(data) {
-			count := int64(binary.LittleEndian.Uint64(data[:setHeaderSize]))
-			offset := setHeaderSize
-
-			for i := int64(firstElement); i < count; i++ {
-				if offset+itemLengthSize > len(data) {
-					break
-				}
-
-				memberLen := int(binary.LittleEndian.Uint32(data[offset:]))
-				offset += itemLengthSize
-
-				if offset+memberLen > len(data) {
-					break
-				}
-
-				member := string(data[offset : offset+memberLen])
-				existingMembers[member] = true
-				offset += memberLen
-			}
+	err = client.update(ctx, func(txn *lmdb.Txn) error {
+		addedCount = 0
+		set, txnErr := db.createSet(txn, key)
+		if hasError(txnErr) {
+			return txnErr
 		}
 
-		newData := make([]byte, setHeaderSize)
-
 		for _, member := range members {
-			memberStr := string(member)
-			if !existingMembers[memberStr] {
-				existingMembers[memberStr] = true
-				addedCount++
+			added, addErr := set.add(member)
+			if hasError(addErr) {
+				return addErr
 			}
-		}
 
-		newCount := int64(len(existingMembers))
-		binary.LittleEndian.PutUint64(newData[:setHeaderSize], uint64(newCount))
-
-		for memberStr := range existingMembers {
-			memberBytes := []byte(memberStr)
-			newData = append(newData, make([]byte, itemLengthSize)...)
-			binary.LittleEndian.PutUint32(newData[len(newData)-itemLengthSize:], uint32(len(memberBytes)))
-			newData = append(newData, memberBytes...)
+			if added {
+				addedCount++
+			}
 		}
 
-		return txn.Put(db, key, newData, noFlags)
+		return set.commit()
 	})
 
 	if hasError(err) {
-		return emptyCount
+		return emptyCount, err
 	}
 
-	return addedCount
+	return addedCount, nil
 }
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+const defaultScanCount = 10
+
+func (client *Client) Scan(ctx context.Context, after, pattern []byte, count int64, kind string) ([]byte, [][]byte, error) {
+	if hasError(ctxFlush(ctx)) {
+		return nil, nil, ctx.Err()
+	}
+
+	db, err := client.sel(ctx)
+	if hasError(err) {
+		return nil, nil, err
+	}
+
+	if count < 1 {
+		count = defaultScanCount
+	}
+
+	keys := make([][]byte, 0)
+	var last []byte
+	var examined int64
+
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		return db.walk(txn, after, func(key, data []byte) bool {
+			if matchesScan(key, data, pattern, kind) {
+				keys = append(keys, clone(key))
+			}
+
+			examined++
+
+			if examined < count {
+				return true
+			}
+
+			last = clone(key)
+			return false
+		})
+	})
+
+	if hasError(err) {
+		return nil, nil, err
+	}
+
+	return last, keys, nil
+}
+
+func matchesScan(key, data, pattern []byte, kind string) bool {
+	if kind != "" && !hasKindName(data, kind) {
+		return false
+	}
+
+	return matchAll(pattern) || matchGlob(pattern, key)
+}
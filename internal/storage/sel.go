@@ -9,32 +9,139 @@ import (
 	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
-func (client *Client) sel(ctx context.Context) (lmdb.DBI, error) {
+const (
+	dataName = "db_%d"
+	ttlName  = "ttl_%d"
+)
+
+func (client *Client) sel(ctx context.Context) (*keyspace, error) {
 	db, _ := ctx.Value(domain.DB).(uint8)
+
+	if int(db) >= client.databases {
+		return nil, ErrDBIndex
+	}
+
+	if tx, active := transactionOf(ctx); active && !client.hasDB(db) {
+		return tx.keyspace(client, db)
+	}
+
+	return client.keyspace(db)
+}
+
+func (client *Client) keyspace(db uint8) (*keyspace, error) {
 	var err error = nil
 
 	if !client.hasDB(db) {
 		err = client.openDB(db)
 	}
 
-	return client.dbi[db], err
+	client.mtx.RLock()
+	defer client.mtx.RUnlock()
+
+	space, hasDB := client.dbs[db]
+
+	if !hasDB {
+		space = &keyspace{index: db, access: client.access, watches: client.watches, limits: &client.maxPacked, expiries: &client.expired}
+	}
+
+	return space, err
 }
 
 func (client *Client) hasDB(db uint8) bool {
 	client.mtx.RLock()
 	defer client.mtx.RUnlock()
-	_, hasDB := client.dbi[db]
+	_, hasDB := client.dbs[db]
 	return hasDB
 }
 
 func (client *Client) openDB(db uint8) error {
+	var space *keyspace
+
+	err := client.env.Update(func(txn *lmdb.Txn) error {
+		var txnErr error
+		space, txnErr = client.openSpace(txn, db)
+		return txnErr
+	})
+
+	if hasError(err) {
+		return err
+	}
+
 	client.mtx.Lock()
 	defer client.mtx.Unlock()
 
-	return client.env.Update(func(txn *lmdb.Txn) error {
-		name := fmt.Sprintf("db_%d", db)
-		dbi, err := txn.OpenDBI(name, lmdb.Create)
-		client.dbi[db] = dbi
-		return err
-	})
+	if _, hasDB := client.dbs[db]; !hasDB {
+		client.dbs[db] = space
+	}
+
+	return nil
+}
+
+func (client *Client) openSpace(txn *lmdb.Txn, db uint8) (*keyspace, error) {
+	data, err := txn.OpenDBI(fmt.Sprintf(dataName, db), lmdb.Create)
+	if hasError(err) {
+		return nil, err
+	}
+
+	ttl, err := txn.OpenDBI(fmt.Sprintf(ttlName, db), lmdb.Create)
+	if hasError(err) {
+		return nil, err
+	}
+
+	expiry, err := txn.OpenDBI(fmt.Sprintf(expiryName, db), lmdb.Create)
+	if hasError(err) {
+		return nil, err
+	}
+
+	elements, err := txn.OpenDBI(fmt.Sprintf(elementsName, db), lmdb.Create)
+	if hasError(err) {
+		return nil, err
+	}
+
+	scores, err := txn.OpenDBI(fmt.Sprintf(scoresName, db), lmdb.Create)
+	if hasError(err) {
+		return nil, err
+	}
+
+	return &keyspace{
+		index:    db,
+		data:     data,
+		ttl:      ttl,
+		expiry:   expiry,
+		elements: elements,
+		scores:   scores,
+		access:   client.access,
+		watches:  client.watches,
+		limits:   &client.maxPacked,
+		expiries: &client.expired,
+	}, nil
+}
+
+func databaseNames(txn *lmdb.Txn) ([]string, error) {
+	root, err := txn.OpenRoot(noFlags)
+	if hasError(err) {
+		return nil, err
+	}
+
+	cursor, err := txn.OpenCursor(root)
+	if hasError(err) {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var names []string
+
+	for {
+		name, _, cursorErr := cursor.Get(nil, nil, lmdb.Next)
+
+		if lmdb.IsNotFound(cursorErr) {
+			return names, nil
+		}
+
+		if hasError(cursorErr) {
+			return nil, cursorErr
+		}
+
+		names = append(names, string(name))
+	}
 }
@@ -4,9 +4,16 @@ import (
 	"context"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
 func (client *Client) Set(ctx context.Context, key, val []byte) error {
+	_, _, err := client.SetWith(ctx, key, val, domain.SetOptions{})
+	return err
+}
+
+func (client *Client) SetWith(ctx context.Context, key, val []byte, options domain.SetOptions) ([]byte, bool, error) {
 	db, err := client.sel(ctx)
 
 	if noError(err) {
@@ -14,14 +21,56 @@ func (client *Client) Set(ctx context.Context, key, val []byte) error {
 	}
 
 	if hasError(err) {
-		return err
+		return nil, false, err
 	}
 
-	return client.env.Update(func(txn *lmdb.Txn) error {
+	var previous []byte
+	var applied bool
+
+	err = client.update(ctx, func(txn *lmdb.Txn) error {
+		previous, applied = nil, false
+
 		if err := ctxFlush(ctx); hasError(err) {
 			return err
 		}
 
-		return txn.Put(db, key, val, noFlags)
+		data, err := db.fetch(txn, key)
+		exists := noError(err)
+
+		if hasError(err) && !isNotFound(err) {
+			return err
+		}
+
+		if exists && options.Get {
+			if err = checkKeyType(data, kindString); hasError(err) {
+				return err
+			}
+
+			previous = clone(payloadOf(data))
+		}
+
+		if (options.NX && exists) || (options.XX && !exists) {
+			return nil
+		}
+
+		if !options.KeepTTL {
+			if err = db.clearDeadline(txn, key); hasError(err) {
+				return err
+			}
+		}
+
+		if err = db.overwrite(txn, key, kindString, encodingRaw, val); hasError(err) {
+			return err
+		}
+
+		applied = true
+
+		if options.Deadline != domain.NoDeadline {
+			return db.setDeadline(txn, key, options.Deadline)
+		}
+
+		return nil
 	})
+
+	return previous, applied, err
 }
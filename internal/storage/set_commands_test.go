@@ -1,6 +1,7 @@
 package storage_test
 
 import (
+	"bytes"
 	"context"
 	"os"
 
@@ -38,32 +39,38 @@ var _ = Describe("Set Storage Commands", func() {
 
 	Describe("SAdd", func() {
 		It("should add single member to new set", func() {
-			count := client.SAdd(ctx, []byte("set"), []byte("member1"))
+			count, err := client.SAdd(ctx, []byte("set"), []byte("member1"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(int64(1)))
 		})
 
 		It("should add multiple members to new set", func() {
-			count := client.SAdd(ctx, []byte("set"), []byte("member1"), []byte("member2"), []byte("member3"))
+			count, err := client.SAdd(ctx, []byte("set"), []byte("member1"), []byte("member2"), []byte("member3"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(int64(3)))
 		})
 
 		It("should not add duplicate members", func() {
 			// Add initial members
-			count1 := client.SAdd(ctx, []byte("set"), []byte("member1"), []byte("member2"))
+			count1, err := client.SAdd(ctx, []byte("set"), []byte("member1"), []byte("member2"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count1).To(Equal(int64(2)))
 
 			// Try to add duplicate and new member
-			count2 := client.SAdd(ctx, []byte("set"), []byte("member1"), []byte("member3"))
+			count2, err := client.SAdd(ctx, []byte("set"), []byte("member1"), []byte("member3"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count2).To(Equal(int64(1))) // Only member3 should be added
 		})
 
 		It("should handle empty key", func() {
-			count := client.SAdd(ctx, []byte(""), []byte("member"))
+			count, err := client.SAdd(ctx, []byte(""), []byte("member"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(int64(0)))
 		})
 
 		It("should handle empty members", func() {
-			count := client.SAdd(ctx, []byte("set"))
+			count, err := client.SAdd(ctx, []byte("set"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(int64(0)))
 		})
 	})
@@ -74,37 +81,44 @@ var _ = Describe("Set Storage Commands", func() {
 		})
 
 		It("should remove existing member", func() {
-			count := client.SRem(ctx, []byte("set"), []byte("member1"))
+			count, err := client.SRem(ctx, []byte("set"), []byte("member1"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(int64(1)))
 		})
 
 		It("should remove multiple existing members", func() {
-			count := client.SRem(ctx, []byte("set"), []byte("member1"), []byte("member2"))
+			count, err := client.SRem(ctx, []byte("set"), []byte("member1"), []byte("member2"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(int64(2)))
 		})
 
 		It("should not remove non-existent member", func() {
-			count := client.SRem(ctx, []byte("set"), []byte("nonexistent"))
+			count, err := client.SRem(ctx, []byte("set"), []byte("nonexistent"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(int64(0)))
 		})
 
 		It("should handle mix of existing and non-existent members", func() {
-			count := client.SRem(ctx, []byte("set"), []byte("member1"), []byte("nonexistent"), []byte("member2"))
+			count, err := client.SRem(ctx, []byte("set"), []byte("member1"), []byte("nonexistent"), []byte("member2"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(int64(2))) // Only member1 and member2 should be removed
 		})
 
 		It("should handle non-existent set", func() {
-			count := client.SRem(ctx, []byte("nonexistent"), []byte("member"))
+			count, err := client.SRem(ctx, []byte("nonexistent"), []byte("member"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(int64(0)))
 		})
 
 		It("should handle empty key", func() {
-			count := client.SRem(ctx, []byte(""), []byte("member"))
+			count, err := client.SRem(ctx, []byte(""), []byte("member"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(int64(0)))
 		})
 
 		It("should handle empty members", func() {
-			count := client.SRem(ctx, []byte("set"))
+			count, err := client.SRem(ctx, []byte("set"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(int64(0)))
 		})
 	})
@@ -182,7 +196,8 @@ var _ = Describe("Set Storage Commands", func() {
 	Describe("Set operations integration", func() {
 		It("should maintain set properties", func() {
 			// Add members
-			count := client.SAdd(ctx, []byte("set"), []byte("a"), []byte("b"), []byte("c"))
+			count, err := client.SAdd(ctx, []byte("set"), []byte("a"), []byte("b"), []byte("c"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(int64(3)))
 
 			// Check membership
@@ -195,7 +210,8 @@ var _ = Describe("Set Storage Commands", func() {
 			Expect(members).To(HaveLen(3))
 
 			// Remove a member
-			count = client.SRem(ctx, []byte("set"), []byte("b"))
+			count, err = client.SRem(ctx, []byte("set"), []byte("b"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(int64(1)))
 
 			// Verify removal
@@ -206,4 +222,107 @@ var _ = Describe("Set Storage Commands", func() {
 			Expect(members).To(HaveLen(2))
 		})
 	})
+
+	Describe("Type enforcement", func() {
+		It("should not treat a long string as a set", func() {
+			err := client.Set(ctx, []byte("greeting"), []byte("hello world"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.SAdd(ctx, []byte("greeting"), []byte("member"))
+			Expect(err).To(Equal(storage.ErrWrongType))
+			Expect(client.SIsMember(ctx, []byte("greeting"), []byte("member"))).To(BeFalse())
+
+			_, err = client.SMembers(ctx, []byte("greeting"))
+			Expect(err).To(Equal(storage.ErrWrongType))
+
+			value, err := client.Get(ctx, []byte("greeting"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal([]byte("hello world")))
+		})
+
+		It("should not read a set as a string or list", func() {
+			client.SAdd(ctx, []byte("set"), []byte("a"), []byte("b"))
+
+			_, err := client.Get(ctx, []byte("set"))
+			Expect(err).To(Equal(storage.ErrWrongType))
+
+			_, err = client.LRange(ctx, []byte("set"), 0, -1)
+			Expect(err).To(Equal(storage.ErrWrongType))
+
+			Expect(client.LPush(ctx, []byte("set"), []byte("c"))).To(Equal(int64(0)))
+			Expect(client.SMembers(ctx, []byte("set"))).To(HaveLen(2))
+		})
+	})
+
+	Describe("Per-element layout", func() {
+		BeforeEach(func() {
+			client.SetMaxPackedEntries(4)
+		})
+
+		It("should convert past the threshold and keep set semantics", func() {
+			Expect(client.SAdd(ctx, []byte("set"), []byte("a"), []byte("b"), []byte("c"))).To(Equal(int64(3)))
+
+			encoding, err := client.Encoding(ctx, []byte("set"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoding).To(Equal("listpack"))
+
+			Expect(client.SAdd(ctx, []byte("set"), []byte("c"), []byte("d"), []byte("e"), []byte("f"))).To(Equal(int64(3)))
+
+			encoding, err = client.Encoding(ctx, []byte("set"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoding).To(Equal("hashtable"))
+
+			Expect(client.SIsMember(ctx, []byte("set"), []byte("e"))).To(BeTrue())
+			Expect(client.SIsMember(ctx, []byte("set"), []byte("x"))).To(BeFalse())
+			Expect(client.SRem(ctx, []byte("set"), []byte("a"), []byte("x"))).To(Equal(int64(1)))
+
+			members, err := client.SMembers(ctx, []byte("set"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(ConsistOf([]byte("b"), []byte("c"), []byte("d"), []byte("e"), []byte("f")))
+		})
+
+		It("should keep members longer than an LMDB key in both encodings", func() {
+			long := bytes.Repeat([]byte("m"), 520)
+			twin := append(bytes.Repeat([]byte("m"), 519), 'x')
+
+			Expect(client.SAdd(ctx, []byte("set"), long, []byte("a"))).To(Equal(int64(2)))
+			Expect(client.SIsMember(ctx, []byte("set"), long)).To(BeTrue())
+
+			Expect(client.SAdd(ctx, []byte("set"), []byte("b"), []byte("c"), []byte("d"), twin)).To(Equal(int64(4)))
+			Expect(client.Encoding(ctx, []byte("set"))).To(Equal("hashtable"))
+
+			Expect(client.SAdd(ctx, []byte("set"), long, twin)).To(Equal(int64(0)))
+			Expect(client.SIsMember(ctx, []byte("set"), long)).To(BeTrue())
+			Expect(client.SIsMember(ctx, []byte("set"), twin[:519])).To(BeFalse())
+			Expect(client.SMembers(ctx, []byte("set"))).To(ConsistOf(
+				long, twin, []byte("a"), []byte("b"), []byte("c"), []byte("d"),
+			))
+
+			Expect(client.SRem(ctx, []byte("set"), long)).To(Equal(int64(1)))
+			Expect(client.SIsMember(ctx, []byte("set"), long)).To(BeFalse())
+			Expect(client.SIsMember(ctx, []byte("set"), twin)).To(BeTrue())
+		})
+
+		It("should remove the key when the last member is removed", func() {
+			client.SAdd(ctx, []byte("set"), []byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e"))
+			Expect(client.SRem(ctx, []byte("set"), []byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e"))).To(Equal(int64(5)))
+
+			Expect(client.Exists(ctx, []byte("set"))).To(BeFalse())
+			Expect(client.SAdd(ctx, []byte("set"), []byte("z"))).To(Equal(int64(1)))
+
+			members, err := client.SMembers(ctx, []byte("set"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([][]byte{[]byte("z")}))
+		})
+
+		It("should not mix members of keys sharing a prefix", func() {
+			client.SAdd(ctx, []byte("s"), []byte("1"), []byte("2"), []byte("3"), []byte("4"), []byte("5"))
+			client.SAdd(ctx, []byte("s1"), []byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e"))
+
+			members, err := client.SMembers(ctx, []byte("s"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(HaveLen(5))
+			Expect(client.SIsMember(ctx, []byte("s"), []byte("1a"))).To(BeFalse())
+		})
+	})
 })
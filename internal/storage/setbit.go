@@ -0,0 +1,20 @@
+package storage
+
+import "context"
+
+func (client *Client) SetBit(ctx context.Context, key []byte, offset uint64, bit int64) (int64, error) {
+	var previous int64
+
+	err := client.updateString(ctx, key, func(payload []byte, _ bool) ([]byte, error) {
+		data := grow(payload, int64(offset/bitsPerByte)+1)
+		previous = bitAt(data, offset)
+		setBitAt(data, offset, bit)
+		return data, nil
+	})
+
+	if hasError(err) {
+		return 0, err
+	}
+
+	return previous, nil
+}
@@ -0,0 +1,30 @@
+package storage
+
+import "context"
+
+func (client *Client) SetRange(ctx context.Context, key []byte, offset int64, value []byte) (int64, error) {
+	if offset+int64(len(value)) > maxStringSize {
+		return 0, ErrStringTooLong
+	}
+
+	var length int64
+
+	err := client.updateString(ctx, key, func(payload []byte, _ bool) ([]byte, error) {
+		length = int64(len(payload))
+
+		if isEmpty(value) {
+			return nil, nil
+		}
+
+		data := grow(payload, offset+int64(len(value)))
+		copy(data[offset:], value)
+		length = int64(len(data))
+		return data, nil
+	})
+
+	if hasError(err) {
+		return 0, err
+	}
+
+	return length, nil
+}
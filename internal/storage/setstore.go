@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"encoding/binary"
+	"slices"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+const setMetaSize = integerSize
+
+type (
+	setStore interface {
+		size() int64
+		has([]byte) (bool, error)
+		add([]byte) (bool, error)
+		remove([]byte) (bool, error)
+		members() ([][]byte, error)
+		commit() error
+	}
+
+	packedSet struct {
+		space  *keyspace
+		txn    *lmdb.Txn
+		key    []byte
+		items  [][]byte
+		lookup map[string]struct{}
+	}
+
+	tableSet struct {
+		space  *keyspace
+		txn    *lmdb.Txn
+		key    []byte
+		prefix []byte
+		count  int64
+	}
+)
+
+func (space *keyspace) openSet(txn *lmdb.Txn, key []byte, write bool) (setStore, error) {
+	encoding, payload, err := space.open(txn, key, kindSet, write)
+	if hasError(err) {
+		return nil, err
+	}
+
+	if encoding == encodingTable {
+		return space.tableSet(txn, key, payload)
+	}
+
+	items, err := decodeItems(payload)
+	if hasError(err) {
+		return nil, err
+	}
+
+	set := &packedSet{space: space, txn: txn, key: key, lookup: make(map[string]struct{}, len(items))}
+
+	for _, item := range items {
+		set.add(item)
+	}
+
+	return set, nil
+}
+
+func (space *keyspace) createSet(txn *lmdb.Txn, key []byte) (setStore, error) {
+	set, err := space.openSet(txn, key, true)
+
+	if isNotFound(err) {
+		return &packedSet{space: space, txn: txn, key: key, lookup: make(map[string]struct{})}, nil
+	}
+
+	return set, err
+}
+
+func (space *keyspace) tableSet(txn *lmdb.Txn, key, payload []byte) (*tableSet, error) {
+	if len(payload) < setMetaSize {
+		return nil, ErrKeyNotFound
+	}
+
+	return &tableSet{
+		space:  space,
+		txn:    txn,
+		key:    key,
+		prefix: elementPrefix(key),
+		count:  int64(binary.LittleEndian.Uint64(payload)),
+	}, nil
+}
+
+func (set *packedSet) size() int64 {
+	return int64(len(set.items))
+}
+
+func (set *packedSet) has(member []byte) (bool, error) {
+	_, found := set.lookup[string(member)]
+	return found, nil
+}
+
+func (set *packedSet) add(member []byte) (bool, error) {
+	if found, _ := set.has(member); found {
+		return false, nil
+	}
+
+	set.lookup[string(member)] = struct{}{}
+	set.items = append(set.items, member)
+	return true, nil
+}
+
+func (set *packedSet) remove(member []byte) (bool, error) {
+	if found, _ := set.has(member); !found {
+		return false, nil
+	}
+
+	delete(set.lookup, string(member))
+	set.items = slices.DeleteFunc(set.items, func(item []byte) bool {
+		return string(item) == string(member)
+	})
+
+	return true, nil
+}
+
+func (set *packedSet) members() ([][]byte, error) {
+	return set.items, nil
+}
+
+func (set *packedSet) commit() error {
+	if len(set.items) == emptyCount {
+		return set.space.del(set.txn, set.key)
+	}
+
+	if set.space.keepsPacked(set.key, set.size()) {
+		return set.space.put(set.txn, set.key, kindSet, encodingPacked, encodeItems(set.items))
+	}
+
+	table := &tableSet{space: set.space, txn: set.txn, key: set.key, prefix: elementPrefix(set.key)}
+
+	for _, item := range set.items {
+		if _, err := table.add(item); hasError(err) {
+			return err
+		}
+	}
+
+	return table.commit()
+}
+
+func (set *tableSet) size() int64 {
+	return set.count
+}
+
+func (set *tableSet) has(member []byte) (bool, error) {
+	_, found, err := set.space.getMember(set.txn, set.space.elements, set.prefix, member)
+	return found, err
+}
+
+func (set *tableSet) add(member []byte) (bool, error) {
+	found, err := set.has(member)
+
+	if found || hasError(err) {
+		return false, err
+	}
+
+	if err = set.space.putMember(set.txn, set.space.elements, set.prefix, member, nil); hasError(err) {
+		return false, err
+	}
+
+	set.count++
+	return true, nil
+}
+
+func (set *tableSet) remove(member []byte) (bool, error) {
+	found, err := set.has(member)
+
+	if found {
+		err = set.txn.Del(set.space.elements, memberKey(set.prefix, member), nil)
+	}
+
+	if !found || hasError(err) {
+		return false, err
+	}
+
+	set.count--
+	return true, nil
+}
+
+func (set *tableSet) members() ([][]byte, error) {
+	items := make([][]byte, 0, set.count)
+
+	err := set.space.eachElement(set.txn, set.space.elements, set.prefix, set.prefix, func(suffix, entry []byte) bool {
+		member, _ := unpackEntry(set.prefix, suffix, entry)
+		items = append(items, clone(member))
+		return true
+	})
+
+	return items, err
+}
+
+func (set *tableSet) commit() error {
+	if set.count == emptyCount {
+		return set.space.del(set.txn, set.key)
+	}
+
+	meta := make([]byte, setMetaSize)
+	binary.LittleEndian.PutUint64(meta, uint64(set.count))
+
+	return set.space.put(set.txn, set.key, kindSet, encodingTable, meta)
+}
@@ -2,7 +2,6 @@ package storage
 
 import (
 	"context"
-	"encoding/binary"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
 )
@@ -23,42 +22,14 @@ func (client *Client) SIsMember(ctx context.Context, key, member []byte) bool {
 
 	var found bool
 
-	err = client.env.View(func(txn *lmdb.Txn) error {
-		data, txnErr := txn.Get(db, key)
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		set, txnErr := db.openSet(txn, key, false)
 		if hasError(txnErr) {
 			return nil
 		}
 
-		if !hasValidSetHeader(data) {
-			return nil
-		}
-
-		count := int64(binary.LittleEndian.Uint64(data[:setHeaderSize]))
-		offset := setHeaderSize
-		memberStr := string(member)
-
-		for i := int64(firstElement); i < count; i++ {
-			if offset+itemLengthSize > len(data) {
-				return nil
-			}
-
-			memberLen := int(binary.LittleEndian.Uint32(data[offset:]))
-			offset += itemLengthSize
-
-			if offset+memberLen > len(data) {
-				return nil
-			}
-
-			existingMember := string(data[offset : offset+memberLen])
-			if existingMember == memberStr {
-				found = true
-				return nil
-			}
-
-			offset += memberLen
-		}
-
-		return nil
+		found, txnErr = set.has(member)
+		return txnErr
 	})
 
 	if hasError(err) {
@@ -2,7 +2,6 @@ package storage
 
 import (
 	"context"
-	"encoding/binary"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
 )
@@ -23,38 +22,18 @@ func (client *Client) SMembers(ctx context.Context, key []byte) ([][]byte, error
 
 	var result [][]byte
 
-	err = client.env.View(func(txn *lmdb.Txn) error {
-		data, txnErr := txn.Get(db, key)
-		if hasError(txnErr) {
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		set, txnErr := db.openSet(txn, key, false)
+		if isNotFound(txnErr) {
 			return nil
 		}
 
-		if !hasValidSetHeader(data) {
-			return nil
-		}
-
-		count := int64(binary.LittleEndian.Uint64(data[:setHeaderSize]))
-		offset := setHeaderSize
-
-		for i := int64(firstElement); i < count; i++ {
-			if offset+itemLengthSize > len(data) {
-				return ErrKeyNotFound
-			}
-
-			memberLen := int(binary.LittleEndian.Uint32(data[offset:]))
-			offset += itemLengthSize
-
-			if offset+memberLen > len(data) {
-				return ErrKeyNotFound
-			}
-
-			member := make([]byte, memberLen)
-			copy(member, data[offset:offset+memberLen])
-			result = append(result, member)
-			offset += memberLen
+		if hasError(txnErr) {
+			return txnErr
 		}
 
-		return nil
+		result, txnErr = set.members()
+		return txnErr
 	})
 
 	if hasError(err) {
@@ -1,7 +1,9 @@
 package storage_test
 
 import (
+	"bytes"
 	"context"
+	"math"
 	"os"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -132,6 +134,28 @@ var _ = Describe("Sorted Set Storage Commands", func() {
 		})
 	})
 
+	Describe("ZRangeWithScores", func() {
+		BeforeEach(func() {
+			client.ZAdd(ctx, []byte("zset"), 1.5, []byte("member1"))
+			client.ZAdd(ctx, []byte("zset"), 2.0, []byte("member2"))
+			client.ZAdd(ctx, []byte("zset"), -3.0, []byte("member3"))
+		})
+
+		It("should return members with their scores in rank order", func() {
+			members, scores, err := client.ZRangeWithScores(ctx, []byte("zset"), 0, -1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([][]byte{[]byte("member3"), []byte("member1"), []byte("member2")}))
+			Expect(scores).To(Equal([]float64{-3.0, 1.5, 2.0}))
+		})
+
+		It("should return empty slices for non-existent sorted set", func() {
+			members, scores, err := client.ZRangeWithScores(ctx, []byte("nonexistent"), 0, -1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(BeEmpty())
+			Expect(scores).To(BeEmpty())
+		})
+	})
+
 	Describe("ZCount", func() {
 		BeforeEach(func() {
 			client.ZAdd(ctx, []byte("zset"), 1.0, []byte("member1"))
@@ -227,4 +251,63 @@ var _ = Describe("Sorted Set Storage Commands", func() {
 			Expect(count).To(Equal(int64(3)))
 		})
 	})
+
+	Describe("Per-element layout", func() {
+		BeforeEach(func() {
+			client.SetMaxPackedEntries(4)
+		})
+
+		It("should convert past the threshold and keep score order", func() {
+			scores := map[string]float64{"a": 3, "b": -1.5, "c": 0, "d": 10, "e": -20, "f": 2}
+			for member, score := range scores {
+				client.ZAdd(ctx, []byte("zset"), score, []byte(member))
+			}
+
+			encoding, err := client.Encoding(ctx, []byte("zset"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoding).To(Equal("skiplist"))
+
+			members, err := client.ZRange(ctx, []byte("zset"), 0, -1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([][]byte{
+				[]byte("e"), []byte("b"), []byte("c"), []byte("f"), []byte("a"), []byte("d"),
+			}))
+
+			Expect(client.ZAdd(ctx, []byte("zset"), 100, []byte("e"))).To(Equal(int64(0)))
+
+			members, err = client.ZRange(ctx, []byte("zset"), 1, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([][]byte{[]byte("c"), []byte("f")}))
+
+			members, err = client.ZRange(ctx, []byte("zset"), -1, -1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([][]byte{[]byte("e")}))
+
+			Expect(client.ZCount(ctx, []byte("zset"), -1.5, 3)).To(Equal(int64(4)))
+			Expect(client.ZCount(ctx, []byte("zset"), math.Inf(-1), math.Inf(1))).To(Equal(int64(6)))
+			Expect(client.ZCount(ctx, []byte("zset"), 5, 1)).To(BeZero())
+
+			members, ranked, err := client.ZRangeWithScores(ctx, []byte("zset"), 0, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([][]byte{[]byte("b"), []byte("c")}))
+			Expect(ranked).To(Equal([]float64{-1.5, 0}))
+		})
+
+		It("should keep members longer than an LMDB key in both encodings", func() {
+			long := bytes.Repeat([]byte("m"), 520)
+
+			Expect(client.ZAdd(ctx, []byte("zset"), 5, long)).To(Equal(int64(1)))
+			for score, member := range []string{"a", "b", "c", "d"} {
+				client.ZAdd(ctx, []byte("zset"), float64(score), []byte(member))
+			}
+
+			Expect(client.Encoding(ctx, []byte("zset"))).To(Equal("skiplist"))
+			Expect(client.ZAdd(ctx, []byte("zset"), 1.5, long)).To(Equal(int64(0)))
+
+			members, ranked, err := client.ZRangeWithScores(ctx, []byte("zset"), 0, -1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([][]byte{[]byte("a"), []byte("b"), long, []byte("c"), []byte("d")}))
+			Expect(ranked).To(Equal([]float64{0, 1, 1.5, 2, 3}))
+		})
+	})
 })
@@ -2,63 +2,44 @@ package storage
 
 import (
 	"context"
-	"encoding/binary"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
 )
 
-func (client *Client) SRem(ctx context.Context, key []byte, members ...[]byte) int64 {
+func (client *Client) SRem(ctx context.Context, key []byte, members ...[]byte) (int64, error) {
 	if hasError(ctxFlush(ctx)) {
-		return emptyCount
+		return emptyCount, ctx.Err()
 	}
 
 	if isEmpty(key) || isEmpty(members) {
-		return emptyCount
+		return emptyCount, nil
 	}
 
 	db, err := client.sel(ctx)
 	if hasError(err) {
-		return emptyCount
+		return emptyCount, err
 	}
 
 	var removedCount int64
 
-	err = client.env.Update(func(txn *lmdb.Txn) error {
-		data, txnErr := txn.Get(db, key)
-		if hasError(txnErr) {
+	err = client.update(ctx, func(txn *lmdb.Txn) error {
+		removedCount = 0
+		set, txnErr := db.openSet(txn, key, true)
+		if isNotFound(txnErr) {
 			return nil
 		}
 
-		if !hasValidSetHeader(data) {
-			return nil
+		if hasError(txnErr) {
+			return txnErr
 		}
 
-		existingMembers := make(map[string]bool)
-		count := int64(binary.LittleEndian.Uint64(data[:setHeaderSize]))
-		offset := setHeaderSize
-
-		for i := int64(firstElement); i < count; i++ {
-			if offset+itemLengthSize > len(data) {
-				break
-			}
-
-			memberLen := int(binary.LittleEndian.Uint32(data[offset:]))
-			offset += itemLengthSize
-
-			if offset+memberLen > len(data) {
-				break
+		for _, member := range members {
+			removed, removeErr := set.remove(member)
+			if hasError(removeErr) {
+				return removeErr
 			}
 
-			member := string(data[offset : offset+memberLen])
-			existingMembers[member] = true
-			offset += memberLen
-		}
-
-		membersToRemove := make(map[string]bool)
-		for _, member := range members {
-			memberStr := string(member)
-			if existingMembers[memberStr] {
-				membersToRemove[memberStr] = true
+			if removed {
 				removedCount++
 			}
 		}
@@ -67,31 +48,12 @@ func (client *Client) SRem(ctx context.Context, key []byte, members ...[]byte) i
 			return nil
 		}
 
-		for memberStr := range membersToRemove {
-			delete(existingMembers, memberStr)
-		}
-
-		if len(existingMembers) == emptyCount {
-			return txn.Del(db, key, nil)
-		}
-
-		newData := make([]byte, setHeaderSize)
-		newCount := int64(len(existingMembers))
-		binary.LittleEndian.PutUint64(newData[:setHeaderSize], uint64(newCount))
-
-		for memberStr := range existingMembers {
-			memberBytes := []byte(memberStr)
-			newData = append(newData, make([]byte, itemLengthSize)...)
-			binary.LittleEndian.PutUint32(newData[len(newData)-itemLengthSize:], uint32(len(memberBytes)))
-			newData = append(newData, memberBytes...)
-		}
-
-		return txn.Put(db, key, newData, noFlags)
+		return set.commit()
 	})
 
 	if hasError(err) {
-		return emptyCount
+		return emptyCount, err
 	}
 
-	return removedCount
+	return removedCount, nil
 }
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+func (client *Client) viewString(ctx context.Context, key []byte, fn func(payload []byte, found bool) error) error {
+	if hasError(ctxFlush(ctx)) {
+		return ctx.Err()
+	}
+
+	db, err := client.sel(ctx)
+	if hasError(err) {
+		return err
+	}
+
+	return client.view(ctx, func(txn *lmdb.Txn) error {
+		payload, txnErr := db.read(txn, key, kindString)
+
+		if isNotFound(txnErr) {
+			return fn(nil, false)
+		}
+
+		if hasError(txnErr) {
+			return txnErr
+		}
+
+		return fn(payload, true)
+	})
+}
+
+// updateString keeps the deadline; a nil value from fn leaves the key untouched.
+func (client *Client) updateString(ctx context.Context, key []byte, fn func(payload []byte, found bool) ([]byte, error)) error {
+	if hasError(ctxFlush(ctx)) {
+		return ctx.Err()
+	}
+
+	db, err := client.sel(ctx)
+	if hasError(err) {
+		return err
+	}
+
+	return client.update(ctx, func(txn *lmdb.Txn) error {
+		payload, txnErr := db.load(txn, key, kindString)
+		found := noError(txnErr)
+
+		if hasError(txnErr) && !isNotFound(txnErr) {
+			return txnErr
+		}
+
+		data, txnErr := fn(payload, found)
+
+		if hasError(txnErr) || data == nil {
+			return txnErr
+		}
+
+		return db.put(txn, key, kindString, encodingRaw, data)
+	})
+}
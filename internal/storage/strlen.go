@@ -0,0 +1,14 @@
+package storage
+
+import "context"
+
+func (client *Client) StrLen(ctx context.Context, key []byte) (int64, error) {
+	var length int64
+
+	err := client.viewString(ctx, key, func(payload []byte, _ bool) error {
+		length = int64(len(payload))
+		return nil
+	})
+
+	return length, err
+}
@@ -4,31 +4,39 @@ import (
 	"context"
 	"time"
 
-	"github.com/luiz-simples/keyp.git/internal/domain"
+	"github.com/PowerDNS/lmdb-go/lmdb"
 )
 
-func (client *Client) TTL(ctx context.Context, keyBytes []byte) uint32 {
-	client.mtx.RLock()
-	defer client.mtx.RUnlock()
-
-	db, _ := ctx.Value(domain.DB).(uint8)
-	key := string(keyBytes)
-	keys, hasKeys := client.ttl[db]
-
-	if !hasKeys {
+func (client *Client) TTL(ctx context.Context, key []byte) uint32 {
+	if hasError(ctxFlush(ctx)) {
 		return 0
 	}
 
-	ttl := keys[key]
+	if isEmpty(key) {
+		return 0
+	}
 
-	if ttl == nil {
+	db, err := client.sel(ctx)
+	if hasError(err) {
 		return 0
 	}
 
-	now := uint32(time.Now().Unix())
-	if ttl.Expire <= now {
+	var remaining int64
+
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		deadline, hasDeadline := db.deadline(txn, key)
+
+		if !hasDeadline {
+			return nil
+		}
+
+		remaining = deadline - time.Now().UnixMilli()
+		return nil
+	})
+
+	if hasError(err) || remaining <= 0 {
 		return 0
 	}
 
-	return ttl.Expire - now
+	return uint32((remaining + 500) / 1000)
 }
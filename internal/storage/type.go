@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+func (client *Client) Type(ctx context.Context, key []byte) string {
+	kind := kindNone
+
+	if hasError(ctxFlush(ctx)) || isEmpty(key) {
+		return kindName(kind)
+	}
+
+	db, err := client.sel(ctx)
+	if hasError(err) {
+		return kindName(kind)
+	}
+
+	_ = client.view(ctx, func(txn *lmdb.Txn) error {
+		data, txnErr := db.peek(txn, key)
+
+		if noError(txnErr) {
+			kind = kindOf(data)
+		}
+
+		return txnErr
+	})
+
+	return kindName(kind)
+}
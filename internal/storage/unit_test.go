@@ -2,8 +2,14 @@ package storage_test
 
 import (
 	"context"
+	"encoding/binary"
+	"math"
+	"os"
+	"sync"
 	"time"
 
+	"github.com/PowerDNS/lmdb-go/lmdb"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -57,6 +63,351 @@ var _ = Describe("Storage Unit Tests", Label("unit"), func() {
 				newClient.Close()
 			})
 		})
+
+		Context("when creating a client with options", func() {
+			It("should honour the database count", func() {
+				tempDir := createUniqueTestDir("options")
+				defer cleanupTestDir(tempDir)
+
+				options := storage.DefaultOptions()
+				options.Databases = 2
+				options.SyncMode = domain.SyncAlways
+
+				newClient, err := storage.NewClientWithOptions(tempDir, options)
+				Expect(err).NotTo(HaveOccurred())
+				defer newClient.Close()
+
+				Expect(newClient.Set(context.WithValue(ctx, domain.DB, uint8(1)), []byte("key"), []byte("value"))).To(Succeed())
+
+				err = newClient.Set(context.WithValue(ctx, domain.DB, uint8(2)), []byte("key"), []byte("value"))
+				Expect(err).To(MatchError(storage.ErrDBIndex))
+			})
+
+			It("should reject invalid options", func() {
+				tempDir := createUniqueTestDir("invalid-options")
+				defer cleanupTestDir(tempDir)
+
+				options := storage.DefaultOptions()
+				options.SyncMode = "sometimes"
+
+				_, err := storage.NewClientWithOptions(tempDir, options)
+				Expect(err).To(MatchError(storage.ErrSyncMode))
+
+				options = storage.DefaultOptions()
+				options.Databases = 0
+
+				_, err = storage.NewClientWithOptions(tempDir, options)
+				Expect(err).To(MatchError(storage.ErrDBIndex))
+			})
+		})
+
+		Context("when reconfiguring a running client", func() {
+			It("should switch the sync mode and packed entry limit", func() {
+				options := storage.DefaultOptions()
+				options.SyncMode = domain.SyncAlways
+				options.MaxPackedEntries = 2
+
+				Expect(client.Configure(options)).To(Succeed())
+
+				client.RPush(ctx, []byte("list"), []byte("a"), []byte("b"), []byte("c"))
+				encoding, err := client.Encoding(ctx, []byte("list"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(encoding).To(Equal("quicklist"))
+
+				options.SyncMode = "sometimes"
+				Expect(client.Configure(options)).To(MatchError(storage.ErrSyncMode))
+			})
+		})
+
+		Context("when syncing to disk", func() {
+			It("should flush a running client and refuse once closed", func() {
+				before := client.LastSync()
+				Expect(client.Set(ctx, []byte("key"), []byte("value"))).To(Succeed())
+				Expect(client.Sync()).To(Succeed())
+				Expect(client.LastSync()).To(BeTemporally(">", before))
+
+				client.Close()
+				Expect(client.Sync()).To(MatchError(storage.ErrClosed))
+
+				_, err := client.Info(ctx)
+				Expect(err).To(MatchError(storage.ErrClosed))
+			})
+
+			It("should sync in the background with everysec", func() {
+				options := storage.DefaultOptions()
+				options.SyncMode = domain.SyncEverySec
+				Expect(client.Configure(options)).To(Succeed())
+
+				before := client.LastSync()
+				Eventually(client.LastSync, 3*time.Second).Should(BeTemporally(">", before))
+			})
+
+			It("should report now with always", func() {
+				options := storage.DefaultOptions()
+				options.SyncMode = domain.SyncAlways
+				Expect(client.Configure(options)).To(Succeed())
+
+				Expect(client.LastSync()).To(BeTemporally("~", time.Now(), time.Second))
+			})
+		})
+
+		Context("when writes from many connections are batched", func() {
+			It("should commit every write and return each caller its own result", func() {
+				options := storage.DefaultOptions()
+				options.MaxBatchSize = 16
+				options.MaxBatchWait = time.Millisecond
+				Expect(client.Configure(options)).To(Succeed())
+
+				client.SAdd(ctx, []byte("set"), []byte("member"))
+
+				var group sync.WaitGroup
+				failures := make(chan error, 64)
+
+				for range 64 {
+					group.Add(1)
+					go func() {
+						defer group.Done()
+						defer GinkgoRecover()
+
+						_, err := client.Incr(ctx, []byte("counter"))
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = client.Incr(ctx, []byte("set"))
+						failures <- err
+					}()
+				}
+
+				group.Wait()
+				close(failures)
+
+				for err := range failures {
+					Expect(err).To(MatchError(storage.ErrWrongType))
+				}
+
+				value, err := client.Get(ctx, []byte("counter"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(value)).To(Equal("64"))
+			})
+
+			It("should reply each caller once when a failed batch is retried", func() {
+				options := storage.DefaultOptions()
+				options.MaxBatchSize = 16
+				options.MaxBatchWait = 100 * time.Millisecond
+				Expect(client.Configure(options)).To(Succeed())
+
+				_, err := client.HSet(ctx, []byte("removed"), []byte("a"), []byte("1"), []byte("b"), []byte("2"))
+				Expect(err).NotTo(HaveOccurred())
+				client.SAdd(ctx, []byte("trimmed"), []byte("a"), []byte("b"))
+				Expect(client.Set(ctx, []byte("deleted"), []byte("value"))).To(Succeed())
+				Expect(client.Set(ctx, []byte("expiring"), []byte("value"))).To(Succeed())
+				client.Expire(ctx, []byte("expiring"), 60)
+
+				var group sync.WaitGroup
+				replies := make(map[string]int64)
+				var lock sync.Mutex
+
+				run := func(name string, write func() (int64, error)) {
+					group.Add(1)
+					go func() {
+						defer group.Done()
+						defer GinkgoRecover()
+
+						reply, err := write()
+						Expect(err).NotTo(HaveOccurred())
+
+						lock.Lock()
+						replies[name] = reply
+						lock.Unlock()
+					}()
+				}
+
+				run("hset", func() (int64, error) {
+					return client.HSet(ctx, []byte("hash"), []byte("a"), []byte("1"), []byte("b"), []byte("2"))
+				})
+				run("hdel", func() (int64, error) {
+					return client.HDel(ctx, []byte("removed"), []byte("a"), []byte("b"))
+				})
+				run("sadd", func() (int64, error) {
+					return client.SAdd(ctx, []byte("set"), []byte("a"), []byte("b"))
+				})
+				run("srem", func() (int64, error) {
+					return client.SRem(ctx, []byte("trimmed"), []byte("a"), []byte("b"))
+				})
+				run("del", func() (int64, error) {
+					deleted, err := client.Del(ctx, []byte("deleted"))
+					return int64(deleted), err
+				})
+				run("persist", func() (int64, error) {
+					if client.Persist(ctx, []byte("expiring")) {
+						return 1, nil
+					}
+
+					return 0, nil
+				})
+
+				time.Sleep(20 * time.Millisecond)
+				Expect(client.Set(ctx, make([]byte, 600), []byte("value"))).To(HaveOccurred())
+				group.Wait()
+
+				Expect(replies).To(Equal(map[string]int64{"hset": 2, "hdel": 2, "sadd": 2, "srem": 2, "del": 1, "persist": 1}))
+
+				length, err := client.HLen(ctx, []byte("hash"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(length).To(Equal(int64(2)))
+			})
+
+			It("should refuse writes once closed", func() {
+				Expect(client.Set(ctx, []byte("key"), []byte("value"))).To(Succeed())
+				client.Close()
+
+				Expect(client.Set(ctx, []byte("key"), []byte("value"))).To(MatchError(storage.ErrClosed))
+			})
+		})
+
+		Context("when opening a data directory without type tags", func() {
+			It("should migrate legacy values to tagged values", func() {
+				legacyDir := createUniqueTestDir("legacy")
+				defer cleanupTestDir(legacyDir)
+
+				legacyItems := func(items ...string) []byte {
+					data := binary.LittleEndian.AppendUint64(nil, uint64(len(items)))
+					for _, item := range items {
+						data = binary.LittleEndian.AppendUint32(data, uint32(len(item)))
+						data = append(data, item...)
+					}
+					return data
+				}
+
+				zset := make([]byte, 8)
+				binary.LittleEndian.PutUint64(zset, 1)
+				zset = binary.LittleEndian.AppendUint64(zset, math.Float64bits(1.5))
+				zset = binary.LittleEndian.AppendUint32(zset, 6)
+				zset = append(zset, "member"...)
+
+				Expect(os.MkdirAll(legacyDir, 0o755)).To(Succeed())
+				env, err := lmdb.NewEnv()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env.SetMaxDBs(4)).To(Succeed())
+				Expect(env.Open(legacyDir, 0, 0o644)).To(Succeed())
+				Expect(env.Update(func(txn *lmdb.Txn) error {
+					dbi, txnErr := txn.OpenDBI("db_0", lmdb.Create)
+					if txnErr != nil {
+						return txnErr
+					}
+
+					Expect(txn.Put(dbi, []byte("string"), []byte("hello world"), 0)).To(Succeed())
+					Expect(txn.Put(dbi, []byte("list"), legacyItems("a", "b", "a"), 0)).To(Succeed())
+					Expect(txn.Put(dbi, []byte("set"), legacyItems("x", "y"), 0)).To(Succeed())
+					Expect(txn.Put(dbi, []byte("queue"), legacyItems("job1", "job2"), 0)).To(Succeed())
+					return txn.Put(dbi, []byte("zset"), zset, 0)
+				})).To(Succeed())
+				env.Close()
+
+				migrated, err := storage.NewClient(legacyDir)
+				Expect(err).NotTo(HaveOccurred())
+				defer migrated.Close()
+
+				value, err := migrated.Get(ctx, []byte("string"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(value).To(Equal([]byte("hello world")))
+
+				items, err := migrated.LRange(ctx, []byte("list"), 0, -1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(items).To(Equal([][]byte{[]byte("a"), []byte("b"), []byte("a")}))
+
+				members, err := migrated.SMembers(ctx, []byte("set"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(members).To(ConsistOf([]byte("x"), []byte("y")))
+				Expect(migrated.SIsMember(ctx, []byte("set"), []byte("x"))).To(BeTrue())
+				Expect(migrated.SAdd(ctx, []byte("set"), []byte("x"), []byte("z"))).To(Equal(int64(1)))
+				Expect(migrated.Type(ctx, []byte("set"))).To(Equal("set"))
+				_, err = migrated.LRange(ctx, []byte("set"), 0, -1)
+				Expect(err).To(MatchError(storage.ErrWrongType))
+
+				Expect(migrated.Type(ctx, []byte("queue"))).To(Equal("list"))
+				items, err = migrated.LRange(ctx, []byte("queue"), 0, -1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(items).To(Equal([][]byte{[]byte("job1"), []byte("job2")}))
+				Expect(migrated.LPop(ctx, []byte("queue"))).To(Equal([]byte("job1")))
+				_, err = migrated.SAdd(ctx, []byte("queue"), []byte("job3"))
+				Expect(err).To(MatchError(storage.ErrWrongType))
+
+				members, err = migrated.ZRange(ctx, []byte("zset"), 0, -1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(members).To(Equal([][]byte{[]byte("member")}))
+				Expect(migrated.ZCount(ctx, []byte("zset"), 1, 2)).To(Equal(int64(1)))
+			})
+
+			It("should index the deadlines written before the expiry index", func() {
+				legacyDir := createUniqueTestDir("unindexed")
+				defer cleanupTestDir(legacyDir)
+
+				deadline := make([]byte, 8)
+				binary.BigEndian.PutUint64(deadline, uint64(time.Now().Add(-time.Second).UnixMilli()))
+
+				Expect(os.MkdirAll(legacyDir, 0o755)).To(Succeed())
+				env, err := lmdb.NewEnv()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(env.SetMaxDBs(4)).To(Succeed())
+				Expect(env.Open(legacyDir, 0, 0o644)).To(Succeed())
+				Expect(env.Update(func(txn *lmdb.Txn) error {
+					meta, txnErr := txn.OpenDBI("meta", lmdb.Create)
+					Expect(txnErr).NotTo(HaveOccurred())
+					data, txnErr := txn.OpenDBI("db_0", lmdb.Create)
+					Expect(txnErr).NotTo(HaveOccurred())
+					ttl, txnErr := txn.OpenDBI("ttl_0", lmdb.Create)
+					Expect(txnErr).NotTo(HaveOccurred())
+
+					Expect(txn.Put(meta, []byte("format"), []byte{1}, 0)).To(Succeed())
+					Expect(txn.Put(data, []byte("stale"), []byte{1, 0, 'v'}, 0)).To(Succeed())
+					return txn.Put(ttl, []byte("stale"), deadline, 0)
+				})).To(Succeed())
+				env.Close()
+
+				migrated, err := storage.NewClient(legacyDir)
+				Expect(err).NotTo(HaveOccurred())
+				defer migrated.Close()
+
+				Eventually(func() int64 {
+					info, infoErr := migrated.Info(ctx)
+					Expect(infoErr).NotTo(HaveOccurred())
+					return info.ExpiredKeys
+				}, 2*time.Second).Should(Equal(int64(1)))
+			})
+		})
+
+		Context("when keys expire without being read", func() {
+			It("should delete them in the active expiry cycle", func() {
+				for _, key := range []string{"a", "b", "c"} {
+					Expect(client.Set(ctx, []byte(key), []byte("value"))).To(Succeed())
+					client.Expire(ctx, []byte(key), 1)
+				}
+				Expect(client.Set(ctx, []byte("kept"), []byte("value"))).To(Succeed())
+
+				Eventually(func() int64 {
+					info, err := client.Info(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					return info.ExpiredKeys
+				}, 3*time.Second).Should(Equal(int64(3)))
+
+				info, err := client.Info(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.ExpireRuns).To(BeNumerically(">", 0))
+				Expect(info.ExpireTime).To(BeNumerically(">", 0))
+				Expect(info.Keyspaces).To(Equal([]domain.KeyspaceInfo{{DB: 0, Keys: 1, Expires: 0}}))
+			})
+
+			It("should keep keys whose deadline was cleared", func() {
+				Expect(client.Set(ctx, []byte("key"), []byte("value"))).To(Succeed())
+				client.Expire(ctx, []byte("key"), 1)
+				Expect(client.Persist(ctx, []byte("key"))).To(BeTrue())
+
+				Consistently(func() error {
+					_, err := client.Get(ctx, []byte("key"))
+					return err
+				}, 1500*time.Millisecond, 100*time.Millisecond).Should(Succeed())
+			})
+		})
 	})
 
 	Describe("Set Operation", func() {
@@ -2,22 +2,24 @@ package storage
 
 import (
 	"context"
-	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
+
+	"github.com/luiz-simples/keyp.git/internal/domain"
 )
 
 const (
-	listHeaderSize      = 8
-	setHeaderSize       = 8
 	sortedSetHeaderSize = 8
 	itemLengthSize      = 4
 	scoreSize           = 8
 
 	integerSize = 8
+	zeroFloat   = "0"
 
 	firstElement = 0
 	emptyCount   = 0
@@ -69,16 +71,9 @@ func isNotFound(err error) bool {
 	return err == ErrKeyNotFound
 }
 
-func hasValidListHeader(data []byte) bool {
-	return len(data) >= listHeaderSize
-}
-
-func hasValidSetHeader(data []byte) bool {
-	return len(data) >= setHeaderSize
-}
-
-func hasValidSortedSetHeader(data []byte) bool {
-	return len(data) >= sortedSetHeaderSize
+func isOpError(err error) bool {
+	var opErr *lmdb.OpError
+	return errors.As(err, &opErr) && !lmdb.IsNotFound(err)
 }
 
 func isScoreInRange(score, min, max float64) bool {
@@ -93,10 +88,6 @@ func isIndexOutOfBounds(index, length int64) bool {
 	return index < 0 || index >= length
 }
 
-func hasInvalidListHeader(data []byte) bool {
-	return len(data) < integerSize
-}
-
 func hasInsufficientData(data []byte, offset, size int) bool {
 	return offset+size > len(data)
 }
@@ -110,164 +101,117 @@ func ctxFlush(ctx context.Context) error {
 	}
 }
 
-func setTimeout(secs uint32, fn func()) func() {
-	running := true
-
-	time.AfterFunc(time.Duration(secs)*time.Second, func() {
-		if running {
-			fn()
-		}
-	})
-
-	return func() {
-		running = false
-	}
-}
-
-func isListData(data []byte) bool {
-	if len(data) < integerSize {
-		return false
-	}
-
-	length := int64(binary.LittleEndian.Uint64(data[:integerSize]))
-
-	if length < 0 {
-		return false
-	}
-
-	expectedSize := integerSize
-	offset := integerSize
-
-	for range length {
-		if offset+itemLengthSize > len(data) {
-			return false
-		}
-
-		itemLength := int(binary.LittleEndian.Uint32(data[offset : offset+itemLengthSize]))
-		offset += itemLengthSize
-
-		if offset+itemLength > len(data) {
-			return false
-		}
-
-		offset += itemLength
-		expectedSize += itemLengthSize + itemLength
-	}
-
-	return expectedSize == len(data)
-}
-
-func isStringData(data []byte) bool {
-	return !isListData(data) && !isSetData(data) && !isSortedSetData(data)
-}
-
-func isSetData(data []byte) bool {
-	return hasValidSetHeader(data)
-}
-
-func isSortedSetData(data []byte) bool {
-	return hasValidSortedSetHeader(data)
-}
-
-func (client *Client) checkKeyType(ctx context.Context, key []byte, expectedType string) error {
+func modifyIntegerBy(client *Client, ctx context.Context, key []byte, delta int64, operation func(int64, int64) (int64, bool)) (int64, error) {
 	if hasError(ctxFlush(ctx)) {
-		return ErrContextCanceled
+		return emptyCount, ErrContextCanceled
 	}
 
 	if isEmpty(key) {
-		return nil
+		return emptyCount, ErrKeyNotFound
 	}
 
 	db, err := client.sel(ctx)
 	if hasError(err) {
-		return err
+		return emptyCount, err
 	}
 
-	var checkErr error
-
-	err = client.env.View(func(txn *lmdb.Txn) error {
-		data, txnErr := txn.Get(db, key)
+	var result int64
 
-		if isNotFound(txnErr) {
-			return nil
-		}
+	err = client.update(ctx, func(txn *lmdb.Txn) error {
+		data, txnErr := db.load(txn, key, kindString)
 
-		if hasError(txnErr) {
+		if hasError(txnErr) && !isNotFound(txnErr) {
 			return txnErr
 		}
 
-		if expectedType == "list" && !isListData(data) {
-			checkErr = ErrWrongType
-			return nil
-		}
+		current := int64(emptyCount)
 
-		if expectedType == "string" && !isStringData(data) {
-			checkErr = ErrWrongType
-			return nil
-		}
+		if noError(txnErr) {
+			parsed, valid := domain.ParseInteger(data)
+			if !valid {
+				return ErrNotInteger
+			}
 
-		if expectedType == "set" && !isSetData(data) {
-			checkErr = ErrWrongType
-			return nil
+			current = parsed
 		}
 
-		if expectedType == "zset" && !isSortedSetData(data) {
-			checkErr = ErrWrongType
-			return nil
+		updated, inRange := operation(current, delta)
+		if !inRange {
+			return ErrOverflow
 		}
 
-		return nil
+		result = updated
+		return db.put(txn, key, kindString, encodingRaw, []byte(strconv.FormatInt(result, 10)))
 	})
 
 	if hasError(err) {
-		return err
+		return emptyCount, err
 	}
 
-	return checkErr
+	return result, nil
 }
 
-func modifyIntegerBy(client *Client, ctx context.Context, key []byte, delta int64, operation func(int64, int64) int64) (int64, error) {
-	if hasError(ctxFlush(ctx)) {
-		return emptyCount, ErrContextCanceled
+func normalizeIndex(index, length int64) (int64, bool) {
+	if isNegativeIndex(index) {
+		index = length + index
 	}
 
-	if isEmpty(key) {
-		return emptyCount, ErrKeyNotFound
+	return index, !isIndexOutOfBounds(index, length)
+}
+
+func normalizeRange(start, stop, length int64) (int64, int64, bool) {
+	if isNegativeIndex(start) {
+		start = length + start
 	}
 
-	db, err := client.sel(ctx)
-	if hasError(err) {
-		return emptyCount, err
+	if isNegativeIndex(stop) {
+		stop = length + stop
 	}
 
-	var result int64
+	start = max(start, firstElement)
+	stop = min(stop, length-singleItem)
 
-	err = client.env.Update(func(txn *lmdb.Txn) error {
-		data, txnErr := txn.Get(db, key)
+	return start, stop, start <= stop
+}
 
-		if isNotFound(txnErr) {
-			result = operation(emptyCount, delta)
-			newValue := strconv.FormatInt(result, 10)
-			return txn.Put(db, key, []byte(newValue), noFlags)
-		}
+func clone(data []byte) []byte {
+	return append(make([]byte, 0, len(data)), data...)
+}
 
-		if hasError(txnErr) {
-			return txnErr
-		}
+func addInt64(value, delta int64) (int64, bool) {
+	result := value + delta
+	overflow := (delta > 0 && result < value) || (delta < 0 && result > value)
+	return result, !overflow
+}
 
-		parsedValue, parseErr := strconv.ParseInt(string(data), 10, 64)
-		if hasError(parseErr) {
-			return ErrNotInteger
-		}
+func parseFloat(data []byte) (float64, bool) {
+	value, err := strconv.ParseFloat(string(data), 64)
+	return value, noError(err) && isFinite(value)
+}
 
-		result = operation(parsedValue, delta)
-		newValue := strconv.FormatInt(result, 10)
-		return txn.Put(db, key, []byte(newValue), noFlags)
-	})
+// addFloat adds in long double precision, as Redis does.
+func addFloat(value []byte, delta float64) ([]byte, error) {
+	sum := longDouble(value)
+	sum.Add(sum, longDouble([]byte(strconv.FormatFloat(delta, 'g', -1, 64))))
+
+	if result, _ := sum.Float64(); !isFinite(result) {
+		return nil, ErrNotFinite
+	}
+
+	return domain.FormatCounter(sum), nil
+}
+
+func longDouble(data []byte) *big.Float {
+	exact, _, err := big.ParseFloat(string(data), 10, domain.LongDoublePrecision, big.ToNearestEven)
 
 	if hasError(err) {
-		return emptyCount, err
+		value, _ := parseFloat(data)
+		return new(big.Float).SetPrec(domain.LongDoublePrecision).SetFloat64(value)
 	}
 
-	return result, nil
+	return exact
+}
+
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
 }
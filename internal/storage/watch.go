@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+const noWatch = 0
+
+type (
+	watchedKey struct {
+		db   uint8
+		key  []byte
+		live bool
+	}
+
+	watch struct {
+		keys  []watchedKey
+		dirty bool
+	}
+
+	watchers struct {
+		mtx     sync.Mutex
+		last    uint64
+		watches map[uint64]*watch
+		keys    map[string]map[uint64]struct{}
+	}
+)
+
+func watchKey(db uint8, key []byte) string {
+	var builder strings.Builder
+	builder.Grow(len(key) + 1)
+	builder.WriteByte(db)
+	builder.Write(key)
+	return builder.String()
+}
+
+func newWatchers() *watchers {
+	return &watchers{
+		watches: make(map[uint64]*watch),
+		keys:    make(map[string]map[uint64]struct{}),
+	}
+}
+
+func (registry *watchers) add(id uint64, keys []watchedKey) uint64 {
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+
+	current, found := registry.watches[id]
+
+	if !found {
+		registry.last++
+		id = registry.last
+		current = &watch{}
+		registry.watches[id] = current
+	}
+
+	for _, watched := range keys {
+		name := watchKey(watched.db, watched.key)
+		ids, hasIDs := registry.keys[name]
+
+		if !hasIDs {
+			ids = make(map[uint64]struct{})
+			registry.keys[name] = ids
+		}
+
+		ids[id] = struct{}{}
+		current.keys = append(current.keys, watched)
+	}
+
+	return id
+}
+
+func (registry *watchers) remove(id uint64) {
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+
+	current, found := registry.watches[id]
+
+	if !found {
+		return
+	}
+
+	for _, watched := range current.keys {
+		name := watchKey(watched.db, watched.key)
+		delete(registry.keys[name], id)
+
+		if len(registry.keys[name]) == emptyCount {
+			delete(registry.keys, name)
+		}
+	}
+
+	delete(registry.watches, id)
+}
+
+func (registry *watchers) touch(db uint8, key []byte) {
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+
+	for id := range registry.keys[watchKey(db, key)] {
+		registry.watches[id].dirty = true
+	}
+}
+
+func (registry *watchers) touchAll(db uint8) {
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+
+	for _, current := range registry.watches {
+		for _, watched := range current.keys {
+			if watched.db == db {
+				current.dirty = true
+				break
+			}
+		}
+	}
+}
+
+func (registry *watchers) snapshot(id uint64) ([]watchedKey, bool) {
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+
+	current, found := registry.watches[id]
+
+	if !found {
+		return nil, false
+	}
+
+	return current.keys, current.dirty
+}
+
+func (client *Client) Watch(ctx context.Context, id uint64, keys ...[]byte) (uint64, error) {
+	if hasError(ctxFlush(ctx)) {
+		return id, ctx.Err()
+	}
+
+	db, err := client.sel(ctx)
+	if hasError(err) {
+		return id, err
+	}
+
+	watched := make([]watchedKey, 0, len(keys))
+
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		for _, key := range keys {
+			_, peekErr := db.peek(txn, key)
+
+			if hasError(peekErr) && !isNotFound(peekErr) {
+				return peekErr
+			}
+
+			watched = append(watched, watchedKey{db: db.index, key: clone(key), live: noError(peekErr)})
+		}
+
+		return nil
+	})
+
+	if hasError(err) {
+		return id, err
+	}
+
+	return client.watches.add(id, watched), nil
+}
+
+func (client *Client) Unwatch(id uint64) {
+	if id == noWatch {
+		return
+	}
+
+	client.watches.remove(id)
+}
+
+func (client *Client) Modified(ctx context.Context, id uint64) bool {
+	if id == noWatch {
+		return false
+	}
+
+	keys, dirty := client.watches.snapshot(id)
+
+	if dirty {
+		return true
+	}
+
+	expired := false
+
+	_ = client.view(ctx, func(txn *lmdb.Txn) error {
+		for _, watched := range keys {
+			space, err := client.keyspace(watched.db)
+
+			if noError(err) && watched.live && space.expired(txn, watched.key) {
+				expired = true
+				return nil
+			}
+		}
+
+		return nil
+	})
+
+	return expired
+}
@@ -2,8 +2,6 @@ package storage
 
 import (
 	"context"
-	"encoding/binary"
-	"math"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
 )
@@ -24,11 +22,10 @@ func (client *Client) ZCount(ctx context.Context, key []byte, min, max float64)
 
 	var count int64
 
-	err = client.env.View(func(txn *lmdb.Txn) error {
-		data, txnErr := txn.Get(db, key)
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		zset, txnErr := db.openZSet(txn, key, false)
 
 		if isNotFound(txnErr) {
-			count = emptyCount
 			return nil
 		}
 
@@ -36,47 +33,8 @@ func (client *Client) ZCount(ctx context.Context, key []byte, min, max float64)
 			return txnErr
 		}
 
-		if !hasValidSortedSetHeader(data) {
-			count = emptyCount
-			return nil
-		}
-
-		totalCount := int64(binary.LittleEndian.Uint64(data[:sortedSetHeaderSize]))
-
-		if totalCount == emptyCount {
-			count = emptyCount
-			return nil
-		}
-
-		offset := sortedSetHeaderSize
-
-		for i := int64(firstElement); i < totalCount; i++ {
-			if offset+scoreSize > len(data) {
-				break
-			}
-
-			score := math.Float64frombits(binary.LittleEndian.Uint64(data[offset:]))
-			offset += scoreSize
-
-			if offset+itemLengthSize > len(data) {
-				break
-			}
-
-			memberLen := int(binary.LittleEndian.Uint32(data[offset:]))
-			offset += itemLengthSize
-
-			if offset+memberLen > len(data) {
-				break
-			}
-
-			if isScoreInRange(score, min, max) {
-				count++
-			}
-
-			offset += memberLen
-		}
-
-		return nil
+		count, txnErr = zset.count(min, max)
+		return txnErr
 	})
 
 	if hasError(err) {
@@ -2,18 +2,49 @@ package storage
 
 import (
 	"context"
-	"encoding/binary"
 
 	"github.com/PowerDNS/lmdb-go/lmdb"
 )
 
 func (client *Client) ZRange(ctx context.Context, key []byte, start, stop int64) ([][]byte, error) {
+	entries, err := client.zrange(ctx, key, start, stop)
+	if hasError(err) {
+		return nil, err
+	}
+
+	members := make([][]byte, 0, len(entries))
+
+	for _, entry := range entries {
+		members = append(members, []byte(entry.member))
+	}
+
+	return members, nil
+}
+
+func (client *Client) ZRangeWithScores(ctx context.Context, key []byte, start, stop int64) ([][]byte, []float64, error) {
+	entries, err := client.zrange(ctx, key, start, stop)
+	if hasError(err) {
+		return nil, nil, err
+	}
+
+	members := make([][]byte, 0, len(entries))
+	scores := make([]float64, 0, len(entries))
+
+	for _, entry := range entries {
+		members = append(members, []byte(entry.member))
+		scores = append(scores, entry.score)
+	}
+
+	return members, scores, nil
+}
+
+func (client *Client) zrange(ctx context.Context, key []byte, start, stop int64) ([]scoredMember, error) {
 	if hasError(ctxFlush(ctx)) {
 		return nil, ErrContextCanceled
 	}
 
 	if isEmpty(key) {
-		return [][]byte{}, nil
+		return nil, nil
 	}
 
 	db, err := client.sel(ctx)
@@ -21,13 +52,12 @@ func (client *Client) ZRange(ctx context.Context, key []byte, start, stop int64)
 		return nil, err
 	}
 
-	var result [][]byte
+	var result []scoredMember
 
-	err = client.env.View(func(txn *lmdb.Txn) error {
-		data, txnErr := txn.Get(db, key)
+	err = client.view(ctx, func(txn *lmdb.Txn) error {
+		zset, txnErr := db.openZSet(txn, key, false)
 
 		if isNotFound(txnErr) {
-			result = [][]byte{}
 			return nil
 		}
 
@@ -35,71 +65,13 @@ func (client *Client) ZRange(ctx context.Context, key []byte, start, stop int64)
 			return txnErr
 		}
 
-		if !hasValidSortedSetHeader(data) {
-			result = [][]byte{}
-			return nil
-		}
-
-		count := int64(binary.LittleEndian.Uint64(data[:sortedSetHeaderSize]))
-
-		if count == emptyCount {
-			result = [][]byte{}
+		first, last, inRange := normalizeRange(start, stop, zset.size())
+		if !inRange {
 			return nil
 		}
 
-		if isNegativeIndex(start) {
-			start = count + start
-		}
-
-		if isNegativeIndex(stop) {
-			stop = count + stop
-		}
-
-		if start < firstElement {
-			start = firstElement
-		}
-
-		if stop >= count {
-			stop = count - singleItem
-		}
-
-		if start > stop {
-			result = [][]byte{}
-			return nil
-		}
-
-		members := make([][]byte, firstElement)
-		offset := sortedSetHeaderSize
-
-		for i := int64(firstElement); i < count; i++ {
-			if offset+scoreSize > len(data) {
-				break
-			}
-
-			offset += scoreSize
-
-			if offset+itemLengthSize > len(data) {
-				break
-			}
-
-			memberLen := int(binary.LittleEndian.Uint32(data[offset:]))
-			offset += itemLengthSize
-
-			if offset+memberLen > len(data) {
-				break
-			}
-
-			if i >= start && i <= stop {
-				member := make([]byte, memberLen)
-				copy(member, data[offset:offset+memberLen])
-				members = append(members, member)
-			}
-
-			offset += memberLen
-		}
-
-		result = members
-		return nil
+		result, txnErr = zset.span(first, last)
+		return txnErr
 	})
 
 	if hasError(err) {
@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"cmp"
+	"encoding/binary"
+	"slices"
+
+	"github.com/PowerDNS/lmdb-go/lmdb"
+)
+
+const zsetMetaSize = integerSize
+
+type (
+	scoredMember struct {
+		score  float64
+		member string
+	}
+
+	zsetStore interface {
+		size() int64
+		add(float64, []byte) (bool, error)
+		span(int64, int64) ([]scoredMember, error)
+		count(float64, float64) (int64, error)
+		commit() error
+	}
+
+	packedZSet struct {
+		space   *keyspace
+		txn     *lmdb.Txn
+		key     []byte
+		entries []scoredMember
+	}
+
+	tableZSet struct {
+		space   *keyspace
+		txn     *lmdb.Txn
+		key     []byte
+		prefix  []byte
+		entries int64
+	}
+)
+
+func compareScored(left, right scoredMember) int {
+	if left.score == right.score {
+		return cmp.Compare(left.member, right.member)
+	}
+
+	return cmp.Compare(left.score, right.score)
+}
+
+func (space *keyspace) openZSet(txn *lmdb.Txn, key []byte, write bool) (zsetStore, error) {
+	encoding, payload, err := space.open(txn, key, kindZSet, write)
+	if hasError(err) {
+		return nil, err
+	}
+
+	if encoding == encodingTable {
+		return space.tableZSet(txn, key, payload)
+	}
+
+	entries, err := decodeScoredItems(payload)
+	if hasError(err) {
+		return nil, err
+	}
+
+	return &packedZSet{space: space, txn: txn, key: key, entries: entries}, nil
+}
+
+func (space *keyspace) createZSet(txn *lmdb.Txn, key []byte) (zsetStore, error) {
+	zset, err := space.openZSet(txn, key, true)
+
+	if isNotFound(err) {
+		return &packedZSet{space: space, txn: txn, key: key}, nil
+	}
+
+	return zset, err
+}
+
+func (space *keyspace) tableZSet(txn *lmdb.Txn, key, payload []byte) (*tableZSet, error) {
+	if len(payload) < zsetMetaSize {
+		return nil, ErrKeyNotFound
+	}
+
+	return &tableZSet{
+		space:   space,
+		txn:     txn,
+		key:     key,
+		prefix:  elementPrefix(key),
+		entries: int64(binary.LittleEndian.Uint64(payload)),
+	}, nil
+}
+
+func (zset *packedZSet) size() int64 {
+	return int64(len(zset.entries))
+}
+
+func (zset *packedZSet) add(score float64, member []byte) (bool, error) {
+	index := slices.IndexFunc(zset.entries, func(entry scoredMember) bool {
+		return entry.member == string(member)
+	})
+
+	added := index < firstElement
+
+	if added {
+		zset.entries = append(zset.entries, scoredMember{score: score, member: string(member)})
+	} else {
+		zset.entries[index].score = score
+	}
+
+	slices.SortFunc(zset.entries, compareScored)
+	return added, nil
+}
+
+func (zset *packedZSet) span(start, stop int64) ([]scoredMember, error) {
+	return slices.Clone(zset.entries[start : stop+singleItem]), nil
+}
+
+func (zset *packedZSet) count(min, max float64) (int64, error) {
+	var count int64
+
+	for _, entry := range zset.entries {
+		if isScoreInRange(entry.score, min, max) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (zset *packedZSet) commit() error {
+	if len(zset.entries) == emptyCount {
+		return zset.space.del(zset.txn, zset.key)
+	}
+
+	if zset.space.keepsPacked(zset.key, zset.size()) {
+		return zset.space.put(zset.txn, zset.key, kindZSet, encodingPacked, encodeScoredItems(zset.entries))
+	}
+
+	table := &tableZSet{space: zset.space, txn: zset.txn, key: zset.key, prefix: elementPrefix(zset.key)}
+
+	for _, entry := range zset.entries {
+		if _, err := table.add(entry.score, []byte(entry.member)); hasError(err) {
+			return err
+		}
+	}
+
+	return table.commit()
+}
+
+func (zset *tableZSet) size() int64 {
+	return zset.entries
+}
+
+func (zset *tableZSet) add(score float64, member []byte) (bool, error) {
+	current, found, err := zset.space.getMember(zset.txn, zset.space.elements, zset.prefix, member)
+	if hasError(err) {
+		return false, err
+	}
+
+	added := !found
+
+	if found {
+		previous := memberKey(elementKey(zset.prefix, encodeSortableScore(decodeScore(current))), member)
+
+		if err = zset.txn.Del(zset.space.scores, previous, nil); hasError(err) {
+			return false, err
+		}
+	}
+
+	if err = zset.space.putMember(zset.txn, zset.space.elements, zset.prefix, member, encodeScore(score)); hasError(err) {
+		return false, err
+	}
+
+	ranked := elementKey(zset.prefix, encodeSortableScore(score))
+	if err = zset.space.putMember(zset.txn, zset.space.scores, ranked, member, nil); hasError(err) {
+		return false, err
+	}
+
+	if added {
+		zset.entries++
+	}
+
+	return added, nil
+}
+
+func (zset *tableZSet) span(start, stop int64) ([]scoredMember, error) {
+	members := make([]scoredMember, 0, stop-start+singleItem)
+	var rank int64
+
+	err := zset.space.eachElement(zset.txn, zset.space.scores, zset.prefix, zset.prefix, func(suffix, entry []byte) bool {
+		if rank >= start {
+			member, _ := unpackEntry(zset.prefix, suffix, entry)
+
+			if !isDigestKey(zset.prefix, suffix) {
+				member = suffix[scoreSize:]
+			}
+
+			members = append(members, scoredMember{
+				score:  decodeSortableScore(suffix[:scoreSize]),
+				member: string(member),
+			})
+		}
+
+		rank++
+		return rank <= stop
+	})
+
+	return members, err
+}
+
+func (zset *tableZSet) count(min, max float64) (int64, error) {
+	var count int64
+	from := elementKey(zset.prefix, encodeSortableScore(min))
+
+	err := zset.space.eachElement(zset.txn, zset.space.scores, from, zset.prefix, func(suffix, _ []byte) bool {
+		if decodeSortableScore(suffix[:scoreSize]) > max {
+			return false
+		}
+
+		count++
+		return true
+	})
+
+	return count, err
+}
+
+func (zset *tableZSet) commit() error {
+	if zset.entries == emptyCount {
+		return zset.space.del(zset.txn, zset.key)
+	}
+
+	meta := make([]byte, zsetMetaSize)
+	binary.LittleEndian.PutUint64(meta, uint64(zset.entries))
+
+	return zset.space.put(zset.txn, zset.key, kindZSet, encodingTable, meta)
+}